@@ -0,0 +1,265 @@
+package astronomy
+
+import (
+	"math"
+	"time"
+)
+
+// Phase is one of the four principal phases of the moon
+type Phase int
+
+// Principal phases, in the order they occur in a lunation
+const (
+	NewMoon Phase = iota
+	FirstQuarter
+	FullMoon
+	LastQuarter
+)
+
+// String returns the English name of the phase
+func (p Phase) String() string {
+	return [...]string{"New Moon", "First Quarter", "Full Moon", "Last Quarter"}[p]
+}
+
+// Rise and set states of the moon that add to the crossing states
+const (
+	NoRise = "noRise" // Sets but does not rise within the period
+	NoSet  = "noSet"  // Rises but does not set within the period
+)
+
+// SynodicMonth is the mean length of a lunation in days
+const SynodicMonth = 29.530588861
+
+// MoonIllumination returns the illuminated fraction of the moon's disk (0 to
+// 1) and its elongation from the sun in degrees, from 0 at new moon through
+// 90 at first quarter, 180 at full moon and 270 at last quarter
+func MoonIllumination(t time.Time) (fraction, elongation float64) {
+	T := julianCentury(t)
+	D := 297.8501921 + T*(445267.1114034+T*(-0.0018819+T*(1.0/545868-T/113065000)))
+	M := 357.5291092 + T*(35999.0502909+T*(-0.0001536+T/24490000))
+	Mm := 134.9633964 + T*(477198.8675055+T*(0.0087414+T*(1.0/69699-T/14712000)))
+
+	// Phase angle, Meeus chapter 48
+	i := 180 - D - 6.289*sin(Mm) + 2.100*sin(M) - 1.274*sin(2*D-Mm) - 0.658*sin(2*D) - 0.214*sin(2*Mm) - 0.110*sin(D)
+
+	fraction = (1 + cos(i)) / 2
+	elongation = math.Mod(180-i, 360)
+	if elongation < 0 {
+		elongation += 360
+	}
+	return fraction, elongation
+}
+
+// NextPhase returns the first instant of a principal phase after t
+func NextPhase(t time.Time, phase Phase) time.Time {
+	k := math.Floor(lunationNumber(t)) - 1
+	for {
+		if at := phaseTime(k, phase); at.After(t) {
+			return at
+		}
+		k++
+	}
+}
+
+// PreviousPhase returns the last instant of a principal phase at or before t
+func PreviousPhase(t time.Time, phase Phase) time.Time {
+	k := math.Floor(lunationNumber(t)) + 1
+	for {
+		if at := phaseTime(k, phase); !at.After(t) {
+			return at
+		}
+		k--
+	}
+}
+
+// MoonAge returns the days since the last new moon
+func MoonAge(t time.Time) float64 {
+	return t.Sub(PreviousPhase(t, NewMoon)).Hours() / 24
+}
+
+// MoonRiseSet finds when the moon's upper limb crosses the horizon between
+// start and end. When only one event happens in the period the other is zero
+// and the state is NoRise or NoSet; when neither happens the state is
+// AlwaysAbove or AlwaysBelow.
+func MoonRiseSet(start, end time.Time, latitude, longitude float64) Crossing {
+	const step = 10 * time.Minute
+
+	// Altitude above the standard altitude for the moon's parallax, Meeus chapter 15
+	above := func(t time.Time) float64 {
+		altitude, parallax := moonAltitude(t, latitude, longitude)
+		return altitude - (0.7275*parallax - 0.5667)
+	}
+
+	c := Crossing{}
+	prev, prevAlt := start, above(start)
+	firstAbove := prevAlt > 0
+	for prev.Before(end) {
+		t := prev.Add(step)
+		if t.After(end) {
+			t = end
+		}
+		alt := above(t)
+
+		if (prevAlt > 0) != (alt > 0) {
+			// Bisect the crossing to the second
+			lo, hi := prev, t
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if (above(mid) > 0) == (prevAlt > 0) {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			if alt > 0 && c.Rise.IsZero() {
+				c.Rise = hi.Truncate(time.Second)
+			} else if alt <= 0 && c.Set.IsZero() {
+				c.Set = hi.Truncate(time.Second)
+			}
+		}
+		prev, prevAlt = t, alt
+	}
+
+	switch {
+	case !c.Rise.IsZero() && !c.Set.IsZero():
+		c.State = CrossingNormal
+	case !c.Set.IsZero():
+		c.State = NoRise
+	case !c.Rise.IsZero():
+		c.State = NoSet
+	case firstAbove:
+		c.State = AlwaysAbove
+	default:
+		c.State = AlwaysBelow
+	}
+	return c
+}
+
+// lunationNumber returns the approximate number of lunations since the new
+// moon of 6 January 2000, Meeus's k
+func lunationNumber(t time.Time) float64 {
+	return (julianDay(t) - 2451550.09766) / SynodicMonth
+}
+
+// phaseTime returns the instant of a principal phase in lunation k, using the
+// periodic terms of Meeus chapter 49 (accurate to well under a minute)
+func phaseTime(k float64, phase Phase) time.Time {
+	k += float64(phase) / 4
+	T := k / 1236.85
+
+	jde := 2451550.09766 + SynodicMonth*k + T*T*(0.00015437+T*(-0.000000150+T*0.00000000073))
+	E := 1 - T*(0.002516+0.0000074*T)
+	M := 2.5534 + 29.10535670*k - T*T*(0.0000014+0.00000011*T)
+	Mm := 201.5643 + 385.81693528*k + T*T*(0.0107582+T*(0.00001238-0.000000058*T))
+	F := 160.7108 + 390.67050284*k - T*T*(0.0016118+T*(0.00000227-0.000000011*T))
+	Om := 124.7746 - 1.56375588*k + T*T*(0.0020672+0.00000215*T)
+
+	var c float64
+	switch phase {
+	case NewMoon, FullMoon:
+		coef := [...]float64{-0.40720, 0.17241, 0.01608, 0.01039, 0.00739, -0.00514, 0.00208}
+		if phase == FullMoon {
+			coef = [...]float64{-0.40614, 0.17302, 0.01614, 0.01043, 0.00734, -0.00515, 0.00209}
+		}
+		c = coef[0]*sin(Mm) + coef[1]*E*sin(M) + coef[2]*sin(2*Mm) + coef[3]*sin(2*F) +
+			coef[4]*E*sin(Mm-M) + coef[5]*E*sin(Mm+M) + coef[6]*E*E*sin(2*M) -
+			0.00111*sin(Mm-2*F) - 0.00057*sin(Mm+2*F) + 0.00056*E*sin(2*Mm+M) -
+			0.00042*sin(3*Mm) + 0.00042*E*sin(M+2*F) + 0.00038*E*sin(M-2*F) -
+			0.00024*E*sin(2*Mm-M) - 0.00017*sin(Om) - 0.00007*sin(Mm+2*M) +
+			0.00004*sin(2*Mm-2*F) + 0.00004*sin(3*M) + 0.00003*sin(Mm+M-2*F) +
+			0.00003*sin(2*Mm+2*F) - 0.00003*sin(Mm+M+2*F) + 0.00003*sin(Mm-M+2*F) -
+			0.00002*sin(Mm-M-2*F) - 0.00002*sin(3*Mm+M) + 0.00002*sin(4*Mm)
+	default:
+		c = -0.62801*sin(Mm) + 0.17172*E*sin(M) - 0.01183*E*sin(Mm+M) +
+			0.00862*sin(2*Mm) + 0.00804*sin(2*F) + 0.00454*E*sin(Mm-M) +
+			0.00204*E*E*sin(2*M) - 0.00180*sin(Mm-2*F) - 0.00070*sin(Mm+2*F) -
+			0.00040*sin(3*Mm) - 0.00034*E*sin(2*Mm-M) + 0.00032*E*sin(M+2*F) +
+			0.00032*E*sin(M-2*F) - 0.00028*E*E*sin(Mm+2*M) + 0.00027*E*sin(2*Mm+M) -
+			0.00017*sin(Om) - 0.00005*sin(Mm-M-2*F) + 0.00004*sin(2*Mm+2*F) -
+			0.00004*sin(Mm+M+2*F) + 0.00004*sin(Mm-2*M) + 0.00003*sin(Mm+M-2*F) +
+			0.00003*sin(3*M) + 0.00002*sin(2*Mm-2*F) + 0.00002*sin(Mm-M+2*F) -
+			0.00002*sin(3*Mm+M)
+
+		w := 0.00306 - 0.00038*E*cos(M) + 0.00026*cos(Mm) - 0.00002*cos(Mm-M) + 0.00002*cos(Mm+M) + 0.00002*cos(2*F)
+		if phase == FirstQuarter {
+			c += w
+		} else {
+			c -= w
+		}
+	}
+
+	// Planetary arguments, common to all phases
+	planetary := [...][3]float64{
+		{0.000325, 299.77, 0.107408}, {0.000165, 251.88, 0.016321}, {0.000164, 251.83, 26.651886},
+		{0.000126, 349.42, 36.412478}, {0.000110, 84.66, 18.206239}, {0.000062, 141.74, 53.303771},
+		{0.000060, 207.14, 2.453732}, {0.000056, 154.84, 7.306860}, {0.000047, 34.52, 27.261239},
+		{0.000042, 207.19, 0.121824}, {0.000040, 291.34, 1.844379}, {0.000037, 161.72, 24.198154},
+		{0.000035, 239.56, 25.513099}, {0.000023, 331.55, 3.592518},
+	}
+	for i, p := range planetary {
+		arg := p[1] + p[2]*k
+		if i == 0 {
+			arg -= 0.009173 * T * T
+		}
+		c += p[0] * sin(arg)
+	}
+
+	return fromJulianDay(jde + c).Add(-deltaT(2000 + k/12.3685)).Truncate(time.Second)
+}
+
+// moonAltitude returns the geocentric altitude of the moon in degrees and its
+// horizontal parallax, using the low-precision series of the Astronomical
+// Almanac (about 0.3° in longitude)
+func moonAltitude(t time.Time, latitude, longitude float64) (altitude, parallax float64) {
+	T := julianCentury(t)
+
+	lambda := 218.32 + 481267.881*T + 6.29*sin(135.0+477198.87*T) - 1.27*sin(259.3-413335.36*T) +
+		0.66*sin(235.7+890534.22*T) + 0.21*sin(269.9+954397.74*T) - 0.19*sin(357.5+35999.05*T) -
+		0.11*sin(186.5+966404.03*T)
+	beta := 5.13*sin(93.3+483202.02*T) + 0.28*sin(228.2+960400.89*T) - 0.28*sin(318.3+6003.15*T) -
+		0.17*sin(217.6-407332.21*T)
+	parallax = 0.9508 + 0.0518*cos(135.0+477198.87*T) + 0.0095*cos(259.3-413335.36*T) +
+		0.0078*cos(235.7+890534.22*T) + 0.0028*cos(269.9+954397.74*T)
+
+	// Ecliptic to equatorial coordinates
+	eps := obliquity(T)
+	ra := deg(math.Atan2(sin(lambda)*cos(eps)-math.Tan(rad(beta))*sin(eps), cos(lambda)))
+	dec := deg(math.Asin(sin(beta)*cos(eps) + cos(beta)*sin(eps)*sin(lambda)))
+
+	// Local hour angle from Greenwich mean sidereal time
+	jd := julianDay(t)
+	gmst := 280.46061837 + 360.98564736629*(jd-2451545) + T*T*(0.000387933-T/38710000)
+	hourAngle := gmst + longitude - ra
+
+	altitude = deg(math.Asin(sin(latitude)*sin(dec) + cos(latitude)*cos(dec)*cos(hourAngle)))
+	return altitude, parallax
+}
+
+// fromJulianDay converts a Julian day number to an instant
+func fromJulianDay(jd float64) time.Time {
+	seconds := (jd - 2440587.5) * 86400
+	whole := math.Floor(seconds)
+	return time.Unix(int64(whole), int64((seconds-whole)*1e9)).UTC()
+}
+
+// deltaT estimates TT - UT for a decimal year, using the polynomials of
+// Espenak and Meeus
+func deltaT(year float64) time.Duration {
+	var seconds float64
+	switch t := year - 2000; {
+	case year >= 2005 && year < 2050:
+		seconds = 62.92 + t*(0.32217+t*0.005589)
+	case year >= 1986 && year < 2005:
+		seconds = 63.86 + t*(0.3345+t*(-0.060374+t*(0.0017275+t*(0.000651814+t*0.00002373599))))
+	case year >= 1961 && year < 1986:
+		t = year - 1975
+		seconds = 45.45 + t*(1.067+t*(-1.0/260-t/718))
+	case year >= 2050 && year < 2150:
+		u := (year - 1820) / 100
+		seconds = -20 + 32*u*u - 0.5628*(2150-year)
+	default:
+		u := (year - 1820) / 100
+		seconds = -20 + 32*u*u
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,95 @@
+package astronomy
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMoonPhases(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// Published times, to the minute
+	tests := []struct {
+		phase    Phase
+		expected string
+	}{
+		{NewMoon, "2024-01-11T11:57:00Z"},
+		{FirstQuarter, "2024-01-18T03:53:00Z"},
+		{FullMoon, "2024-01-25T17:54:00Z"},
+		{LastQuarter, "2024-01-04T03:30:00Z"},
+	}
+
+	for _, tt := range tests {
+		expected, _ := time.Parse(time.RFC3339, tt.expected)
+		got := NextPhase(from, tt.phase)
+		if d := got.Sub(expected); d <= -time.Minute || d >= time.Minute {
+			t.Errorf("%s: expected %s, got %s", tt.phase, expected, got)
+		}
+		if prev := PreviousPhase(got, tt.phase); !prev.Equal(got) {
+			t.Errorf("%s: previous phase at %s is %s", tt.phase, got, prev)
+		}
+		if prev := PreviousPhase(got.Add(-time.Second), tt.phase); got.Sub(prev) < 29*24*time.Hour {
+			t.Errorf("%s: expected the lunation before %s, got %s", tt.phase, got, prev)
+		}
+	}
+}
+
+func TestMoonIllumination(t *testing.T) {
+	tests := []struct {
+		at         time.Time
+		fraction   float64
+		elongation float64
+	}{
+		{time.Date(2024, time.January, 11, 11, 57, 0, 0, time.UTC), 0, 0},
+		{time.Date(2024, time.January, 18, 3, 53, 0, 0, time.UTC), 0.5, 90},
+		{time.Date(2024, time.January, 25, 17, 54, 0, 0, time.UTC), 1, 180},
+		{time.Date(2024, time.January, 4, 3, 30, 0, 0, time.UTC), 0.5, 270},
+	}
+
+	for _, tt := range tests {
+		fraction, elongation := MoonIllumination(tt.at)
+		if math.Abs(fraction-tt.fraction) > 0.01 {
+			t.Errorf("%s: expected illumination %.2f, got %.4f", tt.at, tt.fraction, fraction)
+		}
+		if d := math.Mod(elongation-tt.elongation+540, 360) - 180; math.Abs(d) > 1 {
+			t.Errorf("%s: expected elongation %.0f, got %.2f", tt.at, tt.elongation, elongation)
+		}
+	}
+
+	if age := MoonAge(time.Date(2024, time.January, 25, 17, 54, 0, 0, time.UTC)); math.Abs(age-14.25) > 0.01 {
+		t.Errorf("Expected an age of 14.25 days at full moon, got %.3f", age)
+	}
+}
+
+func TestMoonRiseSet(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+
+	// The moon rises about 50 minutes later each day, so once a month a
+	// day has no moonrise and another has no moonset
+	states := make(map[string][]int)
+	for day := 1; day <= 31; day++ {
+		start := time.Date(2024, time.March, day, 0, 0, 0, 0, london)
+		c := MoonRiseSet(start, start.AddDate(0, 0, 1), 51.5074, -0.1278)
+		states[c.State] = append(states[c.State], day)
+
+		if c.State == CrossingNormal && (c.Rise.Before(start) || c.Set.Before(start)) {
+			t.Errorf("March %d: events %s and %s before the day", day, c.Rise, c.Set)
+		}
+	}
+
+	if len(states[NoRise]) == 0 || len(states[NoSet]) == 0 || len(states[CrossingNormal]) < 25 {
+		t.Errorf("Unexpected states for March 2024: %v", states)
+	}
+
+	// New moon on 10 March rises and sets with the sun
+	start := time.Date(2024, time.March, 10, 0, 0, 0, 0, london)
+	c := MoonRiseSet(start, start.AddDate(0, 0, 1), 51.5074, -0.1278)
+	sun := Solar(start.Add(12*time.Hour), 51.5074, -0.1278).Sunrise
+	if d := c.Rise.Sub(sun.Rise); d < 0 || d > time.Hour {
+		t.Errorf("Expected moonrise shortly after sunrise %s, got %s", sun.Rise, c.Rise)
+	}
+	if d := c.Set.Sub(sun.Set); d < 0 || d > time.Hour {
+		t.Errorf("Expected moonset shortly after sunset %s, got %s", sun.Set, c.Set)
+	}
+}
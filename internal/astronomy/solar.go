@@ -0,0 +1,190 @@
+// Package astronomy computes the positions of the sun and moon and the times
+// of the events they define, such as sunrise, twilight and moon phases. All
+// functions work on instants and geographic coordinates in degrees (north and
+// east positive); turning them into local dates and zones is left to callers.
+package astronomy
+
+import (
+	"math"
+	"time"
+)
+
+// Sun altitudes, in degrees, that define the solar events
+const (
+	SunriseAltitude      = -0.833 // Upper limb on the horizon, with refraction
+	CivilTwilight        = -6.0
+	NauticalTwilight     = -12.0
+	AstronomicalTwilight = -18.0
+)
+
+// Crossing states of the sun relative to an altitude over one day
+const (
+	CrossingNormal = "normal"      // Rises above and sets below the altitude
+	AlwaysAbove    = "alwaysAbove" // Stays above the altitude all day
+	AlwaysBelow    = "alwaysBelow" // Stays below the altitude all day
+)
+
+// Crossing is the pair of times the sun passes an altitude on one day. Rise
+// and Set are zero unless State is CrossingNormal.
+type Crossing struct {
+	Rise  time.Time
+	Set   time.Time
+	State string
+}
+
+// SolarDay holds the solar events of the day around one solar noon
+type SolarDay struct {
+	Noon                 time.Time
+	NoonAltitude         float64 // Altitude of the sun's centre at noon, without refraction
+	Sunrise              Crossing
+	CivilTwilight        Crossing
+	NauticalTwilight     Crossing
+	AstronomicalTwilight Crossing
+}
+
+// DayLength returns the time between sunrise and sunset: 24 hours during the
+// polar day and zero during the polar night
+func (d SolarDay) DayLength() time.Duration {
+	switch d.Sunrise.State {
+	case AlwaysAbove:
+		return 24 * time.Hour
+	case AlwaysBelow:
+		return 0
+	}
+	return d.Sunrise.Set.Sub(d.Sunrise.Rise)
+}
+
+// Solar computes the solar events of the day whose solar noon is nearest to
+// near, using the NOAA solar calculator algorithms. Times are accurate to
+// about a minute between latitudes 72°N and 72°S and degrade towards the poles.
+func Solar(near time.Time, latitude, longitude float64) SolarDay {
+	// Solar noon, refined with the equation of time at noon itself
+	noon := solarNoon(near, near, longitude)
+	noon = solarNoon(noon, near, longitude)
+
+	day := SolarDay{
+		Noon:         noon.Truncate(time.Second),
+		NoonAltitude: 90 - math.Abs(latitude-sunDeclination(julianCentury(noon))),
+	}
+	day.Sunrise = crossing(noon, latitude, longitude, SunriseAltitude)
+	day.CivilTwilight = crossing(noon, latitude, longitude, CivilTwilight)
+	day.NauticalTwilight = crossing(noon, latitude, longitude, NauticalTwilight)
+	day.AstronomicalTwilight = crossing(noon, latitude, longitude, AstronomicalTwilight)
+	return day
+}
+
+// SunLongitude returns the sun's apparent ecliptic longitude in degrees, from
+// 0 at the March equinox through 90, 180 and 270 at the June solstice,
+// September equinox and December solstice
+func SunLongitude(t time.Time) float64 {
+	return math.Mod(math.Mod(sunApparentLongitude(julianCentury(t)), 360)+360, 360)
+}
+
+// solarNoon returns the solar noon at a longitude on the day nearest to near,
+// using the equation of time at the estimate
+func solarNoon(estimate, near time.Time, longitude float64) time.Time {
+	utc := near.UTC()
+	base := time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)
+	noon := base.Add(minutes(720 - 4*longitude - equationOfTime(julianCentury(estimate))))
+
+	// Keep the noon within half a day of the requested time
+	for noon.Sub(near) > 12*time.Hour {
+		noon = noon.Add(-24 * time.Hour)
+	}
+	for near.Sub(noon) > 12*time.Hour {
+		noon = noon.Add(24 * time.Hour)
+	}
+	return noon
+}
+
+// crossing finds when the sun passes an altitude before and after a solar
+// noon, refining each time with the sun's position at that time
+func crossing(noon time.Time, latitude, longitude, altitude float64) Crossing {
+	event := func(sign float64) (time.Time, string) {
+		t := noon
+		for i := 0; i < 4; i++ {
+			T := julianCentury(t)
+			cosH := (sin(altitude) - sin(latitude)*sin(sunDeclination(T))) / (cos(latitude) * cos(sunDeclination(T)))
+			switch {
+			case cosH > 1:
+				return time.Time{}, AlwaysBelow
+			case cosH < -1:
+				return time.Time{}, AlwaysAbove
+			}
+			hourAngle := deg(math.Acos(cosH))
+			t = solarNoon(t, noon, longitude).Add(minutes(sign * 4 * hourAngle))
+		}
+		return t, CrossingNormal
+	}
+
+	rise, riseState := event(-1)
+	set, setState := event(1)
+	if riseState != CrossingNormal || setState != CrossingNormal {
+		// Near the polar circles the refined times can disagree; the noon
+		// position decides
+		state := riseState
+		if state == CrossingNormal {
+			state = setState
+		}
+		return Crossing{State: state}
+	}
+	return Crossing{Rise: rise.Truncate(time.Second), Set: set.Truncate(time.Second), State: CrossingNormal}
+}
+
+// julianDay returns the Julian day number of an instant. Seconds and
+// nanoseconds are taken apart so that instants outside the range of
+// UnixNano, 1678 to 2262, convert too.
+func julianDay(t time.Time) float64 {
+	return (float64(t.Unix())+float64(t.Nanosecond())/1e9)/86400 + 2440587.5
+}
+
+// julianCentury returns Julian centuries since J2000.0
+func julianCentury(t time.Time) float64 {
+	return (julianDay(t) - 2451545) / 36525
+}
+
+// sunGeometry returns the sun's geometric mean longitude and anomaly and the
+// eccentricity of the earth's orbit
+func sunGeometry(T float64) (meanLongitude, meanAnomaly, eccentricity float64) {
+	meanLongitude = math.Mod(280.46646+T*(36000.76983+T*0.0003032), 360)
+	meanAnomaly = 357.52911 + T*(35999.05029-0.0001537*T)
+	eccentricity = 0.016708634 - T*(0.000042037+0.0000001267*T)
+	return meanLongitude, meanAnomaly, eccentricity
+}
+
+// obliquity returns the corrected obliquity of the ecliptic in degrees
+func obliquity(T float64) float64 {
+	mean := 23 + (26+(21.448-T*(46.815+T*(0.00059-T*0.001813)))/60)/60
+	return mean + 0.00256*cos(125.04-1934.136*T)
+}
+
+// sunApparentLongitude returns the sun's apparent ecliptic longitude in degrees
+func sunApparentLongitude(T float64) float64 {
+	L0, M, _ := sunGeometry(T)
+	center := sin(M)*(1.914602-T*(0.004817+0.000014*T)) + sin(2*M)*(0.019993-0.000101*T) + sin(3*M)*0.000289
+	return L0 + center - 0.00569 - 0.00478*sin(125.04-1934.136*T)
+}
+
+// sunDeclination returns the sun's declination in degrees
+func sunDeclination(T float64) float64 {
+	return deg(math.Asin(sin(obliquity(T)) * sin(sunApparentLongitude(T))))
+}
+
+// equationOfTime returns apparent minus mean solar time in minutes
+func equationOfTime(T float64) float64 {
+	L0, M, e := sunGeometry(T)
+	y := math.Pow(math.Tan(rad(obliquity(T))/2), 2)
+
+	eq := y*sin(2*L0) - 2*e*sin(M) + 4*e*y*sin(M)*cos(2*L0) - 0.5*y*y*sin(4*L0) - 1.25*e*e*sin(2*M)
+	return 4 * deg(eq)
+}
+
+// minutes converts fractional minutes to a duration
+func minutes(m float64) time.Duration {
+	return time.Duration(m * float64(time.Minute))
+}
+
+func rad(d float64) float64 { return d * math.Pi / 180 }
+func deg(r float64) float64 { return r * 180 / math.Pi }
+func sin(d float64) float64 { return math.Sin(rad(d)) }
+func cos(d float64) float64 { return math.Cos(rad(d)) }
@@ -0,0 +1,86 @@
+package astronomy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSolar(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	london, _ := time.LoadLocation("Europe/London")
+	oslo, _ := time.LoadLocation("Europe/Oslo")
+
+	tests := []struct {
+		name                string
+		near                time.Time
+		latitude, longitude float64
+		noon                string
+		sunrise, sunset     string
+		sunState            string
+		civilState          string
+		dayLength           time.Duration
+	}{
+		{
+			name:     "summer solstice in New York",
+			near:     time.Date(2024, time.June, 21, 12, 0, 0, 0, newYork),
+			latitude: 40.7128, longitude: -74.0060,
+			noon: "2024-06-21T12:58:00-04:00", sunrise: "2024-06-21T05:25:00-04:00", sunset: "2024-06-21T20:31:00-04:00",
+			sunState: CrossingNormal, civilState: CrossingNormal, dayLength: 15*time.Hour + 6*time.Minute,
+		},
+		{
+			name:     "equinox in London",
+			near:     time.Date(2024, time.March, 20, 12, 0, 0, 0, london),
+			latitude: 51.5074, longitude: -0.1278,
+			noon: "2024-03-20T12:08:00Z", sunrise: "2024-03-20T06:02:00Z", sunset: "2024-03-20T18:14:00Z",
+			sunState: CrossingNormal, civilState: CrossingNormal, dayLength: 12*time.Hour + 12*time.Minute,
+		},
+		{
+			name:     "midnight sun in Tromsø",
+			near:     time.Date(2024, time.June, 21, 12, 0, 0, 0, oslo),
+			latitude: 69.6492, longitude: 18.9553,
+			sunState: AlwaysAbove, civilState: AlwaysAbove, dayLength: 24 * time.Hour,
+		},
+		{
+			name:     "polar night in Tromsø",
+			near:     time.Date(2024, time.December, 21, 12, 0, 0, 0, oslo),
+			latitude: 69.6492, longitude: 18.9553,
+			sunState: AlwaysBelow, civilState: CrossingNormal, dayLength: 0,
+		},
+	}
+
+	// NOAA publishes times to the minute
+	near := func(got time.Time, want string) bool {
+		w, err := time.Parse(time.RFC3339, want)
+		if err != nil {
+			return false
+		}
+		d := got.Sub(w)
+		return d > -time.Minute && d < time.Minute
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := Solar(tt.near, tt.latitude, tt.longitude)
+
+			if day.Sunrise.State != tt.sunState || day.CivilTwilight.State != tt.civilState {
+				t.Fatalf("Expected sun %s and civil twilight %s, got %s and %s", tt.sunState, tt.civilState, day.Sunrise.State, day.CivilTwilight.State)
+			}
+			if tt.noon != "" && !near(day.Noon, tt.noon) {
+				t.Errorf("Expected solar noon near %s, got %s", tt.noon, day.Noon)
+			}
+			if tt.sunrise != "" && (!near(day.Sunrise.Rise, tt.sunrise) || !near(day.Sunrise.Set, tt.sunset)) {
+				t.Errorf("Expected sunrise %s and sunset %s, got %s and %s", tt.sunrise, tt.sunset, day.Sunrise.Rise, day.Sunrise.Set)
+			}
+			if d := day.DayLength() - tt.dayLength; d <= -time.Minute || d >= time.Minute {
+				t.Errorf("Expected day length near %s, got %s", tt.dayLength, day.DayLength())
+			}
+
+			// Darker twilights start earlier and end later
+			if day.CivilTwilight.State == CrossingNormal && day.Sunrise.State == CrossingNormal {
+				if !day.CivilTwilight.Rise.Before(day.Sunrise.Rise) || !day.CivilTwilight.Set.After(day.Sunrise.Set) {
+					t.Errorf("Civil twilight %+v does not surround the day %+v", day.CivilTwilight, day.Sunrise)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package calendars
+
+import "time"
+
+// Month names of the arithmetic calendars
+var (
+	islamicMonths  = [...]string{"Muharram", "Safar", "Rabi al-Awwal", "Rabi al-Thani", "Jumada al-Awwal", "Jumada al-Thani", "Rajab", "Shaban", "Ramadan", "Shawwal", "Dhu al-Qadah", "Dhu al-Hijjah"}
+	copticMonths   = [...]string{"Thout", "Paopi", "Hathor", "Koiak", "Tobi", "Meshir", "Paremhat", "Parmouti", "Pashons", "Paoni", "Epip", "Mesori", "Pi Kogi Enavot"}
+	ethiopicMonths = [...]string{"Meskerem", "Tikimt", "Hidar", "Tahsas", "Tir", "Yekatit", "Megabit", "Miyazya", "Ginbot", "Sene", "Hamle", "Nehase", "Pagume"}
+	indianMonths   = [...]string{"Chaitra", "Vaishakha", "Jyeshtha", "Ashadha", "Shravana", "Bhadra", "Ashvin", "Kartika", "Agrahayana", "Pausha", "Magha", "Phalguna"}
+)
+
+// Epochs of the arithmetic calendars, as fixed day numbers
+const (
+	islamicEpoch  = 227015 // 16 July 622, Julian
+	copticEpoch   = 103605 // 29 August 284, Julian
+	ethiopicEpoch = 2796   // 29 August 8, Julian
+)
+
+// islamicFromFixed converts a fixed day number to a date in the tabular
+// Islamic calendar, which has 11 leap years in each 30-year cycle
+func islamicFromFixed(fixed int) Date {
+	year := floorDiv(30*(fixed-islamicEpoch)+10646, 10631)
+	prior := fixed - fixedFromIslamicDate(year, 1, 1)
+	month := floorDiv(11*prior+330, 325)
+	day := fixed - fixedFromIslamicDate(year, month, 1) + 1
+	return Date{Calendar: IslamicCivil, Era: "AH", Year: year, Month: month, Day: day, MonthName: islamicMonths[month-1]}
+}
+
+// fixedFromIslamic converts a tabular Islamic date to a fixed day number
+func fixedFromIslamic(d Date) (int, error) {
+	return fixedFromIslamicDate(d.Year, d.Month, d.Day), nil
+}
+
+// fixedFromIslamicDate converts a tabular Islamic year, month and day to a
+// fixed day number
+func fixedFromIslamicDate(year, month, day int) int {
+	return day + 29*(month-1) + floorDiv(6*month-1, 11) + (year-1)*354 + floorDiv(3+11*year, 30) + islamicEpoch - 1
+}
+
+// copticFromFixed converts a fixed day number to a Coptic date
+func copticFromFixed(fixed int) Date {
+	year, month, day := alexandrianFromFixed(fixed, copticEpoch)
+	return Date{Calendar: Coptic, Era: "AM", Year: year, Month: month, Day: day, MonthName: copticMonths[month-1]}
+}
+
+// fixedFromCoptic converts a Coptic date to a fixed day number
+func fixedFromCoptic(d Date) (int, error) {
+	return fixedFromAlexandrian(d.Year, d.Month, d.Day, copticEpoch), nil
+}
+
+// ethiopicFromFixed converts a fixed day number to an Ethiopian date
+func ethiopicFromFixed(fixed int) Date {
+	year, month, day := alexandrianFromFixed(fixed, ethiopicEpoch)
+	return Date{Calendar: Ethiopic, Era: "EC", Year: year, Month: month, Day: day, MonthName: ethiopicMonths[month-1]}
+}
+
+// fixedFromEthiopic converts an Ethiopian date to a fixed day number
+func fixedFromEthiopic(d Date) (int, error) {
+	return fixedFromAlexandrian(d.Year, d.Month, d.Day, ethiopicEpoch), nil
+}
+
+// alexandrianFromFixed converts a fixed day number to a date in a calendar of
+// twelve 30-day months and five or six epagomenal days, as the Coptic and
+// Ethiopian calendars are
+func alexandrianFromFixed(fixed, epoch int) (year, month, day int) {
+	year = floorDiv(4*(fixed-epoch)+1463, 1461)
+	month = floorDiv(fixed-fixedFromAlexandrian(year, 1, 1, epoch), 30) + 1
+	day = fixed + 1 - fixedFromAlexandrian(year, month, 1, epoch)
+	return year, month, day
+}
+
+// fixedFromAlexandrian converts a Coptic or Ethiopian date to a fixed day
+// number
+func fixedFromAlexandrian(year, month, day, epoch int) int {
+	return epoch - 1 + 365*(year-1) + floorDiv(year, 4) + 30*(month-1) + day
+}
+
+// indianFromFixed converts a fixed day number to a date in the Indian
+// national calendar, whose years begin on 22 March, or 21 March in Gregorian
+// leap years
+func indianFromFixed(fixed int) Date {
+	gregorianYear, _, _ := GregorianDate(fixed)
+	year := gregorianYear - 78
+	start := indianNewYear(year)
+	if fixed < start {
+		year--
+		start = indianNewYear(year)
+	}
+
+	chaitra := 30
+	if isGregorianLeapYear(year + 78) {
+		chaitra = 31
+	}
+
+	var month, day int
+	switch days := fixed - start; {
+	case days < chaitra:
+		month, day = 1, days+1
+	case days-chaitra < 5*31:
+		days -= chaitra
+		month, day = days/31+2, days%31+1
+	default:
+		days -= chaitra + 5*31
+		month, day = days/30+7, days%30+1
+	}
+	return Date{Calendar: Indian, Era: "Saka", Year: year, Month: month, Day: day, MonthName: indianMonths[month-1]}
+}
+
+// fixedFromIndian converts an Indian national date to a fixed day number
+func fixedFromIndian(d Date) (int, error) {
+	fixed := indianNewYear(d.Year) + d.Day - 1
+	chaitra := 30
+	if isGregorianLeapYear(d.Year + 78) {
+		chaitra = 31
+	}
+
+	switch {
+	case d.Month <= 1:
+		return fixed + 30*(d.Month-1), nil
+	case d.Month <= 6:
+		return fixed + chaitra + 31*(d.Month-2), nil
+	}
+	return fixed + chaitra + 5*31 + 30*(d.Month-7), nil
+}
+
+// indianNewYear returns the fixed day number of 1 Chaitra of a Saka year
+func indianNewYear(year int) int {
+	if isGregorianLeapYear(year + 78) {
+		return Fixed(year+78, time.March, 21)
+	}
+	return Fixed(year+78, time.March, 22)
+}
+
+// buddhistFromFixed converts a fixed day number to a Thai solar date, which
+// counts years of the Buddhist Era, 543 years before the Common Era
+func buddhistFromFixed(fixed int) Date {
+	year, month, day := GregorianDate(fixed)
+	return Date{Calendar: Buddhist, Era: "BE", Year: year + 543, Month: int(month), Day: day, MonthName: month.String()}
+}
+
+// fixedFromBuddhist converts a Thai solar date to a fixed day number
+func fixedFromBuddhist(d Date) (int, error) {
+	return Fixed(d.Year-543, time.Month(d.Month), d.Day), nil
+}
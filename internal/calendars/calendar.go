@@ -0,0 +1,239 @@
+// Package calendars converts dates between the Gregorian calendar and other
+// calendar systems. Dates are exchanged as fixed day numbers (Rata Die): day 1
+// is 1 January of year 1 in the proleptic Gregorian calendar. The arithmetic
+// follows Dershowitz and Reingold's Calendrical Calculations; the Chinese and
+// Umm al-Qura calendars are computed from the positions of the sun and moon.
+package calendars
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Calendar system IDs, as used by CLDR and ICU
+const (
+	Gregorian        = "gregorian"
+	Hebrew           = "hebrew"
+	IslamicCivil     = "islamic-civil"
+	IslamicUmmAlQura = "islamic-umalqura"
+	Persian          = "persian"
+	Chinese          = "chinese"
+	Japanese         = "japanese"
+	Buddhist         = "buddhist"
+	Ethiopic         = "ethiopic"
+	Coptic           = "coptic"
+	Indian           = "indian"
+)
+
+// aliases maps other common names of calendar systems to their IDs
+var aliases = map[string]string{
+	"islamic":         IslamicCivil,
+	"islamic-tabular": IslamicCivil,
+	"hijri":           IslamicCivil,
+	"umalqura":        IslamicUmmAlQura,
+	"umm-al-qura":     IslamicUmmAlQura,
+	"solar-hijri":     Persian,
+	"jalali":          Persian,
+	"thai":            Buddhist,
+	"ethiopian":       Ethiopic,
+	"saka":            Indian,
+}
+
+// Date is a date in a calendar system. Era is the name of a Japanese era or
+// the abbreviation of the calendar's era, such as "AH"; YearName is the
+// sexagenary name of a Chinese year.
+type Date struct {
+	Calendar  string
+	Era       string
+	Year      int
+	Month     int
+	LeapMonth bool
+	Day       int
+	MonthName string
+	YearName  string
+}
+
+// String formats the date in English, e.g. "1 Ramadan 1445 AH"
+func (d Date) String() string {
+	switch d.Calendar {
+	case Chinese:
+		return fmt.Sprintf("%s, day %d, %s (%d)", d.MonthName, d.Day, d.YearName, d.Year)
+	case Japanese:
+		return fmt.Sprintf("%d %s, %s %d", d.Day, d.MonthName, d.Era, d.Year)
+	}
+
+	s := fmt.Sprintf("%d %s %d", d.Day, d.MonthName, d.Year)
+	if d.Era != "" {
+		s += " " + d.Era
+	}
+	return s
+}
+
+// system is one calendar system. Dates outside the Gregorian years minYear to
+// maxYear, where these are set, cannot be converted.
+type system struct {
+	fromFixed func(fixed int) Date
+	toFixed   func(d Date) (int, error)
+	minYear   int
+	maxYear   int
+}
+
+// systems lists the supported calendar systems by ID
+var systems = map[string]system{
+	Gregorian:        {fromFixed: gregorianFromFixed, toFixed: fixedFromGregorianDate},
+	Hebrew:           {fromFixed: hebrewFromFixed, toFixed: fixedFromHebrew},
+	IslamicCivil:     {fromFixed: islamicFromFixed, toFixed: fixedFromIslamic},
+	IslamicUmmAlQura: {fromFixed: ummAlQuraFromFixed, toFixed: fixedFromUmmAlQura, minYear: 1900, maxYear: 2200},
+	Persian:          {fromFixed: persianFromFixed, toFixed: fixedFromPersian, minYear: 561, maxYear: 3798},
+	Chinese:          {fromFixed: chineseFromFixed, toFixed: fixedFromChinese, minYear: 1645, maxYear: 2600},
+	Japanese:         {fromFixed: japaneseFromFixed, toFixed: fixedFromJapanese, minYear: 1873},
+	Buddhist:         {fromFixed: buddhistFromFixed, toFixed: fixedFromBuddhist},
+	Ethiopic:         {fromFixed: ethiopicFromFixed, toFixed: fixedFromEthiopic},
+	Coptic:           {fromFixed: copticFromFixed, toFixed: fixedFromCoptic},
+	Indian:           {fromFixed: indianFromFixed, toFixed: fixedFromIndian},
+}
+
+// IDs returns the IDs of the supported calendar systems, sorted
+func IDs() []string {
+	ids := make([]string, 0, len(systems))
+	for id := range systems {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Lookup resolves a calendar system name or alias, case-insensitively, to its
+// ID
+func Lookup(name string) (string, bool) {
+	id := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := aliases[id]; ok {
+		id = alias
+	}
+	_, ok := systems[id]
+	return id, ok
+}
+
+// FromFixed converts a fixed day number to a date in a calendar system
+func FromFixed(calendar string, fixed int) (Date, error) {
+	id, ok := Lookup(calendar)
+	if !ok {
+		return Date{}, fmt.Errorf("unknown calendar system '%s'", calendar)
+	}
+	if err := checkRange(id, fixed); err != nil {
+		return Date{}, err
+	}
+	return systems[id].fromFixed(fixed), nil
+}
+
+// ToFixed converts a date in its calendar system to a fixed day number. Dates
+// that do not exist, such as 30 Iyar, are rejected.
+func ToFixed(d Date) (int, error) {
+	id, ok := Lookup(d.Calendar)
+	if !ok {
+		return 0, fmt.Errorf("unknown calendar system '%s'", d.Calendar)
+	}
+	d.Calendar = id
+
+	fixed, err := systems[id].toFixed(d)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkRange(id, fixed); err != nil {
+		return 0, err
+	}
+
+	// Out-of-range months and days roll over into other dates
+	back := systems[id].fromFixed(fixed)
+	if back.Year != d.Year || back.Month != d.Month || back.Day != d.Day || back.LeapMonth != d.LeapMonth {
+		return 0, fmt.Errorf("%s date %s does not exist", id, formatNumeric(d))
+	}
+	return fixed, nil
+}
+
+// checkRange rejects fixed day numbers outside a calendar system's supported
+// range of Gregorian years
+func checkRange(id string, fixed int) error {
+	sys := systems[id]
+	year, _, _ := GregorianDate(fixed)
+	switch {
+	case sys.minYear != 0 && sys.maxYear != 0 && (year < sys.minYear || year > sys.maxYear):
+		return fmt.Errorf("%s calendar supports only Gregorian years %d to %d", id, sys.minYear, sys.maxYear)
+	case sys.minYear != 0 && year < sys.minYear:
+		return fmt.Errorf("%s calendar supports only Gregorian years from %d", id, sys.minYear)
+	}
+	return nil
+}
+
+// formatNumeric formats a date as year-month-day, with the era and leap month
+func formatNumeric(d Date) string {
+	s := fmt.Sprintf("%d-%02d-%02d", d.Year, d.Month, d.Day)
+	if d.LeapMonth {
+		s = fmt.Sprintf("%d-%02dL-%02d", d.Year, d.Month, d.Day)
+	}
+	if d.Era != "" {
+		s = d.Era + " " + s
+	}
+	return s
+}
+
+// unixEpoch is the fixed day number of 1970-01-01
+const unixEpoch = 719163
+
+// Fixed returns the fixed day number of a civil date in the Gregorian calendar
+func Fixed(year int, month time.Month, day int) int {
+	days := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400
+	return int(days) + unixEpoch
+}
+
+// GregorianDate returns the civil date in the Gregorian calendar of a fixed
+// day number
+func GregorianDate(fixed int) (year int, month time.Month, day int) {
+	return time.Unix(int64(fixed-unixEpoch)*86400, 0).UTC().Date()
+}
+
+// Weekday returns the day of the week of a fixed day number
+func Weekday(fixed int) time.Weekday {
+	return time.Weekday(mod(fixed, 7))
+}
+
+// gregorianFromFixed converts a fixed day number to a Gregorian date
+func gregorianFromFixed(fixed int) Date {
+	year, month, day := GregorianDate(fixed)
+	return Date{Calendar: Gregorian, Year: year, Month: int(month), Day: day, MonthName: month.String()}
+}
+
+// fixedFromGregorianDate converts a Gregorian date to a fixed day number
+func fixedFromGregorianDate(d Date) (int, error) {
+	return Fixed(d.Year, time.Month(d.Month), d.Day), nil
+}
+
+// isGregorianLeapYear reports whether a Gregorian year has 366 days
+func isGregorianLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// mod returns x modulo y with the sign of y
+func mod(x, y int) int {
+	m := x % y
+	if m != 0 && (m < 0) != (y < 0) {
+		m += y
+	}
+	return m
+}
+
+// floorDiv returns x divided by y, rounded down
+func floorDiv(x, y int) int {
+	q := x / y
+	if (x%y != 0) && ((x < 0) != (y < 0)) {
+		q--
+	}
+	return q
+}
+
+// amod returns x modulo y in the range 1 to y
+func amod(x, y int) int {
+	return y + mod(x, -y)
+}
@@ -0,0 +1,122 @@
+package calendars
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConversions(t *testing.T) {
+	// Published dates in each calendar
+	tests := []struct {
+		gregorian string
+		date      Date
+		formatted string
+	}{
+		{"2024-10-03", Date{Calendar: Hebrew, Year: 5785, Month: 7, Day: 1}, "1 Tishrei 5785 AM"},
+		{"2024-04-23", Date{Calendar: Hebrew, Year: 5784, Month: 1, Day: 15}, "15 Nisan 5784 AM"},
+		{"2024-03-24", Date{Calendar: Hebrew, Year: 5784, Month: 13, Day: 14}, "14 Adar II 5784 AM"},
+		{"2023-12-08", Date{Calendar: Hebrew, Year: 5784, Month: 9, Day: 25}, "25 Kislev 5784 AM"},
+		{"2024-07-08", Date{Calendar: IslamicCivil, Year: 1446, Month: 1, Day: 1}, "1 Muharram 1446 AH"},
+		{"2024-03-11", Date{Calendar: IslamicUmmAlQura, Year: 1445, Month: 9, Day: 1}, "1 Ramadan 1445 AH"},
+		{"2024-04-10", Date{Calendar: IslamicUmmAlQura, Year: 1445, Month: 10, Day: 1}, "1 Shawwal 1445 AH"},
+		{"2024-07-07", Date{Calendar: IslamicUmmAlQura, Year: 1446, Month: 1, Day: 1}, "1 Muharram 1446 AH"},
+		{"2025-03-01", Date{Calendar: IslamicUmmAlQura, Year: 1446, Month: 9, Day: 1}, "1 Ramadan 1446 AH"},
+		{"2024-03-20", Date{Calendar: Persian, Year: 1403, Month: 1, Day: 1}, "1 Farvardin 1403 AP"},
+		{"2025-03-20", Date{Calendar: Persian, Year: 1403, Month: 12, Day: 30}, "30 Esfand 1403 AP"},
+		{"2024-02-10", Date{Calendar: Chinese, Year: 2024, Month: 1, Day: 1}, "Month 1, day 1, Jia-Chen year of the Dragon (2024)"},
+		{"2023-03-22", Date{Calendar: Chinese, Year: 2023, Month: 2, LeapMonth: true, Day: 1}, "Leap month 2, day 1, Gui-Mao year of the Rabbit (2023)"},
+		{"2025-07-25", Date{Calendar: Chinese, Year: 2025, Month: 6, LeapMonth: true, Day: 1}, "Leap month 6, day 1, Yi-Si year of the Snake (2025)"},
+		{"2025-01-28", Date{Calendar: Chinese, Year: 2024, Month: 12, Day: 29}, "Month 12, day 29, Jia-Chen year of the Dragon (2024)"},
+		{"2019-05-01", Date{Calendar: Japanese, Era: "Reiwa", Year: 1, Month: 5, Day: 1}, "1 May, Reiwa 1"},
+		{"2019-04-30", Date{Calendar: Japanese, Era: "Heisei", Year: 31, Month: 4, Day: 30}, "30 April, Heisei 31"},
+		{"2024-01-01", Date{Calendar: Buddhist, Year: 2567, Month: 1, Day: 1}, "1 January 2567 BE"},
+		{"2024-09-11", Date{Calendar: Ethiopic, Year: 2017, Month: 1, Day: 1}, "1 Meskerem 2017 EC"},
+		{"2024-09-11", Date{Calendar: Coptic, Year: 1741, Month: 1, Day: 1}, "1 Thout 1741 AM"},
+		{"2024-03-21", Date{Calendar: Indian, Year: 1946, Month: 1, Day: 1}, "1 Chaitra 1946 Saka"},
+		{"2024-09-23", Date{Calendar: Indian, Year: 1946, Month: 7, Day: 1}, "1 Ashvin 1946 Saka"},
+	}
+
+	for _, tt := range tests {
+		g, _ := time.Parse(time.DateOnly, tt.gregorian)
+		fixed := Fixed(g.Date())
+
+		got, err := FromFixed(tt.date.Calendar, fixed)
+		if err != nil {
+			t.Errorf("%s to %s: %v", tt.gregorian, tt.date.Calendar, err)
+			continue
+		}
+		if got.String() != tt.formatted {
+			t.Errorf("%s to %s: expected %q, got %q", tt.gregorian, tt.date.Calendar, tt.formatted, got.String())
+		}
+
+		back, err := ToFixed(tt.date)
+		if err != nil {
+			t.Errorf("%s from %s: %v", tt.gregorian, tt.date.Calendar, err)
+		} else if back != fixed {
+			y, m, d := GregorianDate(back)
+			t.Errorf("%s from %s: got %04d-%02d-%02d", tt.gregorian, tt.date.Calendar, y, m, d)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	// Every day of a few years converts back to itself
+	start, end := Fixed(2023, time.January, 1), Fixed(2026, time.January, 1)
+	for _, id := range IDs() {
+		for fixed := start; fixed < end; fixed += 7 {
+			d, err := FromFixed(id, fixed)
+			if err != nil {
+				t.Fatalf("%s: %v", id, err)
+			}
+			if back, err := ToFixed(d); err != nil || back != fixed {
+				t.Fatalf("%s: %s converted back to %d, %v; expected %d", id, d, back, err, fixed)
+			}
+		}
+	}
+}
+
+func TestInvalidDates(t *testing.T) {
+	tests := []struct {
+		date     Date
+		expected string
+	}{
+		{Date{Calendar: Hebrew, Year: 5784, Month: 8, Day: 30}, "does not exist"},   // Iyar has 29 days
+		{Date{Calendar: Hebrew, Year: 5785, Month: 13, Day: 1}, "does not exist"},   // Not a leap year
+		{Date{Calendar: Persian, Year: 1402, Month: 12, Day: 30}, "does not exist"}, // Not a leap year
+		{Date{Calendar: Chinese, Year: 2024, Month: 2, LeapMonth: true, Day: 1}, "does not exist"},
+		{Date{Calendar: Japanese, Era: "Heisei", Year: 31, Month: 5, Day: 1}, "does not exist"},
+		{Date{Calendar: Japanese, Year: 6, Month: 5, Day: 1}, "need an era"},
+		{Date{Calendar: Japanese, Era: "Edo", Year: 6, Month: 5, Day: 1}, "unknown Japanese era"},
+		{Date{Calendar: Japanese, Era: "Meiji", Year: 1, Month: 12, Day: 1}, "supports only"},
+		{Date{Calendar: "mayan", Year: 13, Month: 1, Day: 1}, "unknown calendar system"},
+	}
+
+	for _, tt := range tests {
+		_, err := ToFixed(tt.date)
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s: expected error containing %q, got %v", formatNumeric(tt.date), tt.expected, err)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := map[string]string{
+		"Hijri":       IslamicCivil,
+		"umm-al-qura": IslamicUmmAlQura,
+		"Jalali":      Persian,
+		" thai ":      Buddhist,
+		"gregorian":   Gregorian,
+	}
+	for name, expected := range tests {
+		if id, ok := Lookup(name); !ok || id != expected {
+			t.Errorf("%q: expected %s, got %s", name, expected, id)
+		}
+	}
+	if _, ok := Lookup("mayan"); ok {
+		t.Error("expected mayan to be unknown")
+	}
+	if wd := Weekday(Fixed(2024, time.January, 1)); wd != time.Monday {
+		t.Errorf("expected 2024-01-01 to be a Monday, got %s", wd)
+	}
+}
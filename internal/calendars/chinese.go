@@ -0,0 +1,165 @@
+package calendars
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/zodimo/go-time-mcp/internal/astronomy"
+)
+
+// Names of the sexagenary cycle of years
+var (
+	celestialStems      = [...]string{"Jia", "Yi", "Bing", "Ding", "Wu", "Ji", "Geng", "Xin", "Ren", "Gui"}
+	terrestrialBranches = [...]string{"Zi", "Chou", "Yin", "Mao", "Chen", "Si", "Wu", "Wei", "Shen", "You", "Xu", "Hai"}
+	zodiacAnimals       = [...]string{"Rat", "Ox", "Tiger", "Rabbit", "Dragon", "Snake", "Horse", "Goat", "Monkey", "Rooster", "Dog", "Pig"}
+)
+
+// chineseFromFixed converts a fixed day number to a date in the Chinese
+// lunisolar calendar. Months begin on the day of the new moon in Beijing;
+// month 11 contains the winter solstice, and in a year of 13 months the first
+// month without a major solar term is a leap month. Year is the Gregorian
+// year in which the Chinese year begins.
+func chineseFromFixed(fixed int) Date {
+	s1 := winterSolsticeOnOrBefore(fixed)
+	s2 := winterSolsticeOnOrBefore(s1 + 370)
+	m12 := newMoonOnOrAfter(s1 + 1)
+	nextM11 := newMoonBefore(s2 + 1)
+	m := newMoonBefore(fixed + 1)
+	leapYear := lunationsBetween(m12, nextM11) == 12
+
+	month := lunationsBetween(m12, m)
+	if leapYear && priorLeapMonth(m12, m) {
+		month--
+	}
+	month = amod(month, 12)
+	leapMonth := leapYear && noMajorSolarTerm(m) && !priorLeapMonth(m12, newMoonBefore(m))
+
+	year, _, _ := GregorianDate(chineseNewYearOnOrBefore(fixed))
+	monthName := fmt.Sprintf("Month %d", month)
+	if leapMonth {
+		monthName = fmt.Sprintf("Leap month %d", month)
+	}
+	cycle := mod(year-4, 60)
+	yearName := fmt.Sprintf("%s-%s year of the %s", celestialStems[cycle%10], terrestrialBranches[cycle%12], zodiacAnimals[cycle%12])
+	return Date{Calendar: Chinese, Year: year, Month: month, LeapMonth: leapMonth, Day: fixed - m + 1, MonthName: monthName, YearName: yearName}
+}
+
+// fixedFromChinese converts a Chinese date, whose year is the Gregorian year
+// in which it begins, to a fixed day number
+func fixedFromChinese(d Date) (int, error) {
+	newYear := chineseNewYearOnOrBefore(Fixed(d.Year, time.July, 1))
+	p := newMoonOnOrAfter(newYear + (d.Month-1)*29)
+	if back := chineseFromFixed(p); back.Month != d.Month || back.LeapMonth != d.LeapMonth {
+		p = newMoonOnOrAfter(p + 1)
+	}
+	return p + d.Day - 1, nil
+}
+
+// chineseNewYearOnOrBefore returns the fixed day number of the Chinese new
+// year on or before a day
+func chineseNewYearOnOrBefore(fixed int) int {
+	if newYear := chineseNewYearInSui(fixed); fixed >= newYear {
+		return newYear
+	}
+	return chineseNewYearInSui(fixed - 180)
+}
+
+// chineseNewYearInSui returns the fixed day number of the Chinese new year in
+// the solstice-to-solstice year (sui) containing a day
+func chineseNewYearInSui(fixed int) int {
+	s1 := winterSolsticeOnOrBefore(fixed)
+	s2 := winterSolsticeOnOrBefore(s1 + 370)
+	m12 := newMoonOnOrAfter(s1 + 1)
+	m13 := newMoonOnOrAfter(m12 + 1)
+	nextM11 := newMoonBefore(s2 + 1)
+	if lunationsBetween(m12, nextM11) == 12 && (noMajorSolarTerm(m12) || noMajorSolarTerm(m13)) {
+		return newMoonOnOrAfter(m13 + 1)
+	}
+	return m13
+}
+
+// priorLeapMonth reports whether there is a leap month from the month
+// beginning on day start up to the month beginning on day m
+func priorLeapMonth(start, m int) bool {
+	for ; m >= start; m = newMoonBefore(m) {
+		if noMajorSolarTerm(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// noMajorSolarTerm reports whether the month beginning on a day has no major
+// solar term, a multiple of 30 degrees of solar longitude
+func noMajorSolarTerm(fixed int) bool {
+	return majorSolarTerm(fixed) == majorSolarTerm(newMoonOnOrAfter(fixed+1))
+}
+
+// majorSolarTerm returns the index of the last major solar term at the start
+// of a day, 1 for the term at longitude 330
+func majorSolarTerm(fixed int) int {
+	longitude := astronomy.SunLongitude(chinaMidnight(fixed))
+	return amod(2+int(math.Floor(longitude/30)), 12)
+}
+
+// winterSolsticeOnOrBefore returns the fixed day number in China of the
+// winter solstice on or before a day
+func winterSolsticeOnOrBefore(fixed int) int {
+	year, _, _ := GregorianDate(fixed)
+	for y := year; ; y-- {
+		// The solstice falls on the day whose following midnight is past 270
+		for d := Fixed(y, time.December, 19); d <= Fixed(y, time.December, 24); d++ {
+			if longitude := astronomy.SunLongitude(chinaMidnight(d + 1)); longitude >= 270 && longitude < 300 {
+				if d <= fixed {
+					return d
+				}
+				break
+			}
+		}
+	}
+}
+
+// newMoonOnOrAfter returns the first fixed day number in China of a new moon
+// on or after a day
+func newMoonOnOrAfter(fixed int) int {
+	return chinaDate(astronomy.NextPhase(chinaMidnight(fixed).Add(-time.Nanosecond), astronomy.NewMoon))
+}
+
+// newMoonBefore returns the fixed day number in China of the last new moon
+// before a day
+func newMoonBefore(fixed int) int {
+	return chinaDate(astronomy.PreviousPhase(chinaMidnight(fixed).Add(-time.Nanosecond), astronomy.NewMoon))
+}
+
+// lunationsBetween returns the whole number of lunations between two new
+// moons
+func lunationsBetween(from, to int) int {
+	return int(math.Round(float64(to-from) / astronomy.SynodicMonth))
+}
+
+// chinaStandardTime is the fixed day number of 1 January 1929, when China
+// adopted UTC+8 in place of Beijing local mean time
+var chinaStandardTime = Fixed(1929, time.January, 1)
+
+// chinaOffset returns the offset from UTC of civil time in China on a day
+func chinaOffset(fixed int) time.Duration {
+	if fixed < chinaStandardTime {
+		return 1397 * time.Hour / 180
+	}
+	return 8 * time.Hour
+}
+
+// chinaMidnight returns the instant at which a day begins in China
+func chinaMidnight(fixed int) time.Time {
+	return time.Unix(int64(fixed-unixEpoch)*86400, 0).Add(-chinaOffset(fixed))
+}
+
+// chinaDate returns the fixed day number in China of an instant
+func chinaDate(t time.Time) int {
+	offset := 8 * time.Hour
+	if t.Before(chinaMidnight(chinaStandardTime)) {
+		offset = chinaOffset(chinaStandardTime - 1)
+	}
+	return floorDiv(int(t.Add(offset).Unix()), 86400) + unixEpoch
+}
@@ -0,0 +1,147 @@
+package calendars
+
+// Hebrew months, numbered from Nisan as in the Bible; the year begins with
+// Tishrei, the seventh month. Leap years add Adar II after Adar (Adar I).
+const (
+	nisan    = 1
+	iyar     = 2
+	tammuz   = 4
+	elul     = 6
+	tishrei  = 7
+	cheshvan = 8
+	kislev   = 9
+	tevet    = 10
+	adar     = 12
+	adarII   = 13
+)
+
+// hebrewMonths names the Hebrew months from Nisan
+var hebrewMonths = [...]string{"Nisan", "Iyar", "Sivan", "Tammuz", "Av", "Elul", "Tishrei", "Cheshvan", "Kislev", "Tevet", "Shevat", "Adar", "Adar II"}
+
+// hebrewEpoch is the fixed day number of 1 Tishrei AM 1 (7 October 3761 BCE,
+// Julian)
+const hebrewEpoch = -1373427
+
+// hebrewFromFixed converts a fixed day number to a Hebrew date
+func hebrewFromFixed(fixed int) Date {
+	// The mean year length puts the year within one of the true one
+	year := floorDiv((fixed-hebrewEpoch)*98496, 35975351)
+	for hebrewNewYear(year+1) <= fixed {
+		year++
+	}
+
+	month := nisan
+	if fixed < fixedFromHebrewDate(year, nisan, 1) {
+		month = tishrei
+	}
+	for fixed > fixedFromHebrewDate(year, month, lastDayOfHebrewMonth(year, month)) {
+		month++
+	}
+
+	day := fixed - fixedFromHebrewDate(year, month, 1) + 1
+	name := hebrewMonths[month-1]
+	if month == adar && isHebrewLeapYear(year) {
+		name = "Adar I"
+	}
+	return Date{Calendar: Hebrew, Era: "AM", Year: year, Month: month, Day: day, MonthName: name}
+}
+
+// fixedFromHebrew converts a Hebrew date to a fixed day number
+func fixedFromHebrew(d Date) (int, error) {
+	return fixedFromHebrewDate(d.Year, d.Month, d.Day), nil
+}
+
+// fixedFromHebrewDate converts a Hebrew year, month and day to a fixed day
+// number, counting months from Tishrei
+func fixedFromHebrewDate(year, month, day int) int {
+	fixed := hebrewNewYear(year) + day - 1
+	if month < tishrei {
+		for m := tishrei; m <= lastMonthOfHebrewYear(year); m++ {
+			fixed += lastDayOfHebrewMonth(year, m)
+		}
+		for m := nisan; m < month; m++ {
+			fixed += lastDayOfHebrewMonth(year, m)
+		}
+	} else {
+		for m := tishrei; m < month; m++ {
+			fixed += lastDayOfHebrewMonth(year, m)
+		}
+	}
+	return fixed
+}
+
+// isHebrewLeapYear reports whether a Hebrew year has 13 months; seven years
+// in each 19-year cycle do
+func isHebrewLeapYear(year int) bool {
+	return mod(7*year+1, 19) < 7
+}
+
+// lastMonthOfHebrewYear returns the number of the last month of the year
+func lastMonthOfHebrewYear(year int) int {
+	if isHebrewLeapYear(year) {
+		return adarII
+	}
+	return adar
+}
+
+// lastDayOfHebrewMonth returns the length of a month, which for Cheshvan and
+// Kislev depends on the length of the year
+func lastDayOfHebrewMonth(year, month int) int {
+	switch {
+	case month == iyar || month == tammuz || month == elul || month == tevet || month == adarII,
+		month == adar && !isHebrewLeapYear(year),
+		month == cheshvan && !hasLongCheshvan(year),
+		month == kislev && hasShortKislev(year):
+		return 29
+	}
+	return 30
+}
+
+// hebrewElapsedDays returns the days from the epoch to the molad of Tishrei of
+// a year, postponed by a day when the molad falls on Sunday, Wednesday or
+// Friday
+func hebrewElapsedDays(year int) int {
+	monthsElapsed := floorDiv(235*year-234, 19)
+	partsElapsed := 12084 + 13753*monthsElapsed
+	days := 29*monthsElapsed + floorDiv(partsElapsed, 25920)
+	if mod(3*(days+1), 7) < 3 {
+		days++
+	}
+	return days
+}
+
+// hebrewYearLengthCorrection returns the further delay of the new year that
+// keeps years within their allowed lengths
+func hebrewYearLengthCorrection(year int) int {
+	ny0, ny1, ny2 := hebrewElapsedDays(year-1), hebrewElapsedDays(year), hebrewElapsedDays(year+1)
+	switch {
+	case ny2-ny1 == 356:
+		return 2
+	case ny1-ny0 == 382:
+		return 1
+	}
+	return 0
+}
+
+// hebrewNewYear returns the fixed day number of 1 Tishrei of a year
+func hebrewNewYear(year int) int {
+	return hebrewEpoch + hebrewElapsedDays(year) + hebrewYearLengthCorrection(year)
+}
+
+// daysInHebrewYear returns the length of a year: 353 to 355 days, or 383 to
+// 385 in leap years
+func daysInHebrewYear(year int) int {
+	return hebrewNewYear(year+1) - hebrewNewYear(year)
+}
+
+// hasLongCheshvan reports whether Cheshvan has 30 days in a year
+func hasLongCheshvan(year int) bool {
+	days := daysInHebrewYear(year)
+	return days == 355 || days == 385
+}
+
+// hasShortKislev reports whether Kislev has 29 days in a year
+func hasShortKislev(year int) bool {
+	days := daysInHebrewYear(year)
+	return days == 353 || days == 383
+}
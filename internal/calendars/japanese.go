@@ -0,0 +1,56 @@
+package calendars
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// japaneseEra is an era of the Japanese imperial calendar
+type japaneseEra struct {
+	name         string
+	abbreviation string
+	kanji        string
+	start        int // Fixed day number of the first day
+}
+
+// japaneseEras lists the modern eras, oldest first. Japan adopted the
+// Gregorian calendar on 1 January 1873 (Meiji 6); earlier dates are not
+// supported.
+var japaneseEras = []japaneseEra{
+	{"Meiji", "M", "明治", Fixed(1868, time.October, 23)},
+	{"Taisho", "T", "大正", Fixed(1912, time.July, 30)},
+	{"Showa", "S", "昭和", Fixed(1926, time.December, 25)},
+	{"Heisei", "H", "平成", Fixed(1989, time.January, 8)},
+	{"Reiwa", "R", "令和", Fixed(2019, time.May, 1)},
+}
+
+// japaneseFromFixed converts a fixed day number to a date in the Japanese
+// calendar, which counts Gregorian years from the start of each era
+func japaneseFromFixed(fixed int) Date {
+	era := japaneseEras[0]
+	for _, e := range japaneseEras {
+		if e.start <= fixed {
+			era = e
+		}
+	}
+
+	year, month, day := GregorianDate(fixed)
+	startYear, _, _ := GregorianDate(era.start)
+	return Date{Calendar: Japanese, Era: era.name, Year: year - startYear + 1, Month: int(month), Day: day, MonthName: month.String()}
+}
+
+// fixedFromJapanese converts a Japanese date to a fixed day number. The era
+// may be given by name, initial or kanji.
+func fixedFromJapanese(d Date) (int, error) {
+	for _, era := range japaneseEras {
+		if strings.EqualFold(d.Era, era.name) || strings.EqualFold(d.Era, era.abbreviation) || d.Era == era.kanji {
+			startYear, _, _ := GregorianDate(era.start)
+			return Fixed(startYear+d.Year-1, time.Month(d.Month), d.Day), nil
+		}
+	}
+	if d.Era == "" {
+		return 0, fmt.Errorf("japanese dates need an era, such as Reiwa")
+	}
+	return 0, fmt.Errorf("unknown Japanese era '%s'", d.Era)
+}
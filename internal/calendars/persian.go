@@ -0,0 +1,72 @@
+package calendars
+
+import "time"
+
+// persianMonths names the months of the Solar Hijri calendar
+var persianMonths = [...]string{"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar", "Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand"}
+
+// persianBreaks are the Solar Hijri years in which the pattern of 33-year
+// leap cycles changes, after Borkowski's algorithm. Years from -61 up to the
+// last break are supported.
+var persianBreaks = [...]int{-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210, 1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178}
+
+// persianFromFixed converts a fixed day number to a Solar Hijri date
+func persianFromFixed(fixed int) Date {
+	gregorianYear, _, _ := GregorianDate(fixed)
+	year := gregorianYear - 621
+	march := persianNewYear(year)
+
+	// Days since 1 Farvardin; before it the date is in the previous year
+	days := fixed - Fixed(gregorianYear, time.March, march)
+	if days < 0 {
+		year--
+		march = persianNewYear(year)
+		days = fixed - Fixed(gregorianYear-1, time.March, march)
+	}
+
+	var month, day int
+	if days < 6*31 {
+		month, day = days/31+1, days%31+1
+	} else {
+		days -= 6 * 31
+		month, day = days/30+7, days%30+1
+	}
+	return Date{Calendar: Persian, Era: "AP", Year: year, Month: month, Day: day, MonthName: persianMonths[month-1]}
+}
+
+// fixedFromPersian converts a Solar Hijri date to a fixed day number
+func fixedFromPersian(d Date) (int, error) {
+	march := persianNewYear(d.Year)
+	fixed := Fixed(d.Year+621, time.March, march) + d.Day - 1
+	if d.Month <= 7 {
+		return fixed + 31*(d.Month-1), nil
+	}
+	return fixed + 6*31 + 30*(d.Month-7), nil
+}
+
+// persianNewYear returns the day of March in the Gregorian year year+621 on
+// which a Solar Hijri year begins
+func persianNewYear(year int) int {
+	// Count the leap years before this one by whole 33-year cycles and
+	// 4-year subcycles between the breaks
+	leapYears := -14
+	previous := persianBreaks[0]
+	jump := 0
+	for _, next := range persianBreaks[1:] {
+		jump = next - previous
+		if year < next {
+			break
+		}
+		leapYears += jump/33*8 + jump%33/4
+		previous = next
+	}
+	n := year - previous
+	leapYears += n/33*8 + (n%33+3)/4
+	if jump%33 == 4 && jump-n == 4 {
+		leapYears++
+	}
+
+	gregorianYear := year + 621
+	gregorianLeapYears := gregorianYear/4 - (gregorianYear/100+1)*3/4 - 150
+	return 20 + leapYears - gregorianLeapYears
+}
@@ -0,0 +1,75 @@
+package calendars
+
+import (
+	"time"
+
+	"github.com/zodimo/go-time-mcp/internal/astronomy"
+)
+
+// Mecca, where Umm al-Qura months are reckoned, on Arabia Standard Time
+const (
+	meccaLatitude  = 21.4225
+	meccaLongitude = 39.8262
+	meccaOffset    = 3 * time.Hour
+)
+
+// ummAlQuraReference is the conjunction that began Ramadan 1445, month
+// number 1445*12+8 counted as in ummAlQuraMonthStart
+var (
+	ummAlQuraReference      = time.Date(2024, time.March, 10, 9, 0, 0, 0, time.UTC)
+	ummAlQuraReferenceMonth = 1445*12 + 8
+)
+
+// ummAlQuraFromFixed converts a fixed day number to a date in the Umm al-Qura
+// calendar of Saudi Arabia
+func ummAlQuraFromFixed(fixed int) Date {
+	// The tabular calendar is within a couple of days of Umm al-Qura
+	approx := islamicFromFixed(fixed)
+	month := approx.Year*12 + approx.Month - 1
+	for fixed < ummAlQuraMonthStart(month) {
+		month--
+	}
+	for fixed >= ummAlQuraMonthStart(month+1) {
+		month++
+	}
+
+	year, m := floorDiv(month, 12), mod(month, 12)+1
+	day := fixed - ummAlQuraMonthStart(month) + 1
+	return Date{Calendar: IslamicUmmAlQura, Era: "AH", Year: year, Month: m, Day: day, MonthName: islamicMonths[m-1]}
+}
+
+// fixedFromUmmAlQura converts an Umm al-Qura date to a fixed day number
+func fixedFromUmmAlQura(d Date) (int, error) {
+	return ummAlQuraMonthStart(d.Year*12+d.Month-1) + d.Day - 1, nil
+}
+
+// ummAlQuraMonthStart returns the fixed day number of the first day of an
+// Islamic month, numbered as year*12 + month - 1. It applies the criterion
+// in use since 1420 AH: a month begins the day after the 29th when on that
+// evening the conjunction precedes sunset and the moon sets after the sun in
+// Mecca, otherwise a day later. Earlier dates were set by other rules and
+// may differ from the published calendar.
+func ummAlQuraMonthStart(month int) int {
+	previous := ummAlQuraFirstVisibility(month - 1)
+	start := ummAlQuraFirstVisibility(month)
+	return min(max(start, previous+29), previous+30)
+}
+
+// ummAlQuraFirstVisibility returns the day after the first evening in Mecca
+// that meets the Umm al-Qura criterion for a month, without regard to the
+// length of the previous month
+func ummAlQuraFirstVisibility(month int) int {
+	approx := ummAlQuraReference.Add(time.Duration(float64(month-ummAlQuraReferenceMonth) * astronomy.SynodicMonth * 24 * float64(time.Hour)))
+	conjunction := astronomy.NextPhase(approx.Add(-7*24*time.Hour), astronomy.NewMoon)
+
+	local := conjunction.Add(meccaOffset)
+	day := Fixed(local.Year(), local.Month(), local.Day())
+
+	noon := time.Unix(int64(day-unixEpoch)*86400, 0).Add(12*time.Hour - meccaOffset)
+	sunset := astronomy.Solar(noon, meccaLatitude, meccaLongitude).Sunrise.Set
+	moonset := astronomy.MoonRiseSet(noon, noon.Add(24*time.Hour), meccaLatitude, meccaLongitude).Set
+	if conjunction.Before(sunset) && moonset.After(sunset) {
+		return day + 1
+	}
+	return day + 2
+}
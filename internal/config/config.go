@@ -10,10 +10,13 @@ import (
 
 // Config holds all configuration for the MCP server
 type Config struct {
-	Mode     string        // SSE or stdio
-	Port     int           // Port for SSE mode
-	Timeout  time.Duration // Request timeout
-	LogLevel string        // Log level (debug, info, warn, error)
+	Mode              string        // SSE or stdio
+	Port              int           // Port for SSE mode
+	Timeout           time.Duration // Request timeout
+	LogLevel          string        // Log level (debug, info, warn, error)
+	HolidaysFile      string        // Path to a custom business calendar (JSON or YAML)
+	AbbreviationsFile string        // Path to custom timezone abbreviations (JSON or YAML)
+	MaxOccurrences    int           // Maximum occurrences returned by one recurrence expansion
 }
 
 // Load parses command line flags and environment variables to create configuration
@@ -25,6 +28,9 @@ func Load() (*Config, error) {
 	port := flag.Int("port", getEnvIntOrDefault("MCP_PORT", 8080), "Port for SSE mode")
 	timeout := flag.Duration("timeout", getEnvDurationOrDefault("MCP_TIMEOUT", 30*time.Second), "Request timeout")
 	logLevel := flag.String("log-level", getEnvOrDefault("MCP_LOG_LEVEL", "info"), "Log level: debug, info, warn, error")
+	holidaysFile := flag.String("holidays-file", getEnvOrDefault("MCP_HOLIDAYS_FILE", ""), "Path to a custom business calendar file (JSON or YAML)")
+	abbreviationsFile := flag.String("abbreviations-file", getEnvOrDefault("MCP_ABBREVIATIONS_FILE", ""), "Path to a custom timezone abbreviations file (JSON or YAML)")
+	maxOccurrences := flag.Int("max-occurrences", getEnvIntOrDefault("MCP_MAX_OCCURRENCES", 1000), "Maximum occurrences returned by one recurrence expansion")
 
 	// Parse command line flags
 	flag.Parse()
@@ -34,6 +40,9 @@ func Load() (*Config, error) {
 	cfg.Port = *port
 	cfg.Timeout = *timeout
 	cfg.LogLevel = *logLevel
+	cfg.HolidaysFile = *holidaysFile
+	cfg.AbbreviationsFile = *abbreviationsFile
+	cfg.MaxOccurrences = *maxOccurrences
 
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
@@ -73,6 +82,25 @@ func (c *Config) Validate() error {
 		return NewInvalidLogLevelError(c.LogLevel)
 	}
 
+	// Validate custom holidays file
+	if c.HolidaysFile != "" {
+		if _, err := os.Stat(c.HolidaysFile); err != nil {
+			return NewInvalidHolidaysFileError(c.HolidaysFile, err)
+		}
+	}
+
+	// Validate custom abbreviations file
+	if c.AbbreviationsFile != "" {
+		if _, err := os.Stat(c.AbbreviationsFile); err != nil {
+			return NewInvalidAbbreviationsFileError(c.AbbreviationsFile, err)
+		}
+	}
+
+	// Validate recurrence expansion cap
+	if c.MaxOccurrences < 1 {
+		return NewInvalidMaxOccurrencesError(c.MaxOccurrences)
+	}
+
 	return nil
 }
 
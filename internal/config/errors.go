@@ -25,11 +25,14 @@ func (e *ConfigError) Unwrap() error {
 
 // Configuration error codes (3000-3999 range)
 const (
-	ErrCodeInvalidMode     = 3001
-	ErrCodeInvalidPort     = 3002
-	ErrCodeInvalidTimeout  = 3003
-	ErrCodeInvalidLogLevel = 3004
-	ErrCodeParsingFailed   = 3005
+	ErrCodeInvalidMode           = 3001
+	ErrCodeInvalidPort           = 3002
+	ErrCodeInvalidTimeout        = 3003
+	ErrCodeInvalidLogLevel       = 3004
+	ErrCodeParsingFailed         = 3005
+	ErrCodeInvalidHolidays       = 3006
+	ErrCodeInvalidAbbreviations  = 3007
+	ErrCodeInvalidMaxOccurrences = 3008
 )
 
 // NewConfigError creates a new configuration error
@@ -81,3 +84,33 @@ func NewInvalidLogLevelError(level string) *ConfigError {
 		nil,
 	)
 }
+
+// NewInvalidHolidaysFileError creates an error for an unreadable holidays file
+func NewInvalidHolidaysFileError(path string, err error) *ConfigError {
+	return NewConfigError(
+		ErrCodeInvalidHolidays,
+		fmt.Sprintf("invalid holidays file '%s'", path),
+		"holidays-file",
+		err,
+	)
+}
+
+// NewInvalidAbbreviationsFileError creates an error for an unreadable abbreviations file
+func NewInvalidAbbreviationsFileError(path string, err error) *ConfigError {
+	return NewConfigError(
+		ErrCodeInvalidAbbreviations,
+		fmt.Sprintf("invalid abbreviations file '%s'", path),
+		"abbreviations-file",
+		err,
+	)
+}
+
+// NewInvalidMaxOccurrencesError creates an error for an invalid recurrence expansion cap
+func NewInvalidMaxOccurrencesError(limit int) *ConfigError {
+	return NewConfigError(
+		ErrCodeInvalidMaxOccurrences,
+		fmt.Sprintf("invalid max occurrences %d: must be positive", limit),
+		"max-occurrences",
+		nil,
+	)
+}
@@ -2,12 +2,16 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/zodimo/go-time-mcp/internal/calendars"
 	"github.com/zodimo/go-time-mcp/internal/config"
 	"github.com/zodimo/go-time-mcp/internal/services"
 )
@@ -97,11 +101,33 @@ func (s *mcpServer) RegisterToolHandler(name string, handler server.ToolHandlerF
 
 // registerToolHandlers registers all time-related tool handlers
 func (s *mcpServer) registerToolHandlers() error {
+	// Shared hint for abbreviations used by several zones, e.g. "IST"
+	regionProperty := map[string]interface{}{
+		"type":        "string",
+		"description": "Region or country hint used when the timezone is an ambiguous abbreviation (e.g., 'IN', 'India', 'Europe' or 'Asia/Kolkata' for 'IST'); ignored otherwise",
+	}
+
+	// Shared selector for the language of format strings
+	formatDialectProperty := map[string]interface{}{
+		"type":        "string",
+		"description": "Language of the format string: 'default' ('YYYY-MM-DD HH:mm:ss'), 'strftime' ('%Y-%m-%d %H:%M:%S'), 'java'/'icu' (\"yyyy-MM-dd'T'HH:mm\"), 'moment' ('YYYY-MM-DD [at] HH:mm') or 'go' ('2006-01-02 15:04'). Optional; formats containing '%' default to strftime",
+		"enum":        []string{"default", "strftime", "java", "icu", "moment", "go"},
+	}
+
+	// Shared selector for the language of names and phrases
+	localeProperty := map[string]interface{}{
+		"type":        "string",
+		"description": "Locale for month and weekday names, AM/PM markers, style formats and phrases (optional, e.g., 'de', 'pt-BR', 'ja'; defaults to English). Supported: " + strings.Join(services.SupportedLocales(), ", "),
+	}
+
 	// Register getCurrentTime tool handler
 	getCurrentTimeHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Extract parameters using mcp helper functions
-		timezone := mcp.ParseString(request, "timezone", "")
+		timezone := parseTimezone(request, "timezone")
 		format := mcp.ParseString(request, "format", "")
+		dialect := mcp.ParseString(request, "formatDialect", "")
+		locale := mcp.ParseString(request, "locale", "")
+		calendar := mcp.ParseString(request, "calendar", "")
 
 		// Get current time
 		currentTime, err := s.timeService.GetCurrentTime(timezone)
@@ -110,11 +136,20 @@ func (s *mcpServer) registerToolHandlers() error {
 		}
 
 		// Format time
-		formattedTime, err := s.timeService.FormatTime(currentTime, format)
+		formattedTime, err := s.timeService.FormatTime(currentTime, format, dialect, locale)
 		if err != nil {
 			return nil, err
 		}
 
+		// Add today's date in another calendar system
+		if calendar != "" {
+			date, err := s.timeService.CalendarDate(currentTime, calendar)
+			if err != nil {
+				return nil, err
+			}
+			formattedTime = fmt.Sprintf("%s (%s)", formattedTime, date.Formatted)
+		}
+
 		return mcp.NewToolResultText(formattedTime), nil
 	}
 
@@ -126,11 +161,19 @@ func (s *mcpServer) registerToolHandlers() error {
 			Properties: map[string]interface{}{
 				"timezone": map[string]interface{}{
 					"type":        "string",
-					"description": "Timezone (IANA format, e.g., 'America/New_York', or empty for UTC)",
+					"description": "Timezone (IANA name, UTC offset such as '+05:30' or abbreviation, e.g., 'America/New_York', or empty for UTC)",
 				},
+				"region": regionProperty,
 				"format": map[string]interface{}{
 					"type":        "string",
-					"description": "Time format string (optional, defaults to RFC3339)",
+					"description": "Time format string (optional, e.g., 'YYYY-MM-DD HH:mm' or '%A %d %B %Y', defaults to RFC3339). 'full', 'long', 'medium' or 'short' select the locale's own date and time pattern; prefix with 'date:' or 'time:' for only one of them",
+				},
+				"formatDialect": formatDialectProperty,
+				"locale":        localeProperty,
+				"calendar": map[string]interface{}{
+					"type":        "string",
+					"description": "Calendar system in which to add today's date after the time (optional, e.g., 'hebrew', 'islamic-umalqura', 'persian', 'chinese' or 'japanese')",
+					"enum":        calendars.IDs(),
 				},
 			},
 		},
@@ -140,24 +183,1169 @@ func (s *mcpServer) registerToolHandlers() error {
 
 	// Register getUnixTimestamp tool handler
 	getUnixTimestampHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		timestamp := s.timeService.GetUnixTimestamp()
+		unit := mcp.ParseString(request, "unit", "s")
+		decimal := mcp.ParseBoolean(request, "decimal", false)
+
+		timestamp, err := s.timeService.GetUnixTimestamp(unit, decimal)
+		if err != nil {
+			return nil, err
+		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("%d", timestamp)), nil
+		return newToolResultJSON(timestamp)
 	}
 
 	getUnixTimestampTool := mcp.Tool{
 		Name:        "getUnixTimestamp",
-		Description: "Get LIVE current Unix timestamp from system clock. IMPORTANT FOR LLMs: Use this tool to obtain the precise current Unix timestamp, as LLMs cannot access real-time system data independently. This provides the current number of seconds since January 1, 1970 UTC, essential for time-based calculations and accurate timestamps.",
+		Description: "Get LIVE current Unix timestamp from system clock. IMPORTANT FOR LLMs: Use this tool to obtain the precise current Unix timestamp, as LLMs cannot access real-time system data independently. This provides the current number of seconds (or milliseconds, microseconds or nanoseconds) since January 1, 1970 UTC, together with the RFC3339 time of the same clock reading, essential for time-based calculations and accurate timestamps.",
 		InputSchema: mcp.ToolInputSchema{
-			Type:       "object",
-			Properties: map[string]interface{}{},
+			Type: "object",
+			Properties: map[string]interface{}{
+				"unit": map[string]interface{}{
+					"type":        "string",
+					"description": "Unit of the timestamp: 's' (default), 'ms', 'us' or 'ns'",
+					"enum":        []string{"s", "ms", "us", "ns"},
+				},
+				"decimal": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also return the exact reading as a decimal number of units, e.g. '1704067200.123456789' seconds (optional, defaults to false)",
+				},
+			},
 		},
 	}
 
 	s.server.AddTool(getUnixTimestampTool, getUnixTimestampHandler)
 
-	log.Printf("Registered %d tools", 2)
-	return nil
+	// Register convertTimestamp tool handler
+	convertTimestampHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Numbers arrive as float64; large ones should be sent as strings to
+		// keep every digit
+		value := mcp.ParseString(request, "value", "")
+		if n, ok := mcp.ParseArgument(request, "value", nil).(float64); ok {
+			value = strconv.FormatFloat(n, 'f', -1, 64)
+		}
+		scale := mcp.ParseString(request, "scale", services.ScaleAuto)
+
+		conversion, err := s.timeService.ConvertTimestamp(value, scale)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(conversion)
+	}
+
+	convertTimestampTool := mcp.Tool{
+		Name:        "convertTimestamp",
+		Description: "Convert a timestamp between time scales and epochs: Unix seconds, milliseconds, microseconds and nanoseconds, NTP (seconds or 64-bit hex), GPS (seconds or week:seconds), TAI (seconds, date or TAI64N label), Excel serial dates, Windows FILETIME, .NET ticks, Mac absolute time, Julian Day and Modified Julian Day. Returns the instant in RFC3339 UTC and in every scale at once, applying leap seconds between UTC, TAI and GPS from an embedded table. IMPORTANT FOR LLMs: Use this tool instead of converting raw timestamps yourself, as epochs, units and leap-second offsets are easy to get wrong.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"value": map[string]interface{}{
+					"type":        "string",
+					"description": "Timestamp to convert (e.g., '1704067200123', '133485408000000000', '0xE93C7F0080000000', '2295:86418', '@40000000659200a500000000' or '2024-01-01T00:00:00Z'). Send large numbers as strings to keep every digit.",
+				},
+				"scale": map[string]interface{}{
+					"type":        "string",
+					"description": "Scale of the value: auto (default; dates as UTC, numbers as Unix seconds, ms, µs or ns by magnitude), utc, unix, unixMillis, unixMicros, unixNanos, ntp, gps, tai, tai64, excel, filetime, dotnetTicks, macAbsolute, julianDay or modifiedJulianDay. Short aliases such as ms, ns, jd, mjd and ticks are accepted.",
+				},
+			},
+			Required: []string{"value"},
+		},
+	}
+
+	s.server.AddTool(convertTimestampTool, convertTimestampHandler)
+
+	// Register extractIdTimestamp tool handler
+	extractIDTimestampHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := mcp.ParseString(request, "id", "")
+		idType := mcp.ParseString(request, "type", services.IDTypeAuto)
+		epoch := mcp.ParseString(request, "epoch", "")
+		timezone := parseTimezone(request, "timezone")
+
+		extracted, err := s.timeService.ExtractIDTimestamp(id, idType, epoch, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(extracted)
+	}
+
+	extractIDTimestampTool := mcp.Tool{
+		Name:        "extractIdTimestamp",
+		Description: "Decode when an ID was created from the timestamp embedded in it: UUID versions 1, 6 and 7, ULID, KSUID, MongoDB ObjectID, and Twitter, Discord or custom-epoch Snowflake IDs. The type is detected from the ID's shape unless given. Returns the instant, the detected type, the precision and other decoded fields such as the sequence, node or counter. IMPORTANT FOR LLMs: Use this tool instead of decoding IDs yourself, as their bit layouts and epochs differ and are easy to get wrong.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID to decode (e.g., '017F22E2-79B0-7CC3-98C4-DC0C0C07398F', '01ARZ3NDEKTSV4RRFFQ69G5FAV', '507f1f77bcf86cd799439011' or '175928847299117063'). Send Snowflake IDs as strings to keep every digit.",
+				},
+				"type": map[string]interface{}{
+					"type":        "string",
+					"description": "Type of the ID (optional, defaults to auto). A Snowflake ID is read with the Twitter epoch, with the Discord reading as an alternative, unless 'twitter' or 'discord' is given or an epoch is set.",
+					"enum":        []string{"auto", "uuid", "ulid", "ksuid", "objectId", "snowflake", "twitter", "discord"},
+				},
+				"epoch": map[string]interface{}{
+					"type":        "string",
+					"description": "Custom Snowflake epoch as Unix milliseconds or a date (optional, e.g., '1577836800000' or '2020-01-01')",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone to show the time in (IANA name, UTC offset or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+			},
+			Required: []string{"id"},
+		},
+	}
+
+	s.server.AddTool(extractIDTimestampTool, extractIDTimestampHandler)
+
+	// Register worldClock tool handler
+	worldClockHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		region := mcp.ParseString(request, "region", "")
+		zones := parseStringList(request, "timezones")
+		for i, zone := range zones {
+			zones[i] = services.QualifyTimezone(zone, region)
+		}
+		format := mcp.ParseString(request, "format", "")
+		dialect := mcp.ParseString(request, "formatDialect", "")
+		locale := mcp.ParseString(request, "locale", "")
+
+		clock, err := s.timeService.WorldClock(zones, format, dialect, locale)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(clock)
+	}
+
+	worldClockTool := mcp.Tool{
+		Name:        "worldClock",
+		Description: "Get the LIVE current time in several timezones at once, all from a single reading of the system clock, with each zone's offset, DST status and day difference relative to the first zone. IMPORTANT FOR LLMs: Use this tool instead of calling getCurrentTime once per city, as separate calls drift apart and waste round trips.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"timezones": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Timezones to show, the first being the reference for day and offset differences (IANA names, UTC offsets or abbreviations, at most 50)",
+				},
+				"region": regionProperty,
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Format for an additional 'formatted' field (optional, e.g., 'HH:mm' or 'short'; omitted when empty)",
+				},
+				"formatDialect": formatDialectProperty,
+				"locale":        localeProperty,
+			},
+			Required: []string{"timezones"},
+		},
+	}
+
+	s.server.AddTool(worldClockTool, worldClockHandler)
+
+	// Register convertTime tool handler
+	convertTimeHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		t := mcp.ParseString(request, "time", "")
+		format := mcp.ParseString(request, "format", "")
+		dialect := mcp.ParseString(request, "formatDialect", "")
+		fromZone := parseTimezone(request, "fromTimezone")
+		toZone := parseTimezone(request, "toTimezone")
+
+		conversion, err := s.timeService.ConvertTime(t, format, dialect, fromZone, toZone)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(conversion)
+	}
+
+	convertTimeTool := mcp.Tool{
+		Name:        "convertTime",
+		Description: "Convert a specific time from one timezone to another. IMPORTANT FOR LLMs: Use this tool instead of calculating timezone differences yourself, as offsets depend on daylight saving rules for the exact date. Returns the instant in both timezones with UTC offsets and abbreviations.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"time": map[string]interface{}{
+					"type":        "string",
+					"description": "Time to convert (RFC3339, e.g., '2024-01-15T15:00:00+01:00', or matching the 'format' parameter)",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Format of the input time (optional, e.g., 'YYYY-MM-DD HH:mm' or '%d/%m/%Y %H:%M', defaults to RFC3339)",
+				},
+				"formatDialect": formatDialectProperty,
+				"fromTimezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Source timezone used to interpret times without an offset (IANA name, UTC offset such as '+05:30' or abbreviation, e.g., 'Europe/Berlin', or empty for UTC)",
+				},
+				"toTimezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Target timezone (IANA name, UTC offset such as '+05:30' or abbreviation, e.g., 'Asia/Tokyo', or empty for UTC)",
+				},
+				"region": regionProperty,
+			},
+			Required: []string{"time", "toTimezone"},
+		},
+	}
+
+	s.server.AddTool(convertTimeTool, convertTimeHandler)
+
+	// Register parseTime tool handler
+	parseTimeHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		input := mcp.ParseString(request, "time", "")
+		format := mcp.ParseString(request, "format", "")
+		dialect := mcp.ParseString(request, "formatDialect", "")
+		timezone := parseTimezone(request, "timezone")
+
+		parsed, err := s.timeService.ParseTime(input, format, dialect, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(parsed)
+	}
+
+	parseTimeTool := mcp.Tool{
+		Name:        "parseTime",
+		Description: "Parse a timestamp string into a canonical instant. IMPORTANT FOR LLMs: Use this tool to interpret timestamps from logs, APIs or users instead of guessing their meaning. Detects RFC3339, RFC1123, ISO 8601 basic/extended, Unix seconds/milliseconds/nanoseconds and common log formats, returning RFC3339, Unix seconds and the detected layout. Ambiguous inputs such as '01/02/2024' return every candidate interpretation.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"time": map[string]interface{}{
+					"type":        "string",
+					"description": "Timestamp to parse (e.g., '2024-01-15T14:30:45Z', 'Mon, 15 Jan 2024 14:30:45 GMT', '1705329045')",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Explicit input format (optional, e.g., 'DD/MM/YYYY HH:mm' or '%d/%m/%Y %H:%M'; detected automatically when empty)",
+				},
+				"formatDialect": formatDialectProperty,
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone used for inputs without an offset and for the result (IANA name, UTC offset such as '+05:30' or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+			},
+			Required: []string{"time"},
+		},
+	}
+
+	s.server.AddTool(parseTimeTool, parseTimeHandler)
+
+	// Register timeDifference tool handler
+	timeDifferenceHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		from := mcp.ParseString(request, "from", "")
+		to := mcp.ParseString(request, "to", "")
+		timezone := parseTimezone(request, "timezone")
+
+		difference, err := s.timeService.Diff(from, to, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(difference)
+	}
+
+	timeDifferenceTool := mcp.Tool{
+		Name:        "timeDifference",
+		Description: "Calculate the difference between two times. IMPORTANT FOR LLMs: Use this tool instead of doing date arithmetic yourself, as month lengths, leap years and daylight saving changes are easy to get wrong. Returns the exact duration, totals in seconds/hours/days and a calendar breakdown in years, months, days, hours, minutes and seconds.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start time (RFC3339, another common timestamp format, or 'now')",
+				},
+				"to": map[string]interface{}{
+					"type":        "string",
+					"description": "End time (RFC3339, another common timestamp format, or 'now')",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone for times without an offset and for the calendar breakdown (IANA name, UTC offset such as '+05:30' or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+			},
+			Required: []string{"from", "to"},
+		},
+	}
+
+	s.server.AddTool(timeDifferenceTool, timeDifferenceHandler)
+
+	// Register addDuration tool handler
+	addDurationHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		t := mcp.ParseString(request, "time", "now")
+		duration := mcp.ParseString(request, "duration", "")
+		timezone := parseTimezone(request, "timezone")
+
+		addition, err := s.timeService.Add(t, duration, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(addition)
+	}
+
+	addDurationTool := mcp.Tool{
+		Name:        "addDuration",
+		Description: "Add or subtract a duration from a time. IMPORTANT FOR LLMs: Use this tool to answer questions like 'what is the date 90 days from now' instead of calculating dates yourself. Accepts Go durations ('90m', '-2h') and ISO 8601 durations ('P1Y2M10DT2H30M', '-P1W'); calendar units keep the local wall-clock time across daylight saving changes.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"time": map[string]interface{}{
+					"type":        "string",
+					"description": "Start time (RFC3339, another common timestamp format, or 'now'; defaults to 'now')",
+				},
+				"duration": map[string]interface{}{
+					"type":        "string",
+					"description": "Duration to add, negative to subtract (e.g., 'P90D', '-P1M', 'PT36H', '1h30m')",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone in which calendar units are applied (IANA name, UTC offset such as '+05:30' or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+			},
+			Required: []string{"duration"},
+		},
+	}
+
+	s.server.AddTool(addDurationTool, addDurationHandler)
+
+	// Shared business calendar parameters
+	calendarProperty := map[string]interface{}{
+		"type":        "string",
+		"description": "Comma-separated holiday calendars: 'us' (US federal), 'uk' (England and Wales bank holidays), 'target2' (TARGET2 closing days) or a custom calendar ID (optional, weekends only when empty)",
+	}
+	weekendProperty := map[string]interface{}{
+		"type":        "string",
+		"description": "Weekend override as a country code (e.g., 'AE', 'IL') or weekday list (e.g., 'Fri,Sat') (optional, defaults to the calendar's weekend)",
+	}
+
+	// Register isBusinessDay tool handler
+	isBusinessDayHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		date := mcp.ParseString(request, "date", "now")
+		calendar := mcp.ParseString(request, "calendar", "")
+		weekend := mcp.ParseString(request, "weekend", "")
+		timezone := parseTimezone(request, "timezone")
+
+		info, err := s.timeService.IsBusinessDay(date, calendar, weekend, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(info)
+	}
+
+	isBusinessDayTool := mcp.Tool{
+		Name:        "isBusinessDay",
+		Description: "Check whether a date is a business day. IMPORTANT FOR LLMs: Use this tool instead of assuming which days are holidays, as holiday dates move every year. Returns the weekday, any holidays on that date and the previous and next business days.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"date": map[string]interface{}{
+					"type":        "string",
+					"description": "Date to check (e.g., '2024-12-25', any timestamp, or 'now'; defaults to 'now')",
+				},
+				"calendar": calendarProperty,
+				"weekend":  weekendProperty,
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone used to determine the calendar date (IANA name, UTC offset such as '+05:30' or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+			},
+		},
+	}
+
+	s.server.AddTool(isBusinessDayTool, isBusinessDayHandler)
+
+	// Register addBusinessDays tool handler
+	addBusinessDaysHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		date := mcp.ParseString(request, "date", "now")
+		days := mcp.ParseInt(request, "days", 0)
+		calendar := mcp.ParseString(request, "calendar", "")
+		weekend := mcp.ParseString(request, "weekend", "")
+		timezone := parseTimezone(request, "timezone")
+
+		addition, err := s.timeService.AddBusinessDays(date, days, calendar, weekend, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(addition)
+	}
+
+	addBusinessDaysTool := mcp.Tool{
+		Name:        "addBusinessDays",
+		Description: "Add or subtract business days from a date, skipping weekends and holidays. IMPORTANT FOR LLMs: Use this tool for deadlines such as '5 business days after X' instead of counting days yourself. Returns the resulting date and the holidays that were skipped.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"date": map[string]interface{}{
+					"type":        "string",
+					"description": "Start date (e.g., '2024-12-20', any timestamp, or 'now'; defaults to 'now')",
+				},
+				"days": map[string]interface{}{
+					"type":        "integer",
+					"description": "Number of business days to add, negative to subtract (0 rolls a non-business day forward)",
+				},
+				"calendar": calendarProperty,
+				"weekend":  weekendProperty,
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone used to determine the calendar date (IANA name, UTC offset such as '+05:30' or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+			},
+			Required: []string{"days"},
+		},
+	}
+
+	s.server.AddTool(addBusinessDaysTool, addBusinessDaysHandler)
+
+	// Register countBusinessDays tool handler
+	countBusinessDaysHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		from := mcp.ParseString(request, "from", "")
+		to := mcp.ParseString(request, "to", "")
+		calendar := mcp.ParseString(request, "calendar", "")
+		weekend := mcp.ParseString(request, "weekend", "")
+		timezone := parseTimezone(request, "timezone")
+
+		count, err := s.timeService.CountBusinessDays(from, to, calendar, weekend, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(count)
+	}
+
+	countBusinessDaysTool := mcp.Tool{
+		Name:        "countBusinessDays",
+		Description: "Count the business days between two dates, excluding weekends and holidays. The start date is included and the end date is excluded. Returns the business, weekend and calendar day counts and the holidays in the range.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start date, inclusive (e.g., '2024-12-01', any timestamp, or 'now')",
+				},
+				"to": map[string]interface{}{
+					"type":        "string",
+					"description": "End date, exclusive (e.g., '2025-01-01', any timestamp, or 'now')",
+				},
+				"calendar": calendarProperty,
+				"weekend":  weekendProperty,
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone used to determine the calendar dates (IANA name, UTC offset such as '+05:30' or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+			},
+			Required: []string{"from", "to"},
+		},
+	}
+
+	s.server.AddTool(countBusinessDaysTool, countBusinessDaysHandler)
+
+	// Register listTimezones tool handler
+	listTimezonesHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		region := mcp.ParseString(request, "region", "")
+		country := mcp.ParseString(request, "country", "")
+		includeLinks := mcp.ParseBoolean(request, "includeLinks", false)
+
+		list, err := s.timeService.ListTimezones(region, country, includeLinks)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(list)
+	}
+
+	listTimezonesTool := mcp.Tool{
+		Name:        "listTimezones",
+		Description: "List the IANA timezones available on this server, optionally filtered by region prefix or country code. Returns each zone's current UTC offset, abbreviation, daylight saving status and whether it is a canonical zone or a link (alias) to another zone.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"region": map[string]interface{}{
+					"type":        "string",
+					"description": "Region prefix (optional, e.g., 'Europe', 'America/Argentina')",
+				},
+				"country": map[string]interface{}{
+					"type":        "string",
+					"description": "ISO 3166 alpha-2 country code (optional, e.g., 'US', 'IN')",
+				},
+				"includeLinks": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include link (alias) names such as 'US/Eastern' (optional, defaults to false)",
+				},
+			},
+		},
+	}
+
+	s.server.AddTool(listTimezonesTool, listTimezonesHandler)
+
+	// Register searchTimezones tool handler
+	searchTimezonesHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		query := mcp.ParseString(request, "query", "")
+		limit := mcp.ParseInt(request, "limit", 0)
+
+		list, err := s.timeService.SearchTimezones(query, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(list)
+	}
+
+	searchTimezonesTool := mcp.Tool{
+		Name:        "searchTimezones",
+		Description: "Find IANA timezone names by city, region or country name. IMPORTANT FOR LLMs: Use this tool when a timezone name is rejected or you are unsure of the exact IANA name (e.g., 'new york', 'kolkata', 'india'). Results are ranked by match quality and include current offset, abbreviation and daylight saving status.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Free-text search (e.g., 'new york', 'kolkata', 'brazil')",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of results (optional, defaults to 20)",
+				},
+			},
+			Required: []string{"query"},
+		},
+	}
+
+	s.server.AddTool(searchTimezonesTool, searchTimezonesHandler)
+
+	// Register timezoneInfo tool handler
+	timezoneInfoHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		timezone := parseTimezone(request, "timezone")
+		from := mcp.ParseString(request, "from", "")
+		to := mcp.ParseString(request, "to", "")
+
+		details, err := s.timeService.ZoneInfo(timezone, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(details)
+	}
+
+	timezoneInfoTool := mcp.Tool{
+		Name:        "timezoneInfo",
+		Description: "Get a timezone's current UTC offset, abbreviation and daylight saving status, plus every offset transition (such as DST changes) within a time window. IMPORTANT FOR LLMs: Use this tool to find out when clocks change instead of relying on remembered DST rules, which differ between countries and change over time.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone (IANA name, UTC offset such as '+05:30' or abbreviation, e.g., 'Europe/London', or empty for UTC)",
+				},
+				"region": regionProperty,
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start of the transition window (optional, RFC3339 or 'now'; defaults to now)",
+				},
+				"to": map[string]interface{}{
+					"type":        "string",
+					"description": "End of the transition window (optional, RFC3339; defaults to one year after 'from')",
+				},
+			},
+			Required: []string{"timezone"},
+		},
+	}
+
+	s.server.AddTool(timezoneInfoTool, timezoneInfoHandler)
+
+	// Register relativeTime tool handler
+	relativeTimeHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		t := mcp.ParseString(request, "time", "")
+		reference := mcp.ParseString(request, "reference", "")
+		locale := mcp.ParseString(request, "locale", "")
+		opts := services.HumanizeOptions{
+			Timezone:    parseTimezone(request, "timezone"),
+			Style:       mcp.ParseString(request, "style", ""),
+			Granularity: mcp.ParseString(request, "granularity", ""),
+			Rounding:    mcp.ParseString(request, "rounding", ""),
+			Thresholds:  parseThresholds(request, "thresholds"),
+		}
+
+		relative, err := s.timeService.Humanize(t, reference, locale, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(relative)
+	}
+
+	relativeTimeTool := mcp.Tool{
+		Name:        "relativeTime",
+		Description: "Describe a time relative to now or another reference time, e.g. 'in 3 hours', '2 days ago', 'yesterday at 14:00' or 'last Tuesday'. IMPORTANT FOR LLMs: Use this tool instead of working out relative phrasing yourself, as you cannot know the current time and calendar-day boundaries depend on the timezone. Returns the phrase plus its components (direction, value, unit, calendar days, weekday) so you can rephrase it.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"time": map[string]interface{}{
+					"type":        "string",
+					"description": "Time to describe (RFC3339, another common timestamp format, or 'now')",
+				},
+				"reference": map[string]interface{}{
+					"type":        "string",
+					"description": "Time to describe it from (optional, same formats; defaults to now)",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone for calendar days such as 'yesterday' and times of day (IANA name, UTC offset such as '+05:30' or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+				"locale": localeProperty,
+				"style": map[string]interface{}{
+					"type":        "string",
+					"description": "'auto' (default): numeric within the same day and 'yesterday at 14:00' / 'last Tuesday' within a week; 'numeric': always 'in 3 hours' / '2 days ago'; 'calendar': day and weekday phrases within a week, otherwise the date",
+					"enum":        []string{"auto", "numeric", "calendar"},
+				},
+				"granularity": map[string]interface{}{
+					"type":        "string",
+					"description": "Smallest unit to report (optional, default 'second'); e.g. 'day' never reports minutes or hours",
+					"enum":        []string{"second", "minute", "hour", "day", "week", "month", "year"},
+				},
+				"rounding": map[string]interface{}{
+					"type":        "string",
+					"description": "How values are rounded (optional, default 'round')",
+					"enum":        []string{"round", "floor", "ceil"},
+				},
+				"thresholds": map[string]interface{}{
+					"type":        "object",
+					"description": "Value at which each unit switches to the next larger one (optional). Defaults: seconds 45, minutes 45, hours 22, days 7, weeks 4, months 11",
+					"properties": map[string]interface{}{
+						"seconds": map[string]interface{}{"type": "number"},
+						"minutes": map[string]interface{}{"type": "number"},
+						"hours":   map[string]interface{}{"type": "number"},
+						"days":    map[string]interface{}{"type": "number"},
+						"weeks":   map[string]interface{}{"type": "number"},
+						"months":  map[string]interface{}{"type": "number"},
+					},
+				},
+			},
+			Required: []string{"time"},
+		},
+	}
+
+	s.server.AddTool(relativeTimeTool, relativeTimeHandler)
+
+	// Register resolveDateExpression tool handler
+	resolveDateExpressionHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		expression := mcp.ParseString(request, "expression", "")
+		reference := mcp.ParseString(request, "reference", "")
+		timezone := parseTimezone(request, "timezone")
+
+		resolved, err := s.timeService.ResolveDateExpression(expression, reference, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(resolved)
+	}
+
+	resolveDateExpressionTool := mcp.Tool{
+		Name:        "resolveDateExpression",
+		Description: "Resolve an English date expression such as 'next Friday at 9am Pacific', 'end of month', 'in two weeks', 'the second Tuesday of March', 'tomorrow morning' or '3/4 at 14:30' to an exact instant. IMPORTANT FOR LLMs: Use this tool whenever a user refers to a date or time in words, as you cannot know the current date and phrases like 'next Friday' depend on it. Returns the resolved time, the interpretation chosen, its precision and a confidence; ambiguous phrases list the other readings as alternatives, which you should confirm with the user when the confidence is low.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"expression": map[string]interface{}{
+					"type":        "string",
+					"description": "Date expression in English. May end with a timezone ('Pacific', 'PST', 'Europe/Paris', 'UTC+2', 'Tokyo time'), which takes precedence over the timezone parameter",
+				},
+				"reference": map[string]interface{}{
+					"type":        "string",
+					"description": "Instant to resolve relative phrases from (optional, RFC3339 or another common timestamp format; defaults to now)",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone the expression is meant in when it names none (IANA name, UTC offset such as '+05:30' or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+			},
+			Required: []string{"expression"},
+		},
+	}
+
+	s.server.AddTool(resolveDateExpressionTool, resolveDateExpressionHandler)
+
+	// Register cronNextRuns tool handler
+	cronNextRunsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		expression := mcp.ParseString(request, "expression", "")
+		reference := mcp.ParseString(request, "from", "")
+		opts := services.CronOptions{
+			Timezone:  parseTimezone(request, "timezone"),
+			Dialect:   mcp.ParseString(request, "dialect", ""),
+			Count:     mcp.ParseInt(request, "count", 0),
+			Direction: mcp.ParseString(request, "direction", ""),
+		}
+
+		schedule, err := s.timeService.CronRuns(expression, reference, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(schedule)
+	}
+
+	cronNextRunsTool := mcp.Tool{
+		Name:        "cronNextRuns",
+		Description: "List the next (or previous) fire times of a cron expression in a timezone, with a plain-English description of the schedule. Accepts standard 5-field cron, 6-field cron with seconds, Quartz expressions (with ?, L, W and #) and macros such as @daily. IMPORTANT FOR LLMs: Use this tool instead of working out cron schedules yourself, as you cannot know the current time and runs near daylight saving transitions are easy to get wrong.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"expression": map[string]interface{}{
+					"type":        "string",
+					"description": "Cron expression (e.g., '0 */4 * * 1-5', '0 30 9 * * *', '0 15 10 ? * 6L', '@weekly')",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone the schedule runs in (IANA name, UTC offset such as '+05:30' or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "Time to list runs after, or before for previous runs (optional, RFC3339 or another common timestamp format; defaults to now)",
+				},
+				"count": map[string]interface{}{
+					"type":        "integer",
+					"description": "Number of runs to list (optional, defaults to 5, at most 100)",
+				},
+				"direction": map[string]interface{}{
+					"type":        "string",
+					"description": "List upcoming runs ('next', default) or past runs ('previous')",
+					"enum":        []string{"next", "previous"},
+				},
+				"dialect": map[string]interface{}{
+					"type":        "string",
+					"description": "'auto' (default): Quartz for 7 fields or 6 fields using '?', standard otherwise; 'standard': 5 fields or 6 with seconds first, Sunday is 0 or 7; 'quartz': seconds first, optional year, Sunday is 1",
+					"enum":        []string{"auto", "standard", "quartz"},
+				},
+			},
+			Required: []string{"expression"},
+		},
+	}
+
+	s.server.AddTool(cronNextRunsTool, cronNextRunsHandler)
+
+	// Register expandRecurrence tool handler
+	expandRecurrenceHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		rule := mcp.ParseString(request, "rrule", "")
+		opts := services.RecurrenceOptions{
+			Start:    mcp.ParseString(request, "dtstart", ""),
+			Timezone: parseTimezone(request, "timezone"),
+			From:     mcp.ParseString(request, "from", ""),
+			To:       mcp.ParseString(request, "to", ""),
+			ExDates:  parseStringList(request, "exdates"),
+			RDates:   parseStringList(request, "rdates"),
+			Limit:    mcp.ParseInt(request, "limit", 0),
+		}
+
+		expansion, err := s.timeService.ExpandRecurrence(rule, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(expansion)
+	}
+
+	expandRecurrenceTool := mcp.Tool{
+		Name:        "expandRecurrence",
+		Description: "Expand an iCalendar (RFC 5545) recurrence rule into its occurrences between two instants, computed on the wall clock of the DTSTART timezone. Supports FREQ, INTERVAL, COUNT, UNTIL, BYDAY with ordinals (e.g., '-1FR'), BYMONTHDAY, BYYEARDAY, BYWEEKNO, BYMONTH, BYHOUR, BYMINUTE, BYSECOND, BYSETPOS, WKST, EXDATE and RDATE. IMPORTANT FOR LLMs: Use this tool instead of expanding recurrence rules yourself, as ordinal weekdays, BYSETPOS and daylight saving transitions are easy to get wrong.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"rrule": map[string]interface{}{
+					"type":        "string",
+					"description": "Recurrence rule, either a bare RRULE value (e.g., 'FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1') or iCalendar lines separated by newlines (e.g., 'DTSTART;TZID=Europe/Paris:20240105T090000', 'RRULE:FREQ=WEEKLY;BYDAY=FR', 'EXDATE;TZID=Europe/Paris:20240112T090000')",
+				},
+				"dtstart": map[string]interface{}{
+					"type":        "string",
+					"description": "First occurrence, when the rule has no DTSTART line (e.g., '20240105T090000', '2024-01-05T09:00:00' or a date such as '2024-01-05' for all-day events)",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone of a DTSTART without an offset or TZID (IANA name, UTC offset or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start of the window, inclusive (optional, defaults to DTSTART)",
+				},
+				"to": map[string]interface{}{
+					"type":        "string",
+					"description": "End of the window, inclusive (optional, defaults to unbounded)",
+				},
+				"exdates": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Occurrences to exclude (optional); a date without a time excludes every occurrence on that day",
+				},
+				"rdates": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Additional occurrences (optional); a date without a time uses the DTSTART time of day",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Maximum occurrences to return (optional, defaults to and at most %d); the result is marked truncated when more exist", s.config.MaxOccurrences),
+				},
+			},
+			Required: []string{"rrule"},
+		},
+	}
+
+	s.server.AddTool(expandRecurrenceTool, expandRecurrenceHandler)
+
+	// Register findMeetingSlots tool handler
+	findMeetingSlotsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		opts := services.MeetingOptions{
+			Participants: parseParticipants(request, "participants"),
+			Duration:     mcp.ParseString(request, "duration", ""),
+			From:         mcp.ParseString(request, "from", ""),
+			To:           mcp.ParseString(request, "to", ""),
+			Timezone:     parseTimezone(request, "timezone"),
+			Step:         mcp.ParseInt(request, "step", 0),
+			Limit:        mcp.ParseInt(request, "limit", 0),
+		}
+
+		plan, err := s.timeService.FindMeetingSlots(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(plan)
+	}
+
+	findMeetingSlotsTool := mcp.Tool{
+		Name:        "findMeetingSlots",
+		Description: "Find meeting times that fall within every participant's working hours on their business days, ranked so that no one meets too early or too late, with each participant's local time. IMPORTANT FOR LLMs: Use this tool instead of working out overlapping hours across timezones yourself, as offsets, daylight saving changes and holidays differ between participants.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"participants": map[string]interface{}{
+					"type":        "array",
+					"description": "Participants, each with a timezone and optional working hours and holiday calendar (at most 20)",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"name": map[string]interface{}{
+								"type":        "string",
+								"description": "Label for the participant (optional, defaults to the timezone)",
+							},
+							"timezone": map[string]interface{}{
+								"type":        "string",
+								"description": "Participant's timezone (IANA name, UTC offset or abbreviation)",
+							},
+							"workingHours": map[string]interface{}{
+								"type":        "string",
+								"description": "Local working hours such as '09:00-17:00', or '22:00-06:00' for a window past midnight (optional, defaults to '09:00-17:00')",
+							},
+							"calendar": calendarProperty,
+							"weekend":  weekendProperty,
+						},
+						"required": []string{"timezone"},
+					},
+				},
+				"duration": map[string]interface{}{
+					"type":        "string",
+					"description": "Meeting length as a Go duration (e.g., '30m', '1h30m') or ISO 8601 duration (e.g., 'PT45M')",
+				},
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start of the search range (optional, a date or timestamp; defaults to now)",
+				},
+				"to": map[string]interface{}{
+					"type":        "string",
+					"description": "End of the search range; a date includes the whole day (optional, defaults to 7 days after 'from', at most 31 days)",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone used to read the range and to show slot times (IANA name, UTC offset or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+				"step": map[string]interface{}{
+					"type":        "integer",
+					"description": "Minutes between candidate start times (optional, defaults to 30)",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Number of slots to return (optional, defaults to 10, at most 50)",
+				},
+			},
+			Required: []string{"participants", "duration"},
+		},
+	}
+
+	s.server.AddTool(findMeetingSlotsTool, findMeetingSlotsHandler)
+
+	latitudeProperty := map[string]interface{}{
+		"type":        "number",
+		"description": "Latitude in decimal degrees, north positive (e.g., 40.7128)",
+	}
+	longitudeProperty := map[string]interface{}{
+		"type":        "number",
+		"description": "Longitude in decimal degrees, east positive (e.g., -74.0060)",
+	}
+
+	// Register solarTimes tool handler
+	solarTimesHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		latitude := mcp.ParseFloat64(request, "latitude", 0)
+		longitude := mcp.ParseFloat64(request, "longitude", 0)
+		date := mcp.ParseString(request, "date", "now")
+		timezone := parseTimezone(request, "timezone")
+
+		times, err := s.timeService.SolarTimes(latitude, longitude, date, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(times)
+	}
+
+	solarTimesTool := mcp.Tool{
+		Name:        "solarTimes",
+		Description: "Get sunrise, sunset, solar noon, day length and civil, nautical and astronomical twilight for a location and date, using the NOAA solar algorithms. Polar day and polar night are reported explicitly. IMPORTANT FOR LLMs: Use this tool instead of estimating daylight times yourself, as they depend on latitude, longitude, season and timezone.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"latitude":  latitudeProperty,
+				"longitude": longitudeProperty,
+				"date": map[string]interface{}{
+					"type":        "string",
+					"description": "Date to compute (e.g., '2024-06-21', any timestamp, or 'now'; defaults to 'now')",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone used to read the date and show the times, usually the location's own (IANA name, UTC offset or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+			},
+			Required: []string{"latitude", "longitude"},
+		},
+	}
+
+	s.server.AddTool(solarTimesTool, solarTimesHandler)
+
+	// Register moonPhase tool handler
+	moonPhaseHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		date := mcp.ParseString(request, "date", "now")
+		timezone := parseTimezone(request, "timezone")
+
+		// Moonrise and moonset are only computed when a location is given
+		var location *services.Coordinates
+		if mcp.ParseArgument(request, "latitude", nil) != nil || mcp.ParseArgument(request, "longitude", nil) != nil {
+			location = &services.Coordinates{
+				Latitude:  mcp.ParseFloat64(request, "latitude", 0),
+				Longitude: mcp.ParseFloat64(request, "longitude", 0),
+			}
+		}
+
+		phase, err := s.timeService.MoonPhase(date, timezone, location)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(phase)
+	}
+
+	moonPhaseTool := mcp.Tool{
+		Name:        "moonPhase",
+		Description: "Get the moon's phase name, illuminated fraction, age and the next new and full moons for a date or instant. With a latitude and longitude, also returns the moonrise and moonset of the local date, including days when the moon does not rise or set. IMPORTANT FOR LLMs: Use this tool instead of estimating moon phases yourself, as lunations vary in length and phases fall on different dates in different timezones.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"date": map[string]interface{}{
+					"type":        "string",
+					"description": "Date or instant to compute (e.g., '2024-01-25', '2024-01-25T18:00:00Z', or 'now'; defaults to 'now'). A date alone is read as local noon.",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone used to read the date and show the times (IANA name, UTC offset or abbreviation, or empty for UTC)",
+				},
+				"region":    regionProperty,
+				"latitude":  latitudeProperty,
+				"longitude": longitudeProperty,
+			},
+		},
+	}
+
+	s.server.AddTool(moonPhaseTool, moonPhaseHandler)
+
+	// Register convertCalendar tool handler
+	convertCalendarHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		date := mcp.ParseString(request, "date", "today")
+		from := mcp.ParseString(request, "from", "gregorian")
+		to := mcp.ParseString(request, "to", "all")
+		timezone := parseTimezone(request, "timezone")
+
+		conversion, err := s.timeService.ConvertCalendar(date, from, to, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(conversion)
+	}
+
+	convertCalendarTool := mcp.Tool{
+		Name:        "convertCalendar",
+		Description: "Convert a date between the Gregorian calendar and the Hebrew, Islamic (tabular civil or Saudi Umm al-Qura), Persian (Solar Hijri), Chinese lunisolar, Japanese imperial era, Thai Buddhist, Ethiopian, Coptic and Indian national calendars. Returns the Gregorian date, the weekday and the date in each requested calendar with its era, month name and an English rendering such as '1 Ramadan 1445 AH'. IMPORTANT FOR LLMs: Use this tool instead of converting dates yourself, as these calendars follow lunar and solar rules that cannot be worked out reliably by hand.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"date": map[string]interface{}{
+					"type":        "string",
+					"description": "Date to convert (defaults to 'today'). Gregorian dates may be any date or timestamp (e.g., '2024-10-03' or 'today'); other calendars take YYYY-MM-DD, with the era first for Japanese dates (e.g., 'Reiwa 6-05-01' or 'R6-05-01') and an L after the month for Chinese leap months (e.g., '2023-02L-01'). Hebrew months are numbered from Nisan, so Tishrei is 7 and Adar II is 13; Chinese years are the Gregorian year in which they begin.",
+				},
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "Calendar system of the date (optional, defaults to gregorian)",
+					"enum":        calendars.IDs(),
+				},
+				"to": map[string]interface{}{
+					"type":        "string",
+					"description": "Comma-separated calendar systems to convert to (optional, e.g., 'hebrew,islamic-umalqura'; defaults to 'all')",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone used to read Gregorian dates such as 'today' (IANA name, UTC offset or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+			},
+		},
+	}
+
+	s.server.AddTool(convertCalendarTool, convertCalendarHandler)
+
+	// Register dateInfo tool handler
+	dateInfoHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		date := mcp.ParseString(request, "date", "today")
+		timezone := parseTimezone(request, "timezone")
+		weekStart := mcp.ParseString(request, "weekStart", "")
+
+		info, err := s.timeService.DateInfo(date, timezone, weekStart)
+		if err != nil {
+			return nil, err
+		}
+
+		return newToolResultJSON(info)
+	}
+
+	dateInfoTool := mcp.Tool{
+		Name:        "dateInfo",
+		Description: "Get the calendar facts of a date: weekday, ISO year, week and weekday, US and broadcast week numbers, day of year, quarter, days in the month and year, leap year, the first and last days of its week, month, quarter and year, and whether the timezone changes its offset (e.g., for DST) that day, with the day's length in hours. IMPORTANT FOR LLMs: Use this tool instead of working out weekdays, week numbers or month lengths yourself, as these are easy to get wrong, especially around year ends and DST changes.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"date": map[string]interface{}{
+					"type":        "string",
+					"description": "Date to describe (optional, e.g., '2027-03-14', 'today' or an RFC3339 timestamp; defaults to 'today')",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "Timezone used to read the date and check for offset changes (IANA name, UTC offset or abbreviation, or empty for UTC)",
+				},
+				"region": regionProperty,
+				"weekStart": map[string]interface{}{
+					"type":        "string",
+					"description": "First day of the week for weekStart and weekEnd (optional, e.g., 'sunday'; defaults to 'monday')",
+				},
+			},
+		},
+	}
+
+	s.server.AddTool(dateInfoTool, dateInfoHandler)
+
+	log.Printf("Registered %d tools", 24)
+	return nil
+}
+
+// parseTimezone reads a timezone argument, qualified with the optional region
+// hint used to disambiguate abbreviations such as "IST"
+func parseTimezone(request mcp.CallToolRequest, key string) string {
+	return services.QualifyTimezone(mcp.ParseString(request, key, ""), mcp.ParseString(request, "region", ""))
+}
+
+// parseThresholds reads the optional unit thresholds of the relativeTime tool;
+// missing or non-numeric entries keep their defaults
+func parseThresholds(request mcp.CallToolRequest, key string) services.RelativeThresholds {
+	values := mcp.ParseStringMap(request, key, nil)
+	number := func(name string) float64 {
+		n, _ := values[name].(float64)
+		return n
+	}
+
+	return services.RelativeThresholds{
+		Seconds: number("seconds"),
+		Minutes: number("minutes"),
+		Hours:   number("hours"),
+		Days:    number("days"),
+		Weeks:   number("weeks"),
+		Months:  number("months"),
+	}
+}
+
+// parseStringList reads a list argument given either as an array of strings
+// or as a single comma-separated string
+func parseStringList(request mcp.CallToolRequest, key string) []string {
+	var values []string
+	switch arg := mcp.ParseArgument(request, key, nil).(type) {
+	case string:
+		for _, value := range strings.Split(arg, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	case []interface{}:
+		for _, item := range arg {
+			if value, ok := item.(string); ok && value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// parseParticipants reads the participant objects of the findMeetingSlots
+// tool, qualifying each timezone with the optional region hint
+func parseParticipants(request mcp.CallToolRequest, key string) []services.MeetingParticipant {
+	items, _ := mcp.ParseArgument(request, key, nil).([]interface{})
+	region := mcp.ParseString(request, "region", "")
+
+	participants := make([]services.MeetingParticipant, 0, len(items))
+	for _, item := range items {
+		fields, _ := item.(map[string]interface{})
+		field := func(name string) string {
+			value, _ := fields[name].(string)
+			return value
+		}
+
+		participants = append(participants, services.MeetingParticipant{
+			Name:         field("name"),
+			Timezone:     services.QualifyTimezone(field("timezone"), region),
+			WorkingHours: field("workingHours"),
+			Calendars:    field("calendar"),
+			Weekend:      field("weekend"),
+		})
+	}
+	return participants
+}
+
+// newToolResultJSON encodes a structured result as an indented JSON text result
+func newToolResultJSON(v interface{}) (*mcp.CallToolResult, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tool result: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(data)), nil
 }
 
 // createTransport creates the appropriate transport based on configuration
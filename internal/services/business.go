@@ -0,0 +1,350 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxBusinessDaySpan limits how many calendar days a business day operation may walk
+const maxBusinessDaySpan = 366 * 100
+
+// BusinessDayInfo describes whether a date is a business day
+type BusinessDayInfo struct {
+	Date                string    `json:"date"`
+	Weekday             string    `json:"weekday"`
+	IsBusinessDay       bool      `json:"isBusinessDay"`
+	IsWeekend           bool      `json:"isWeekend"`
+	Holidays            []Holiday `json:"holidays,omitempty"`
+	PreviousBusinessDay string    `json:"previousBusinessDay"`
+	NextBusinessDay     string    `json:"nextBusinessDay"`
+	Calendars           []string  `json:"calendars"`
+}
+
+// BusinessDayAddition is the result of adding business days to a date
+type BusinessDayAddition struct {
+	Start           string    `json:"start"`
+	Days            int       `json:"days"`
+	Result          string    `json:"result"`
+	Weekday         string    `json:"weekday"`
+	SkippedHolidays []Holiday `json:"skippedHolidays,omitempty"`
+	Calendars       []string  `json:"calendars"`
+}
+
+// BusinessDayCount is the number of business days in a date range
+type BusinessDayCount struct {
+	From         string    `json:"from"`
+	To           string    `json:"to"`
+	BusinessDays int       `json:"businessDays"`
+	CalendarDays int       `json:"calendarDays"`
+	WeekendDays  int       `json:"weekendDays"`
+	Holidays     []Holiday `json:"holidays,omitempty"`
+	Calendars    []string  `json:"calendars"`
+}
+
+// businessDays combines one or more calendars with a weekend definition
+type businessDays struct {
+	calendars []*BusinessCalendar
+	weekend   [7]bool
+}
+
+// IsBusinessDay reports whether a date is a working day in the given calendars.
+// Calendars is a comma-separated list of calendar IDs (empty for weekends only);
+// weekend optionally overrides the weekend with a country code or day list.
+func (ts *timeService) IsBusinessDay(date, calendars, weekend, timezone string) (*BusinessDayInfo, error) {
+	bd, err := ts.resolveBusinessDays(calendars, weekend)
+	if err != nil {
+		return nil, err
+	}
+
+	day, err := ts.parseCivilDate(date, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := bd.holidaysOn(day)
+	return &BusinessDayInfo{
+		Date:                day.Format("2006-01-02"),
+		Weekday:             day.Weekday().String(),
+		IsBusinessDay:       bd.isBusinessDay(day),
+		IsWeekend:           bd.weekend[day.Weekday()],
+		Holidays:            holidays,
+		PreviousBusinessDay: bd.step(day, -1).Format("2006-01-02"),
+		NextBusinessDay:     bd.step(day, 1).Format("2006-01-02"),
+		Calendars:           bd.ids(),
+	}, nil
+}
+
+// AddBusinessDays moves a date forward (or backward, for negative days) by a
+// number of business days. Adding zero days rolls a non-business day forward
+// to the next business day.
+func (ts *timeService) AddBusinessDays(date string, days int, calendars, weekend, timezone string) (*BusinessDayAddition, error) {
+	if days > maxBusinessDaySpan || days < -maxBusinessDaySpan {
+		return nil, NewTimeServiceError(
+			ErrCodeTimeOperation,
+			fmt.Sprintf("days must be between -%d and %d", maxBusinessDaySpan, maxBusinessDaySpan),
+			"days",
+			nil,
+		)
+	}
+
+	bd, err := ts.resolveBusinessDays(calendars, weekend)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := ts.parseCivilDate(date, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	result := start
+	var skipped []Holiday
+	if days == 0 {
+		for !bd.isBusinessDay(result) {
+			skipped = append(skipped, bd.workdayHolidays(result)...)
+			result = result.AddDate(0, 0, 1)
+		}
+	} else {
+		direction := 1
+		if days < 0 {
+			direction = -1
+		}
+		for remaining := days * direction; remaining > 0; {
+			result = result.AddDate(0, 0, direction)
+			if bd.isBusinessDay(result) {
+				remaining--
+			} else {
+				skipped = append(skipped, bd.workdayHolidays(result)...)
+			}
+		}
+	}
+
+	return &BusinessDayAddition{
+		Start:           start.Format("2006-01-02"),
+		Days:            days,
+		Result:          result.Format("2006-01-02"),
+		Weekday:         result.Weekday().String(),
+		SkippedHolidays: skipped,
+		Calendars:       bd.ids(),
+	}, nil
+}
+
+// CountBusinessDays counts business days from one date (inclusive) to another
+// (exclusive). The count is negative when to is before from.
+func (ts *timeService) CountBusinessDays(from, to, calendars, weekend, timezone string) (*BusinessDayCount, error) {
+	bd, err := ts.resolveBusinessDays(calendars, weekend)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := ts.parseCivilDate(from, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := ts.parseCivilDate(to, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	sign := 1
+	lo, hi := start, end
+	if end.Before(start) {
+		sign = -1
+		lo, hi = end, start
+	}
+
+	span := int(hi.Sub(lo).Hours() / 24)
+	if span > maxBusinessDaySpan {
+		return nil, NewTimeServiceError(
+			ErrCodeTimeOperation,
+			fmt.Sprintf("date range cannot exceed %d days", maxBusinessDaySpan),
+			"to",
+			nil,
+		)
+	}
+
+	result := &BusinessDayCount{
+		From:         start.Format("2006-01-02"),
+		To:           end.Format("2006-01-02"),
+		CalendarDays: span * sign,
+		Calendars:    bd.ids(),
+	}
+
+	for day := lo; day.Before(hi); day = day.AddDate(0, 0, 1) {
+		switch {
+		case bd.weekend[day.Weekday()]:
+			result.WeekendDays++
+		case bd.isBusinessDay(day):
+			result.BusinessDays++
+		default:
+			result.Holidays = append(result.Holidays, bd.holidaysOn(day)...)
+		}
+	}
+	result.BusinessDays *= sign
+
+	return result, nil
+}
+
+// parseCivilDate parses a date or timestamp and returns its calendar day in the timezone
+func (ts *timeService) parseCivilDate(date, timezone string) (time.Time, error) {
+	loc, err := ts.loadLocation(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := ts.parseInstant(date, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	year, month, day := t.In(loc).Date()
+	return civilDate(year, month, day), nil
+}
+
+// resolveBusinessDays looks up the requested calendars and weekend definition
+func (ts *timeService) resolveBusinessDays(calendars, weekend string) (*businessDays, error) {
+	bd := &businessDays{}
+
+	for _, id := range strings.Split(calendars, ",") {
+		id = strings.ToLower(strings.TrimSpace(id))
+		if id == "" {
+			continue
+		}
+		cal, ok := ts.calendars[id]
+		if !ok {
+			return nil, NewInvalidCalendarError(id, fmt.Sprintf("unknown calendar, available: %s", strings.Join(ts.calendarIDs(), ", ")), nil)
+		}
+		bd.calendars = append(bd.calendars, cal)
+	}
+
+	switch {
+	case weekend != "":
+		days, err := parseWeekend(weekend)
+		if err != nil {
+			return nil, err
+		}
+		bd.weekend = days
+	case len(bd.calendars) == 0:
+		bd.weekend = countryWeekend("")
+	default:
+		for _, cal := range bd.calendars {
+			for wd, isWeekend := range cal.weekend {
+				bd.weekend[wd] = bd.weekend[wd] || isWeekend
+			}
+		}
+	}
+
+	if bd.weekend == [7]bool{true, true, true, true, true, true, true} {
+		return nil, NewTimeServiceError(
+			ErrCodeInvalidCalendar,
+			"weekend cannot cover every day of the week",
+			"weekend",
+			nil,
+		)
+	}
+
+	return bd, nil
+}
+
+// calendarIDs returns the registered calendar IDs in sorted order
+func (ts *timeService) calendarIDs() []string {
+	ids := make([]string, 0, len(ts.calendars))
+	for id := range ts.calendars {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// parseWeekend parses a weekend given as a country code ("AE") or day list ("Fri,Sat")
+func parseWeekend(weekend string) ([7]bool, error) {
+	var days [7]bool
+
+	weekend = strings.TrimSpace(weekend)
+	if len(weekend) == 2 {
+		if _, ok := getZoneCatalog().countryNames[strings.ToUpper(weekend)]; !ok {
+			return days, NewTimeServiceError(
+				ErrCodeInvalidCalendar,
+				fmt.Sprintf("unknown country code '%s': expected an ISO 3166 alpha-2 code such as 'AE'", weekend),
+				"weekend",
+				nil,
+			)
+		}
+		return countryWeekend(weekend), nil
+	}
+
+	for _, name := range strings.Split(weekend, ",") {
+		wd, ok := parseWeekday(name)
+		if !ok {
+			return days, NewTimeServiceError(
+				ErrCodeInvalidCalendar,
+				fmt.Sprintf("invalid weekend '%s': expected a country code or a list of weekdays", weekend),
+				"weekend",
+				nil,
+			)
+		}
+		days[wd] = true
+	}
+
+	return days, nil
+}
+
+// isBusinessDay reports whether a civil date is neither a weekend day nor a holiday
+func (bd *businessDays) isBusinessDay(day time.Time) bool {
+	if bd.weekend[day.Weekday()] {
+		return false
+	}
+	for _, cal := range bd.calendars {
+		if len(cal.holidaysOn(day)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// holidaysOn returns the holidays of every calendar observed on a civil date
+func (bd *businessDays) holidaysOn(day time.Time) []Holiday {
+	var holidays []Holiday
+	for _, cal := range bd.calendars {
+		for _, h := range cal.holidaysOn(day) {
+			holidays = append(holidays, Holiday{
+				Date:     h.date.Format("2006-01-02"),
+				Name:     h.name,
+				Observed: h.observed,
+				Calendar: cal.id,
+			})
+		}
+	}
+	return holidays
+}
+
+// workdayHolidays returns the holidays on a date that is not already a weekend day
+func (bd *businessDays) workdayHolidays(day time.Time) []Holiday {
+	if bd.weekend[day.Weekday()] {
+		return nil
+	}
+	return bd.holidaysOn(day)
+}
+
+// step returns the nearest business day strictly before (-1) or after (+1) a date
+func (bd *businessDays) step(day time.Time, direction int) time.Time {
+	for i := 0; i < maxBusinessDaySpan; i++ {
+		day = day.AddDate(0, 0, direction)
+		if bd.isBusinessDay(day) {
+			break
+		}
+	}
+	return day
+}
+
+// ids returns the IDs of the combined calendars
+func (bd *businessDays) ids() []string {
+	ids := make([]string, len(bd.calendars))
+	for i, cal := range bd.calendars {
+		ids[i] = cal.id
+	}
+	return ids
+}
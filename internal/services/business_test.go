@@ -0,0 +1,195 @@
+package services
+
+import "testing"
+
+func TestTimeService_AddBusinessDays(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name     string
+		date     string
+		days     int
+		calendar string
+		weekend  string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Five business days over a weekend",
+			date:     "2024-01-10",
+			days:     5,
+			expected: "2024-01-17",
+		},
+		{
+			name:     "Skips US Thanksgiving",
+			date:     "2024-11-27",
+			days:     1,
+			calendar: "us",
+			expected: "2024-11-29",
+		},
+		{
+			name:     "Skips UK Christmas and Boxing Day",
+			date:     "2024-12-24",
+			days:     1,
+			calendar: "uk",
+			expected: "2024-12-27",
+		},
+		{
+			name:     "Backwards over Easter",
+			date:     "2024-04-02",
+			days:     -1,
+			calendar: "target2",
+			expected: "2024-03-28",
+		},
+		{
+			name:     "Zero rolls weekend forward",
+			date:     "2024-01-13",
+			days:     0,
+			expected: "2024-01-15",
+		},
+		{
+			name:     "Combined calendars",
+			date:     "2024-05-24",
+			days:     1,
+			calendar: "us,uk",
+			expected: "2024-05-28",
+		},
+		{
+			name:    "Malformed weekend",
+			date:    "2024-01-11",
+			days:    1,
+			weekend: "AE,",
+			wantErr: true,
+		},
+		{
+			name:     "Country weekend override",
+			date:     "2024-01-11",
+			days:     1,
+			weekend:  "IL",
+			expected: "2024-01-14",
+		},
+		{
+			name:    "Unknown country weekend",
+			date:    "2024-01-11",
+			days:    1,
+			weekend: "XX",
+			wantErr: true,
+		},
+		{
+			name:     "Unknown calendar",
+			date:     "2024-01-11",
+			days:     1,
+			calendar: "atlantis",
+			wantErr:  true,
+		},
+		{
+			name:    "Weekend covering every day",
+			date:    "2024-01-11",
+			days:    1,
+			weekend: "Mon,Tue,Wed,Thu,Fri,Sat,Sun",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.AddBusinessDays(tt.date, tt.days, tt.calendar, tt.weekend, "")
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			if result.Result != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result.Result)
+			}
+		})
+	}
+}
+
+func TestTimeService_CountBusinessDays(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name             string
+		from             string
+		to               string
+		calendar         string
+		expected         int
+		expectedHolidays int
+	}{
+		{
+			name:     "One week",
+			from:     "2024-01-08",
+			to:       "2024-01-15",
+			expected: 5,
+		},
+		{
+			name:             "December with US holidays",
+			from:             "2024-12-01",
+			to:               "2025-01-01",
+			calendar:         "us",
+			expected:         21,
+			expectedHolidays: 1,
+		},
+		{
+			name:             "Reversed range is negative",
+			from:             "2025-01-01",
+			to:               "2024-12-01",
+			calendar:         "us",
+			expected:         -21,
+			expectedHolidays: 1,
+		},
+		{
+			name:     "Empty range",
+			from:     "2024-01-08",
+			to:       "2024-01-08",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.CountBusinessDays(tt.from, tt.to, tt.calendar, "", "")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.BusinessDays != tt.expected {
+				t.Errorf("Expected %d business days, got %d", tt.expected, result.BusinessDays)
+			}
+
+			if len(result.Holidays) != tt.expectedHolidays {
+				t.Errorf("Expected %d holidays, got %d", tt.expectedHolidays, len(result.Holidays))
+			}
+		})
+	}
+}
+
+func TestTimeService_IsBusinessDay(t *testing.T) {
+	ts := NewTimeService()
+
+	info, err := ts.IsBusinessDay("2024-07-04", "us", "", "America/New_York")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if info.IsBusinessDay {
+		t.Errorf("Expected Independence Day not to be a business day")
+	}
+
+	if len(info.Holidays) != 1 || info.Holidays[0].Name != "Independence Day" {
+		t.Errorf("Expected Independence Day holiday, got %v", info.Holidays)
+	}
+
+	if info.NextBusinessDay != "2024-07-05" || info.PreviousBusinessDay != "2024-07-03" {
+		t.Errorf("Unexpected neighbouring business days %s and %s", info.PreviousBusinessDay, info.NextBusinessDay)
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zodimo/go-time-mcp/internal/calendars"
+)
+
+// CalendarDate is a date in a calendar system. YearName is the sexagenary
+// name of a Chinese year and LeapMonth marks a Chinese leap month.
+type CalendarDate struct {
+	Calendar  string `json:"calendar"`
+	Era       string `json:"era,omitempty"`
+	Year      int    `json:"year"`
+	Month     int    `json:"month"`
+	LeapMonth bool   `json:"leapMonth,omitempty"`
+	Day       int    `json:"day"`
+	MonthName string `json:"monthName"`
+	YearName  string `json:"yearName,omitempty"`
+	Formatted string `json:"formatted"`
+}
+
+// CalendarConversion is one day expressed in several calendar systems
+type CalendarConversion struct {
+	Input     string         `json:"input"`
+	From      string         `json:"from"`
+	Gregorian string         `json:"gregorian"`
+	Weekday   string         `json:"weekday"`
+	Dates     []CalendarDate `json:"dates"`
+}
+
+// calendarDatePattern matches a date in a non-Gregorian calendar: an
+// optional era, the year, the month with an optional L for a Chinese leap
+// month, and the day, e.g. "5785-07-01", "Reiwa 6-05-01" or "2023-02L-01"
+var calendarDatePattern = regexp.MustCompile(`^(?:(\pL[\pL.]*)\s*)?(-?\d+)-(\d{1,2})([lL]?)-(\d{1,2})$`)
+
+// ConvertCalendar converts a date between the Gregorian calendar and the
+// Hebrew, Islamic, Persian, Chinese, Japanese, Buddhist, Ethiopian, Coptic
+// and Indian national calendars. Gregorian dates are read like other dates,
+// so "today", the default, and timestamps work, in the given timezone; dates
+// in other calendars are written year-month-day with months numbered as in
+// the calendars package. to is a comma-separated list of calendar systems or
+// "all", the default, for every system other than from; with "all", systems
+// that do not cover the date are left out.
+func (ts *timeService) ConvertCalendar(date, from, to, timezone string) (*CalendarConversion, error) {
+	fromID, err := lookupCalendarSystem(from)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixed int
+	if fromID == calendars.Gregorian {
+		if strings.TrimSpace(date) == "" {
+			date = "today"
+		}
+		day, err := ts.parseCivilDate(date, timezone)
+		if err != nil {
+			return nil, err
+		}
+		fixed = calendars.Fixed(day.Date())
+	} else {
+		d, err := parseCalendarDate(date, fromID)
+		if err != nil {
+			return nil, err
+		}
+		if fixed, err = calendars.ToFixed(d); err != nil {
+			return nil, NewInvalidTimeError(date, err.Error(), err)
+		}
+	}
+
+	all := strings.TrimSpace(to) == "" || strings.EqualFold(strings.TrimSpace(to), "all")
+	var ids []string
+	if all {
+		for _, id := range calendars.IDs() {
+			if id != fromID {
+				ids = append(ids, id)
+			}
+		}
+	} else {
+		for _, name := range strings.Split(to, ",") {
+			id, err := lookupCalendarSystem(name)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	year, month, day := calendars.GregorianDate(fixed)
+	result := &CalendarConversion{
+		Input:     date,
+		From:      fromID,
+		Gregorian: civilDate(year, month, day).Format(time.DateOnly),
+		Weekday:   calendars.Weekday(fixed).String(),
+		Dates:     make([]CalendarDate, 0, len(ids)),
+	}
+	for _, id := range ids {
+		d, err := calendars.FromFixed(id, fixed)
+		if err != nil {
+			if all {
+				continue
+			}
+			return nil, NewInvalidTimeError(date, err.Error(), err)
+		}
+		result.Dates = append(result.Dates, newCalendarDate(d))
+	}
+	return result, nil
+}
+
+// CalendarDate returns the civil date of t, in its own location, in a
+// calendar system
+func (ts *timeService) CalendarDate(t time.Time, calendar string) (*CalendarDate, error) {
+	id, err := lookupCalendarSystem(calendar)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := calendars.FromFixed(id, calendars.Fixed(t.Date()))
+	if err != nil {
+		return nil, NewInvalidTimeError(t.Format(time.DateOnly), err.Error(), err)
+	}
+	cd := newCalendarDate(d)
+	return &cd, nil
+}
+
+// lookupCalendarSystem resolves a calendar system name, defaulting to
+// Gregorian when empty
+func lookupCalendarSystem(name string) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return calendars.Gregorian, nil
+	}
+	id, ok := calendars.Lookup(name)
+	if !ok {
+		return "", NewInvalidCalendarError(name, fmt.Sprintf("unknown calendar system, available: %s", strings.Join(calendars.IDs(), ", ")), nil)
+	}
+	return id, nil
+}
+
+// parseCalendarDate parses a year-month-day date in a calendar system
+func parseCalendarDate(input, calendar string) (calendars.Date, error) {
+	m := calendarDatePattern.FindStringSubmatch(strings.TrimSpace(input))
+	if m == nil {
+		return calendars.Date{}, NewInvalidTimeError(input, fmt.Sprintf("expected a %s date as YYYY-MM-DD, with an era first for Japanese dates", calendar), nil)
+	}
+
+	year, _ := strconv.Atoi(m[2])
+	month, _ := strconv.Atoi(m[3])
+	day, _ := strconv.Atoi(m[5])
+	return calendars.Date{Calendar: calendar, Era: m[1], Year: year, Month: month, LeapMonth: m[4] != "", Day: day}, nil
+}
+
+// newCalendarDate converts a calendars.Date to its JSON form
+func newCalendarDate(d calendars.Date) CalendarDate {
+	return CalendarDate{
+		Calendar:  d.Calendar,
+		Era:       d.Era,
+		Year:      d.Year,
+		Month:     d.Month,
+		LeapMonth: d.LeapMonth,
+		Day:       d.Day,
+		MonthName: d.MonthName,
+		YearName:  d.YearName,
+		Formatted: d.String(),
+	}
+}
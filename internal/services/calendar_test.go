@@ -0,0 +1,115 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeService_ConvertCalendar(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name      string
+		date      string
+		from, to  string
+		timezone  string
+		gregorian string
+		weekday   string
+		expected  []string
+		wantErr   bool
+		errCode   int
+	}{
+		{name: "gregorian to hebrew", date: "2024-10-03", to: "hebrew", gregorian: "2024-10-03", weekday: "Thursday", expected: []string{"1 Tishrei 5785 AM"}},
+		{name: "several calendars", date: "2024-03-11", to: "islamic-umalqura, islamic-civil, persian", gregorian: "2024-03-11", weekday: "Monday", expected: []string{"1 Ramadan 1445 AH", "1 Ramadan 1445 AH", "21 Esfand 1402 AP"}},
+		{name: "timestamp in a timezone", date: "2024-02-09T20:00:00Z", to: "chinese", timezone: "Asia/Shanghai", gregorian: "2024-02-10", weekday: "Saturday", expected: []string{"Month 1, day 1, Jia-Chen year of the Dragon (2024)"}},
+		{name: "japanese era", date: "Heisei 31-04-30", from: "japanese", to: "gregorian", gregorian: "2019-04-30", weekday: "Tuesday", expected: []string{"30 April 2019"}},
+		{name: "japanese era initial", date: "R1-05-01", from: "japanese", to: "buddhist", gregorian: "2019-05-01", weekday: "Wednesday", expected: []string{"1 May 2562 BE"}},
+		{name: "chinese leap month", date: "2023-02L-01", from: "chinese", to: "gregorian", gregorian: "2023-03-22", weekday: "Wednesday", expected: []string{"22 March 2023"}},
+		{name: "ethiopian alias", date: "2017-01-01", from: "ethiopian", to: "coptic,indian", gregorian: "2024-09-11", weekday: "Wednesday", expected: []string{"1 Thout 1741 AM", "20 Bhadra 1946 Saka"}},
+		{name: "all calendars", date: "2024-01-01", gregorian: "2024-01-01", weekday: "Monday"},
+		{name: "nonexistent date", date: "5784-02-30", from: "hebrew", wantErr: true, errCode: ErrCodeInvalidTime},
+		{name: "malformed date", date: "Ramadan 1445", from: "islamic", wantErr: true, errCode: ErrCodeInvalidTime},
+		{name: "before the japanese calendar", date: "1800-01-01", to: "japanese", wantErr: true, errCode: ErrCodeInvalidTime},
+		{name: "unknown calendar", date: "2024-01-01", to: "mayan", wantErr: true, errCode: ErrCodeInvalidCalendar},
+		{name: "invalid timezone", date: "today", timezone: "Mars/Olympus", wantErr: true, errCode: ErrCodeInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.ConvertCalendar(tt.date, tt.from, tt.to, tt.timezone)
+
+			if tt.wantErr {
+				var tsErr *TimeServiceError
+				if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+					t.Errorf("Expected error code %d, got %+v, %v", tt.errCode, result, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Gregorian != tt.gregorian || result.Weekday != tt.weekday {
+				t.Errorf("Expected %s %s, got %s %s", tt.weekday, tt.gregorian, result.Weekday, result.Gregorian)
+			}
+			if tt.expected == nil {
+				// Every calendar other than Gregorian covers 2024
+				if len(result.Dates) != 10 {
+					t.Errorf("Expected 10 calendars, got %d", len(result.Dates))
+				}
+				return
+			}
+
+			var got []string
+			for _, d := range result.Dates {
+				got = append(got, d.Formatted)
+			}
+			if strings.Join(got, "; ") != strings.Join(tt.expected, "; ") {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTimeService_ConvertCalendarToday(t *testing.T) {
+	ts := NewTimeService()
+	loc, _ := time.LoadLocation("Asia/Jerusalem")
+
+	for _, date := range []string{"", "today"} {
+		before := time.Now().In(loc).Format(time.DateOnly)
+		result, err := ts.ConvertCalendar(date, "", "hebrew", "Asia/Jerusalem")
+		after := time.Now().In(loc).Format(time.DateOnly)
+
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", date, err)
+		}
+		if result.Gregorian != before && result.Gregorian != after {
+			t.Errorf("%q: expected %s, got %s", date, before, result.Gregorian)
+		}
+		if len(result.Dates) != 1 || result.Dates[0].Calendar != "hebrew" {
+			t.Errorf("%q: expected a Hebrew date, got %+v", date, result.Dates)
+		}
+	}
+}
+
+func TestTimeService_CalendarDate(t *testing.T) {
+	ts := NewTimeService()
+
+	// The date is taken in the time's own zone, although in UTC it is already 3 October
+	at := time.Date(2024, time.October, 2, 22, 0, 0, 0, time.FixedZone("EDT", -4*3600))
+	d, err := ts.CalendarDate(at, "Hebrew")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d.Formatted != "29 Elul 5784 AM" || d.Month != 6 || d.Era != "AM" {
+		t.Errorf("Expected 29 Elul 5784 AM, got %+v", d)
+	}
+
+	var tsErr *TimeServiceError
+	if _, err := ts.CalendarDate(at, "klingon"); !errors.As(err, &tsErr) || tsErr.Code != ErrCodeInvalidCalendar {
+		t.Errorf("Expected error code %d, got %v", ErrCodeInvalidCalendar, err)
+	}
+}
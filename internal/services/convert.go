@@ -0,0 +1,94 @@
+package services
+
+import "time"
+
+// ZonedTime describes an instant as observed in a particular timezone
+type ZonedTime struct {
+	Timezone      string `json:"timezone"`
+	Time          string `json:"time"`
+	Offset        string `json:"offset"`
+	OffsetSeconds int    `json:"offsetSeconds"`
+	Abbreviation  string `json:"abbreviation"`
+}
+
+// TimeConversion holds the same instant expressed in a source and target timezone
+type TimeConversion struct {
+	From ZonedTime `json:"from"`
+	To   ZonedTime `json:"to"`
+}
+
+// ConvertTime converts a timestamp from one timezone to another.
+// The input is parsed as RFC3339 when no format is given, otherwise with the
+// user format in the given dialect. Inputs without an explicit offset are
+// interpreted as wall-clock time in fromZone.
+func (ts *timeService) ConvertTime(t, format, dialect, fromZone, toZone string) (*TimeConversion, error) {
+	fromLoc, err := ts.loadLocation(fromZone)
+	if err != nil {
+		return nil, err
+	}
+
+	toLoc, err := ts.loadLocation(toZone)
+	if err != nil {
+		return nil, err
+	}
+
+	instant, err := ts.parseTimeInput(t, format, dialect, fromLoc)
+	if err != nil {
+		return nil, err
+	}
+
+	// An RFC3339 input carries its own offset; keep it when no source zone is given
+	if fromZone != "" {
+		instant = instant.In(fromLoc)
+	}
+
+	return &TimeConversion{
+		From: newZonedTime(instant, fromZone),
+		To:   newZonedTime(instant.In(toLoc), toZone),
+	}, nil
+}
+
+// parseTimeInput parses a timestamp using RFC3339 or a user format in the given location
+func (ts *timeService) parseTimeInput(input, format, dialect string, loc *time.Location) (time.Time, error) {
+	if input == "" {
+		return time.Time{}, NewInvalidTimeError(input, "time value cannot be empty", nil)
+	}
+
+	layout := time.RFC3339
+	if format != "" {
+		compiled, err := compileFormat(format, dialect)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if layout, err = compiled.Layout(); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	parsed, err := time.ParseInLocation(layout, input, loc)
+	if err != nil {
+		return time.Time{}, NewInvalidTimeError(input, "does not match the expected format", err)
+	}
+
+	return parsed, nil
+}
+
+// newZonedTime builds a ZonedTime for t, labelling it with the requested timezone name
+func newZonedTime(t time.Time, timezone string) ZonedTime {
+	abbreviation, offset := t.Zone()
+
+	if timezone == "" {
+		timezone = t.Location().String()
+		if timezone == "" || timezone == "Local" {
+			timezone = t.Format("-07:00")
+		}
+	}
+
+	return ZonedTime{
+		Timezone:      timezone,
+		Time:          t.Format(time.RFC3339Nano),
+		Offset:        t.Format("-07:00"),
+		OffsetSeconds: offset,
+		Abbreviation:  abbreviation,
+	}
+}
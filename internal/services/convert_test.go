@@ -0,0 +1,99 @@
+package services
+
+import "testing"
+
+func TestTimeService_ConvertTime(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name         string
+		time         string
+		format       string
+		fromZone     string
+		toZone       string
+		expectedFrom string
+		expectedTo   string
+		expectedAbbr string
+		wantErr      bool
+	}{
+		{
+			name:         "Berlin afternoon to Tokyo",
+			time:         "2024-01-15 15:00",
+			format:       "YYYY-MM-DD HH:mm",
+			fromZone:     "Europe/Berlin",
+			toZone:       "Asia/Tokyo",
+			expectedFrom: "2024-01-15T15:00:00+01:00",
+			expectedTo:   "2024-01-15T23:00:00+09:00",
+			expectedAbbr: "JST",
+			wantErr:      false,
+		},
+		{
+			name:         "RFC3339 input with offset",
+			time:         "2024-07-01T12:00:00Z",
+			fromZone:     "",
+			toZone:       "America/New_York",
+			expectedFrom: "2024-07-01T12:00:00Z",
+			expectedTo:   "2024-07-01T08:00:00-04:00",
+			expectedAbbr: "EDT",
+			wantErr:      false,
+		},
+		{
+			name:         "RFC3339 input shown in source timezone",
+			time:         "2024-07-01T12:00:00Z",
+			fromZone:     "Europe/London",
+			toZone:       "UTC",
+			expectedFrom: "2024-07-01T13:00:00+01:00",
+			expectedTo:   "2024-07-01T12:00:00Z",
+			expectedAbbr: "UTC",
+			wantErr:      false,
+		},
+		{
+			name:    "Invalid target timezone",
+			time:    "2024-07-01T12:00:00Z",
+			toZone:  "Invalid/Timezone",
+			wantErr: true,
+		},
+		{
+			name:    "Unparseable time",
+			time:    "not a time",
+			toZone:  "UTC",
+			wantErr: true,
+		},
+		{
+			name:    "Empty time",
+			time:    "",
+			toZone:  "UTC",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.ConvertTime(tt.time, tt.format, "", tt.fromZone, tt.toZone)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for time %s, but got none", tt.time)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error for time %s: %v", tt.time, err)
+				return
+			}
+
+			if result.From.Time != tt.expectedFrom {
+				t.Errorf("Expected source time %s, got %s", tt.expectedFrom, result.From.Time)
+			}
+
+			if result.To.Time != tt.expectedTo {
+				t.Errorf("Expected target time %s, got %s", tt.expectedTo, result.To.Time)
+			}
+
+			if result.To.Abbreviation != tt.expectedAbbr {
+				t.Errorf("Expected target abbreviation %s, got %s", tt.expectedAbbr, result.To.Abbreviation)
+			}
+		})
+	}
+}
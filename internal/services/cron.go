@@ -0,0 +1,884 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron dialects
+const (
+	CronDialectAuto     = "auto"     // Quartz for 7 fields, or 6 fields using '?'; standard otherwise
+	CronDialectStandard = "standard" // 5 fields, or 6 with seconds first; Sunday is 0 or 7
+	CronDialectQuartz   = "quartz"   // Seconds first and an optional year; Sunday is 1
+)
+
+// Cron run directions
+const (
+	CronDirectionNext     = "next"
+	CronDirectionPrevious = "previous"
+)
+
+// Limits on the runs returned for a cron expression
+const (
+	defaultCronRuns = 5
+	maxCronRuns     = 100
+	cronSearchYears = 100
+)
+
+// cronMacros maps the @ macros to standard expressions
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronMonthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	cronWeekdayNames = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+)
+
+// CronOptions controls which runs of a cron expression are listed
+type CronOptions struct {
+	Timezone  string // Timezone the schedule runs in, default UTC
+	Dialect   string // auto (default), standard or quartz
+	Count     int    // Number of runs, default 5, at most 100
+	Direction string // next (default) or previous
+}
+
+// CronFields holds the normalized fields of a cron expression
+type CronFields struct {
+	Second     string `json:"second"`
+	Minute     string `json:"minute"`
+	Hour       string `json:"hour"`
+	DayOfMonth string `json:"dayOfMonth"`
+	Month      string `json:"month"`
+	DayOfWeek  string `json:"dayOfWeek"`
+	Year       string `json:"year,omitempty"`
+}
+
+// CronRun is one fire time of a cron schedule. Note explains runs moved or
+// repeated by a daylight saving transition.
+type CronRun struct {
+	ZonedTime
+	Note string `json:"note,omitempty"`
+}
+
+// CronSchedule lists the runs of a cron expression after (or before) a reference time
+type CronSchedule struct {
+	Expression  string     `json:"expression"`
+	Dialect     string     `json:"dialect"`
+	Fields      CronFields `json:"fields"`
+	Description string     `json:"description"`
+	Direction   string     `json:"direction"`
+	Reference   ZonedTime  `json:"reference"`
+	Runs        []CronRun  `json:"runs"`
+}
+
+// CronRuns lists the next (or previous) fire times of a cron expression after
+// reference (now when empty), in the schedule's timezone.
+//
+// Daylight saving transitions follow Vixie cron: a time skipped by a gap runs
+// once when the clocks go forward, and a time repeated by an overlap runs
+// twice only when the hour field is a wildcard such as '*' or '*/2'.
+func (ts *timeService) CronRuns(expression, reference string, opts CronOptions) (*CronSchedule, error) {
+	loc, err := ts.loadLocation(opts.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := time.Now().In(loc)
+	if reference != "" {
+		if ref, err = ts.parseInstant(reference, loc); err != nil {
+			return nil, err
+		}
+		ref = ref.In(loc)
+	}
+
+	count := opts.Count
+	if count == 0 {
+		count = defaultCronRuns
+	}
+	if count < 1 || count > maxCronRuns {
+		return nil, NewTimeServiceError(
+			ErrCodeTimeOperation,
+			fmt.Sprintf("invalid count %d: expected 1 to %d", count, maxCronRuns),
+			"count",
+			nil,
+		)
+	}
+
+	direction := strings.ToLower(strings.TrimSpace(opts.Direction))
+	switch direction {
+	case "":
+		direction = CronDirectionNext
+	case CronDirectionNext, CronDirectionPrevious:
+	default:
+		return nil, NewTimeServiceError(
+			ErrCodeTimeOperation,
+			fmt.Sprintf("invalid direction '%s': expected next or previous", opts.Direction),
+			"direction",
+			nil,
+		)
+	}
+
+	spec, err := parseCron(expression, opts.Dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := spec.runs(ref, count, direction == CronDirectionPrevious)
+	schedule := &CronSchedule{
+		Expression:  expression,
+		Dialect:     spec.dialect,
+		Fields:      spec.fields(),
+		Description: spec.describe(),
+		Direction:   direction,
+		Reference:   newZonedTime(ref, opts.Timezone),
+		Runs:        make([]CronRun, 0, len(runs)),
+	}
+	for _, run := range runs {
+		schedule.Runs = append(schedule.Runs, CronRun{ZonedTime: newZonedTime(run.t, opts.Timezone), Note: run.note})
+	}
+
+	return schedule, nil
+}
+
+// cronItem is one comma-separated item of a field: a value, a range or a
+// stepped range. Single values have from == to and step 0.
+type cronItem struct {
+	from, to, step int
+	star           bool // '*' or '*/n'
+	open           bool // 'a/n', running to the field maximum
+}
+
+// cronNth is a Quartz day-of-week item selecting the nth weekday of the
+// month ('5#2'), or the last one ('5L') when nth is -1
+type cronNth struct {
+	weekday, nth int
+}
+
+// cronField is a parsed cron field
+type cronField struct {
+	text  string
+	min   int
+	max   int
+	set   []bool
+	items []cronItem
+	any   bool // '*' or '?': every value, unrestricted
+	skip  bool // '?': no specific value
+	// Day-of-month specials
+	last        bool // 'L'
+	lastOffset  int  // 'L-3'
+	lastWeekday bool // 'LW'
+	nearest     []int
+	// Day-of-week specials
+	nth []cronNth
+}
+
+// has reports whether the field includes v
+func (f *cronField) has(v int) bool {
+	return v >= 0 && v < len(f.set) && f.set[v]
+}
+
+// values returns the included values in ascending order
+func (f *cronField) values() []int {
+	var values []int
+	for v := f.min; v <= f.max; v++ {
+		if f.set[v] {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// cronSpec is a parsed cron expression
+type cronSpec struct {
+	expression string
+	dialect    string
+	hasSeconds bool
+	second     cronField
+	minute     cronField
+	hour       cronField
+	dom        cronField
+	month      cronField
+	dow        cronField
+	year       *cronField
+}
+
+// parseCron parses a 5-field, 6-field (seconds first) or Quartz expression,
+// or one of the @yearly, @monthly, @weekly, @daily and @hourly macros
+func parseCron(expression, dialect string) (*cronSpec, error) {
+	dialect = strings.ToLower(strings.TrimSpace(dialect))
+	switch dialect {
+	case "", CronDialectAuto, CronDialectStandard, CronDialectQuartz:
+	default:
+		return nil, NewTimeServiceError(
+			ErrCodeTimeOperation,
+			fmt.Sprintf("invalid dialect '%s': expected auto, standard or quartz", dialect),
+			"dialect",
+			nil,
+		)
+	}
+
+	spec := &cronSpec{expression: expression}
+	text := strings.TrimSpace(expression)
+	if strings.HasPrefix(text, "@") {
+		macro := strings.ToLower(text)
+		standard, ok := cronMacros[macro]
+		if !ok {
+			return nil, NewInvalidCronError(expression, "unknown macro; expected @yearly, @annually, @monthly, @weekly, @daily, @midnight or @hourly")
+		}
+		text, dialect = standard, CronDialectStandard
+	}
+
+	fields := strings.Fields(text)
+	if dialect == "" || dialect == CronDialectAuto {
+		dialect = CronDialectStandard
+		if len(fields) == 7 || (len(fields) == 6 && strings.Contains(text, "?")) {
+			dialect = CronDialectQuartz
+		}
+	}
+	spec.dialect = dialect
+
+	switch {
+	case dialect == CronDialectStandard && len(fields) == 5:
+		fields = append([]string{"0"}, fields...)
+	case dialect == CronDialectStandard && len(fields) == 6:
+		spec.hasSeconds = true
+	case dialect == CronDialectQuartz && (len(fields) == 6 || len(fields) == 7):
+		spec.hasSeconds = true
+	case dialect == CronDialectQuartz:
+		return nil, NewInvalidCronError(expression, fmt.Sprintf("expected 6 or 7 fields for a Quartz expression, got %d", len(fields)))
+	default:
+		return nil, NewInvalidCronError(expression, fmt.Sprintf("expected 5 or 6 fields, got %d", len(fields)))
+	}
+
+	var err error
+	parse := func(name, text string, min, max int, names map[string]int) cronField {
+		var field cronField
+		if err == nil {
+			field, err = spec.parseField(name, text, min, max, names)
+		}
+		return field
+	}
+
+	spec.second = parse("second", fields[0], 0, 59, nil)
+	spec.minute = parse("minute", fields[1], 0, 59, nil)
+	spec.hour = parse("hour", fields[2], 0, 23, nil)
+	spec.dom = parse("day-of-month", fields[3], 1, 31, nil)
+	spec.month = parse("month", fields[4], 1, 12, cronMonthNames)
+	spec.dow = parse("day-of-week", fields[5], 0, 6, cronWeekdayNames)
+	if len(fields) == 7 {
+		year := parse("year", fields[6], 1970, 2199, nil)
+		spec.year = &year
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if dialect == CronDialectQuartz && spec.dom.skip == spec.dow.skip {
+		return nil, NewInvalidCronError(expression, "Quartz expressions need '?' in exactly one of day-of-month and day-of-week")
+	}
+
+	return spec, nil
+}
+
+// parseField parses one field. Values are validated against min and max,
+// except that day-of-week numbers follow the dialect: 0-7 (Sunday is 0 or 7)
+// in standard cron and 1-7 (Sunday is 1) in Quartz.
+func (spec *cronSpec) parseField(name, text string, lo, hi int, names map[string]int) (cronField, error) {
+	field := cronField{text: text, min: lo, max: hi, set: make([]bool, hi+1)}
+	fail := func(reason string) (cronField, error) {
+		return cronField{}, NewInvalidCronError(spec.expression, fmt.Sprintf("%s field '%s': %s", name, text, reason))
+	}
+
+	upper := strings.ToUpper(text)
+	if upper == "?" {
+		if name != "day-of-month" && name != "day-of-week" {
+			return fail("'?' is only allowed for day-of-month and day-of-week")
+		}
+		field.any, field.skip = true, true
+		for v := lo; v <= hi; v++ {
+			field.set[v] = true
+		}
+		return field, nil
+	}
+
+	isDow := name == "day-of-week"
+	quartz := spec.dialect == CronDialectQuartz
+
+	// value parses a number or name, converting day-of-week numbers to 0-6
+	value := func(s string) (int, bool) {
+		if v, ok := names[s]; ok {
+			return v, true
+		}
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, false
+		}
+		if isDow {
+			switch {
+			case quartz && v >= 1 && v <= 7:
+				return v - 1, true
+			case !quartz && v >= 0 && v <= 7:
+				return v % 7, true
+			}
+			return 0, false
+		}
+		return v, v >= lo && v <= hi
+	}
+
+	for _, part := range strings.Split(upper, ",") {
+		if part == "" {
+			return fail("empty list item")
+		}
+
+		// Quartz day specials
+		if name == "day-of-month" {
+			switch {
+			case part == "L":
+				field.last = true
+				continue
+			case part == "LW":
+				field.lastWeekday = true
+				continue
+			case strings.HasPrefix(part, "L-"):
+				n, err := strconv.Atoi(part[2:])
+				if err != nil || n < 1 || n > 30 {
+					return fail("expected 'L-n' with n from 1 to 30")
+				}
+				field.last, field.lastOffset = true, n
+				continue
+			case strings.HasSuffix(part, "W"):
+				n, err := strconv.Atoi(strings.TrimSuffix(part, "W"))
+				if err != nil || n < 1 || n > 31 {
+					return fail("expected 'nW' with n from 1 to 31")
+				}
+				field.nearest = append(field.nearest, n)
+				continue
+			}
+		}
+		if isDow {
+			if part == "L" {
+				// A bare 'L' is the last day of the week, Saturday
+				part = "SAT"
+				if !quartz {
+					part = "6"
+				}
+			}
+			if before, after, found := strings.Cut(part, "#"); found {
+				weekday, ok := value(before)
+				nth, err := strconv.Atoi(after)
+				if !ok || err != nil || nth < 1 || nth > 5 {
+					return fail("expected 'weekday#n' with n from 1 to 5")
+				}
+				field.nth = append(field.nth, cronNth{weekday: weekday, nth: nth})
+				continue
+			}
+			if before, found := strings.CutSuffix(part, "L"); found && before != "" {
+				weekday, ok := value(before)
+				if !ok {
+					return fail(fmt.Sprintf("invalid weekday '%s'", before))
+				}
+				field.nth = append(field.nth, cronNth{weekday: weekday, nth: -1})
+				continue
+			}
+		}
+
+		item := cronItem{}
+		rangePart, stepPart, stepped := strings.Cut(part, "/")
+		if stepped {
+			step, err := strconv.Atoi(stepPart)
+			if err != nil || step < 1 || step > hi-lo+1 {
+				return fail(fmt.Sprintf("invalid step '%s'", stepPart))
+			}
+			item.step = step
+		}
+
+		switch {
+		case rangePart == "*":
+			item.star = true
+			item.from, item.to = lo, hi
+			if isDow {
+				item.from, item.to = 0, 6
+			}
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			a, okA := value(from)
+			b, okB := value(to)
+			if !okA || !okB {
+				return fail(fmt.Sprintf("invalid range '%s'", rangePart))
+			}
+			if isDow && b == 0 && (a > 0 || (!quartz && to == "7")) {
+				b = 7 // e.g. 5-7, 0-7 or FRI-SUN ends on Sunday
+			}
+			if a > b {
+				return fail(fmt.Sprintf("range '%s' runs backwards", rangePart))
+			}
+			item.from, item.to = a, b
+		default:
+			v, ok := value(rangePart)
+			if !ok {
+				return fail(fmt.Sprintf("invalid value '%s'", rangePart))
+			}
+			item.from, item.to = v, v
+			if stepped {
+				item.to, item.open = hi, true
+				if isDow {
+					item.to = 6
+				}
+			}
+		}
+
+		step := max(item.step, 1)
+		for v := item.from; v <= item.to; v += step {
+			field.set[v%len(field.set)] = true
+		}
+		if item.star && !stepped {
+			field.any = true
+		}
+		field.items = append(field.items, item)
+	}
+
+	return field, nil
+}
+
+// fields returns the normalized fields
+func (spec *cronSpec) fields() CronFields {
+	fields := CronFields{
+		Second:     spec.second.text,
+		Minute:     spec.minute.text,
+		Hour:       spec.hour.text,
+		DayOfMonth: spec.dom.text,
+		Month:      spec.month.text,
+		DayOfWeek:  spec.dow.text,
+	}
+	if spec.year != nil {
+		fields.Year = spec.year.text
+	}
+	return fields
+}
+
+// restricted reports whether a day field limits the days the schedule runs on.
+// As in Vixie cron, a field starting with '*' does not, even when stepped.
+func (f *cronField) restricted() bool {
+	return !f.skip && !strings.HasPrefix(f.text, "*")
+}
+
+// matchesDay reports whether the schedule runs on a date. In standard cron a
+// date matching either a restricted day-of-month or a restricted day-of-week
+// matches; otherwise both must match.
+func (spec *cronSpec) matchesDay(year int, month time.Month, day int) bool {
+	if !spec.month.has(int(month)) {
+		return false
+	}
+	if spec.year != nil && !spec.year.has(year) {
+		return false
+	}
+
+	domMatch := spec.matchesDayOfMonth(year, month, day)
+	dowMatch := spec.matchesDayOfWeek(year, month, day)
+	if spec.dialect == CronDialectStandard && spec.dom.restricted() && spec.dow.restricted() {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+func (spec *cronSpec) matchesDayOfMonth(year int, month time.Month, day int) bool {
+	f := &spec.dom
+	if f.has(day) {
+		return true
+	}
+
+	last := daysIn(year, month)
+	if f.last && day == last-f.lastOffset {
+		return true
+	}
+	if f.lastWeekday && day == nearestWeekday(year, month, last) {
+		return true
+	}
+	for _, n := range f.nearest {
+		if n <= last && day == nearestWeekday(year, month, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func (spec *cronSpec) matchesDayOfWeek(year int, month time.Month, day int) bool {
+	f := &spec.dow
+	weekday := int(civilDate(year, month, day).Weekday())
+	if f.has(weekday) {
+		return true
+	}
+
+	for _, nth := range f.nth {
+		if nth.weekday != weekday {
+			continue
+		}
+		if (nth.nth == -1 && day+7 > daysIn(year, month)) || (day-1)/7+1 == nth.nth {
+			return true
+		}
+	}
+	return false
+}
+
+// nearestWeekday returns the weekday (Monday to Friday) nearest to a day,
+// staying within the month as Quartz's 'W' does
+func nearestWeekday(year int, month time.Month, day int) int {
+	switch civilDate(year, month, day).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return 3
+		}
+		return day - 1
+	case time.Sunday:
+		if day == daysIn(year, month) {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+// cronRun is a fire time with an optional daylight saving note
+type cronRun struct {
+	t    time.Time
+	note string
+}
+
+// runs lists up to count fire times strictly after (or, when previous is set,
+// strictly before) ref, searching day by day in ref's location
+func (spec *cronSpec) runs(ref time.Time, count int, previous bool) []cronRun {
+	loc := ref.Location()
+	seconds, minutes, hours := spec.second.values(), spec.minute.values(), spec.hour.values()
+	repeatOverlap := strings.HasPrefix(spec.hour.text, "*")
+
+	step := 1
+	if previous {
+		step = -1
+	}
+
+	var runs []cronRun
+	y, m, d := ref.Date()
+	for i := 0; i <= cronSearchYears*366 && len(runs) < count; i++ {
+		day := civilDate(y, m, d+i*step)
+		if !spec.matchesDay(day.Year(), day.Month(), day.Day()) {
+			continue
+		}
+
+		// Days well inside one offset period need no daylight saving checks
+		_, periodEnd := civilDateIn(day, -1, loc).ZoneBounds()
+		stable := periodEnd.IsZero() || periodEnd.After(civilDateIn(day, 2, loc))
+
+		var candidates []cronRun
+		for _, hour := range hours {
+			for _, minute := range minutes {
+				for _, second := range seconds {
+					if stable {
+						candidates = append(candidates, cronRun{t: time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, loc)})
+						continue
+					}
+					candidates = append(candidates, wallClockRuns(day, hour, minute, second, loc, repeatOverlap)...)
+				}
+			}
+		}
+
+		sort.SliceStable(candidates, func(a, b int) bool { return candidates[a].t.Before(candidates[b].t) })
+		if previous {
+			for a, b := 0, len(candidates)-1; a < b; a, b = a+1, b-1 {
+				candidates[a], candidates[b] = candidates[b], candidates[a]
+			}
+		}
+
+		for _, c := range candidates {
+			if (!previous && !c.t.After(ref)) || (previous && !c.t.Before(ref)) {
+				continue
+			}
+			// Times skipped by a gap collapse into the transition instant,
+			// which may also be a regular run
+			if n := len(runs); n > 0 && runs[n-1].t.Equal(c.t) {
+				if c.note == "" {
+					runs[n-1].note = ""
+				}
+				continue
+			}
+			if len(runs) == count {
+				break
+			}
+			runs = append(runs, c)
+		}
+	}
+
+	return runs
+}
+
+// civilDateIn returns midnight in loc, offset days from a civil date
+func civilDateIn(day time.Time, offset int, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+offset, 0, 0, 0, 0, loc)
+}
+
+// wallClockRuns returns the instants at which a wall-clock time occurs on a
+// day: none skipped by a gap (the run moves to the transition instant), one,
+// or two in an overlap, of which only the first is kept unless repeatOverlap
+// is set
+func wallClockRuns(day time.Time, hour, minute, second int, loc *time.Location, repeatOverlap bool) []cronRun {
+	y, m, d := day.Date()
+	wall := time.Date(y, m, d, hour, minute, second, 0, time.UTC)
+	label := wall.Format("15:04:05")
+	t := time.Date(y, m, d, hour, minute, second, 0, loc)
+
+	if !sameWallClock(t, wall) {
+		// t is the wall-clock time read in the offset before or after the
+		// gap; the transition is the boundary between the two
+		start, end := t.ZoneBounds()
+		if shown := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC); shown.After(wall) {
+			end = start
+		}
+		return []cronRun{{t: end, note: fmt.Sprintf("%s does not exist on this day (daylight saving gap); runs when the clocks go forward", label)}}
+	}
+
+	// Read the wall-clock time with the offsets of t's period and its
+	// neighbours; time.Date may have picked either occurrence, so keep every
+	// reading that shows the wall clock, in order
+	_, offset := t.Zone()
+	offsets := []int{offset}
+	start, end := t.ZoneBounds()
+	if !start.IsZero() {
+		_, before := start.Add(-time.Second).Zone()
+		offsets = append(offsets, before)
+	}
+	if !end.IsZero() {
+		_, after := end.Zone()
+		offsets = append(offsets, after)
+	}
+
+	var occurrences []time.Time
+	for _, offset := range offsets {
+		c := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if !sameWallClock(c, wall) || containsInstant(occurrences, c) {
+			continue
+		}
+		occurrences = append(occurrences, c)
+	}
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+
+	if len(occurrences) == 1 {
+		return []cronRun{{t: occurrences[0]}}
+	}
+	if !repeatOverlap {
+		return []cronRun{{t: occurrences[0], note: fmt.Sprintf("%s occurs twice on this day (daylight saving overlap); runs on the first occurrence only", label)}}
+	}
+	return []cronRun{
+		{t: occurrences[0], note: fmt.Sprintf("%s occurs twice on this day (daylight saving overlap); first occurrence", label)},
+		{t: occurrences[1], note: fmt.Sprintf("%s occurs twice on this day (daylight saving overlap); second occurrence", label)},
+	}
+}
+
+// containsInstant reports whether times holds an instant equal to t
+func containsInstant(times []time.Time, t time.Time) bool {
+	for _, u := range times {
+		if u.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameWallClock reports whether t shows the wall-clock time of wall, a UTC
+// time holding the local date and time
+func sameWallClock(t, wall time.Time) bool {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, time.UTC).Equal(wall)
+}
+
+// describe returns a plain-English description of the schedule, in the style
+// of "At minute 0 past every 4th hour on Monday through Friday"
+func (spec *cronSpec) describe() string {
+	var parts []string
+
+	second, minute, hour := &spec.second, &spec.minute, &spec.hour
+	secondsZero := !spec.hasSeconds || second.text == "0"
+	hours := hour.values()
+
+	switch {
+	case (secondsZero || isSingle(second)) && isSingle(minute) && isSinglesList(hour):
+		// Fixed times of day, e.g. "At 09:00 and 17:00"
+		var times []string
+		for _, h := range hours {
+			clock := fmt.Sprintf("%02d:%02d", h, minute.items[0].from)
+			if !secondsZero {
+				clock += fmt.Sprintf(":%02d", second.items[0].from)
+			}
+			times = append(times, clock)
+		}
+		parts = append(parts, "At "+joinList(times))
+
+	default:
+		if !secondsZero {
+			parts = append(parts, "At "+describeField(second, "second", nil), "past")
+		} else {
+			parts = append(parts, "At")
+		}
+		if minute.any {
+			parts = append(parts, "every minute")
+		} else {
+			parts = append(parts, describeField(minute, "minute", nil))
+		}
+		if !hour.any {
+			parts = append(parts, "past "+describeField(hour, "hour", nil))
+		}
+	}
+
+	var days []string
+	if dom := spec.describeDayOfMonth(); dom != "" {
+		days = append(days, "on "+dom)
+	}
+	if dow := spec.describeDayOfWeek(); dow != "" {
+		days = append(days, "on "+dow)
+	}
+	joiner := " and "
+	if spec.dialect == CronDialectStandard && spec.dom.restricted() && spec.dow.restricted() {
+		joiner = " or "
+	}
+	if len(days) > 0 {
+		parts = append(parts, strings.Join(days, joiner))
+	}
+
+	if !spec.month.any {
+		parts = append(parts, "in "+describeField(&spec.month, "month", func(v int) string { return time.Month(v).String() }))
+	}
+	if spec.year != nil && !spec.year.any {
+		parts = append(parts, "in "+describeField(spec.year, "year", nil))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// describeDayOfMonth describes the day-of-month field, or "" when it is unrestricted
+func (spec *cronSpec) describeDayOfMonth() string {
+	f := &spec.dom
+	if f.any {
+		return ""
+	}
+
+	var phrases []string
+	if len(f.items) > 0 {
+		phrases = append(phrases, describeField(f, "day-of-month", nil))
+	}
+	switch {
+	case f.last && f.lastOffset > 0:
+		phrases = append(phrases, fmt.Sprintf("the %s last day of the month", ordinal(f.lastOffset+1)))
+	case f.last:
+		phrases = append(phrases, "the last day of the month")
+	}
+	if f.lastWeekday {
+		phrases = append(phrases, "the last weekday of the month")
+	}
+	for _, n := range f.nearest {
+		phrases = append(phrases, fmt.Sprintf("the weekday nearest day %d of the month", n))
+	}
+	return joinList(phrases)
+}
+
+// describeDayOfWeek describes the day-of-week field, or "" when it is unrestricted
+func (spec *cronSpec) describeDayOfWeek() string {
+	f := &spec.dow
+	if f.any {
+		return ""
+	}
+
+	weekday := func(v int) string { return time.Weekday(v % 7).String() }
+	var phrases []string
+	if len(f.items) > 0 {
+		phrases = append(phrases, describeField(f, "day-of-week", weekday))
+	}
+	for _, nth := range f.nth {
+		if nth.nth == -1 {
+			phrases = append(phrases, fmt.Sprintf("the last %s of the month", weekday(nth.weekday)))
+		} else {
+			phrases = append(phrases, fmt.Sprintf("the %s %s of the month", ordinal(nth.nth), weekday(nth.weekday)))
+		}
+	}
+	return joinList(phrases)
+}
+
+// describeField describes the items of a field, e.g. "minute 0 and 30",
+// "every 4th hour" or "Monday through Friday". Named fields (months and
+// weekdays) omit the unit.
+func describeField(f *cronField, unit string, name func(int) string) string {
+	label := func(v int) string {
+		if name != nil {
+			return name(v)
+		}
+		return strconv.Itoa(v)
+	}
+	prefix := unit + " "
+	if name != nil {
+		prefix = ""
+	}
+
+	var singles, phrases []string
+	for _, item := range f.items {
+		switch {
+		case item.step == 0 && item.from == item.to:
+			singles = append(singles, label(item.from))
+		case item.step == 0 && (item.star || (item.from <= f.min && item.to >= f.max)):
+			phrases = append(phrases, "every "+unit)
+		case item.step == 0 && name != nil:
+			phrases = append(phrases, fmt.Sprintf("%s through %s", label(item.from), label(item.to)))
+		case item.step == 0:
+			phrases = append(phrases, fmt.Sprintf("every %s from %s through %s", unit, label(item.from), label(item.to)))
+		case item.star:
+			phrases = append(phrases, fmt.Sprintf("every %s %s", ordinal(item.step), unit))
+		default:
+			phrases = append(phrases, fmt.Sprintf("every %s %s from %s through %s", ordinal(item.step), unit, label(item.from), label(item.to)))
+		}
+	}
+
+	if len(singles) > 0 {
+		phrases = append([]string{prefix + joinList(singles)}, phrases...)
+	}
+	return joinList(phrases)
+}
+
+// isSingle reports whether a field is a single value
+func isSingle(f *cronField) bool {
+	return len(f.items) == 1 && f.items[0].step == 0 && f.items[0].from == f.items[0].to
+}
+
+// isSinglesList reports whether a field lists one or more single values
+func isSinglesList(f *cronField) bool {
+	if len(f.items) == 0 {
+		return false
+	}
+	for _, item := range f.items {
+		if item.step != 0 || item.from != item.to {
+			return false
+		}
+	}
+	return true
+}
+
+// joinList joins items as "a", "a and b" or "a, b and c"
+func joinList(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
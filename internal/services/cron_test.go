@@ -0,0 +1,234 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeService_CronRuns(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name        string
+		expression  string
+		reference   string
+		opts        CronOptions
+		dialect     string
+		description string
+		expected    []string
+		notes       []bool
+		wantErr     bool
+		errCode     int
+	}{
+		{
+			name:        "every 4 hours on weekdays",
+			expression:  "0 */4 * * 1-5",
+			reference:   "2024-03-29T10:00:00Z",
+			opts:        CronOptions{Timezone: "Europe/London", Count: 4},
+			dialect:     "standard",
+			description: "At minute 0 past every 4th hour on Monday through Friday",
+			expected:    []string{"2024-03-29T12:00:00Z", "2024-03-29T16:00:00Z", "2024-03-29T20:00:00Z", "2024-04-01T00:00:00+01:00"},
+		},
+		{
+			name:        "fixed time in a daylight saving gap",
+			expression:  "30 1 * * *",
+			reference:   "2024-03-29T10:00:00Z",
+			opts:        CronOptions{Timezone: "Europe/London", Count: 3},
+			description: "At 01:30",
+			expected:    []string{"2024-03-30T01:30:00Z", "2024-03-31T02:00:00+01:00", "2024-04-01T01:30:00+01:00"},
+			notes:       []bool{false, true, false},
+		},
+		{
+			name:       "fixed time in an overlap runs once",
+			expression: "30 1 * * *",
+			reference:  "2024-10-26T10:00:00Z",
+			opts:       CronOptions{Timezone: "Europe/London", Count: 2},
+			expected:   []string{"2024-10-27T01:30:00+01:00", "2024-10-28T01:30:00Z"},
+			notes:      []bool{true, false},
+		},
+		{
+			name:        "hourly job in an overlap runs twice",
+			expression:  "30 * * * *",
+			reference:   "2024-10-27T00:00:00Z",
+			opts:        CronOptions{Timezone: "Europe/London", Count: 3},
+			description: "At minute 30",
+			expected:    []string{"2024-10-27T01:30:00+01:00", "2024-10-27T01:30:00Z", "2024-10-27T02:30:00Z"},
+			notes:       []bool{true, true, false},
+		},
+		{
+			name:       "fixed time in a US overlap runs on the first occurrence",
+			expression: "30 1 * * *",
+			reference:  "2024-11-02T12:00:00Z",
+			opts:       CronOptions{Timezone: "America/New_York", Count: 2},
+			expected:   []string{"2024-11-03T01:30:00-04:00", "2024-11-04T01:30:00-05:00"},
+			notes:      []bool{true, false},
+		},
+		{
+			name:       "hourly job in a US overlap runs twice in order",
+			expression: "30 * * * *",
+			reference:  "2024-11-03T05:00:00Z",
+			opts:       CronOptions{Timezone: "America/New_York", Count: 3},
+			expected:   []string{"2024-11-03T01:30:00-04:00", "2024-11-03T01:30:00-05:00", "2024-11-03T02:30:00-05:00"},
+			notes:      []bool{true, true, false},
+		},
+		{
+			name:        "skipped wildcard times are not repeated",
+			expression:  "*/20 * * * *",
+			reference:   "2024-03-31T00:30:00Z",
+			opts:        CronOptions{Timezone: "Europe/London", Count: 3},
+			description: "At every 20th minute",
+			expected:    []string{"2024-03-31T00:40:00Z", "2024-03-31T02:00:00+01:00", "2024-03-31T02:20:00+01:00"},
+			notes:       []bool{false, false, false},
+		},
+		{
+			name:        "previous runs of a macro",
+			expression:  "@daily",
+			reference:   "2024-03-29T10:00:00Z",
+			opts:        CronOptions{Count: 2, Direction: "previous"},
+			description: "At 00:00",
+			expected:    []string{"2024-03-29T00:00:00Z", "2024-03-28T00:00:00Z"},
+		},
+		{
+			name:        "seconds field",
+			expression:  "*/10 * * * * *",
+			reference:   "2024-06-01T10:00:00Z",
+			opts:        CronOptions{Count: 2},
+			description: "At every 10th second past every minute",
+			expected:    []string{"2024-06-01T10:00:10Z", "2024-06-01T10:00:20Z"},
+		},
+		{
+			name:        "quartz last weekday",
+			expression:  "0 15 10 ? * 6L",
+			reference:   "2024-03-29T10:00:00Z",
+			opts:        CronOptions{Count: 2},
+			dialect:     "quartz",
+			description: "At 10:15 on the last Friday of the month",
+			expected:    []string{"2024-03-29T10:15:00Z", "2024-04-26T10:15:00Z"},
+		},
+		{
+			name:        "quartz nearest weekday",
+			expression:  "0 0 9 15W * ?",
+			reference:   "2024-06-01T00:00:00Z",
+			opts:        CronOptions{Count: 3},
+			description: "At 09:00 on the weekday nearest day 15 of the month",
+			expected:    []string{"2024-06-14T09:00:00Z", "2024-07-15T09:00:00Z", "2024-08-15T09:00:00Z"},
+		},
+		{
+			name:        "quartz last weekday of month",
+			expression:  "0 0 0 LW * ?",
+			reference:   "2024-06-01T00:00:00Z",
+			opts:        CronOptions{Count: 2},
+			description: "At 00:00 on the last weekday of the month",
+			expected:    []string{"2024-06-28T00:00:00Z", "2024-07-31T00:00:00Z"},
+		},
+		{
+			name:        "quartz nth weekday and year",
+			expression:  "0 0 12 ? * MON#2 2025",
+			reference:   "2024-06-01T00:00:00Z",
+			opts:        CronOptions{Count: 2},
+			description: "At 12:00 on the 2nd Monday of the month in year 2025",
+			expected:    []string{"2025-01-13T12:00:00Z", "2025-02-10T12:00:00Z"},
+		},
+		{
+			name:        "quartz sunday is 1",
+			expression:  "0 0 8 ? * 1",
+			reference:   "2024-06-01T00:00:00Z",
+			opts:        CronOptions{Count: 1},
+			description: "At 08:00 on Sunday",
+			expected:    []string{"2024-06-02T08:00:00Z"},
+		},
+		{
+			name:        "day of month or day of week",
+			expression:  "0 9 1,15 * MON",
+			reference:   "2024-06-01T10:00:00Z",
+			opts:        CronOptions{Count: 3},
+			description: "At 09:00 on day-of-month 1 and 15 or on Monday",
+			expected:    []string{"2024-06-03T09:00:00Z", "2024-06-10T09:00:00Z", "2024-06-15T09:00:00Z"},
+		},
+		{
+			name:        "month names and hour list",
+			expression:  "0 9,17 * jan-mar *",
+			reference:   "2024-06-01T10:00:00Z",
+			opts:        CronOptions{Count: 2},
+			description: "At 09:00 and 17:00 in January through March",
+			expected:    []string{"2025-01-01T09:00:00Z", "2025-01-01T17:00:00Z"},
+		},
+		{
+			name:        "sunday as 7",
+			expression:  "0 0 * * 5-7",
+			reference:   "2024-06-01T10:00:00Z",
+			opts:        CronOptions{Count: 2},
+			description: "At 00:00 on Friday through Sunday",
+			expected:    []string{"2024-06-02T00:00:00Z", "2024-06-07T00:00:00Z"},
+		},
+		{
+			name:       "never runs",
+			expression: "0 0 30 2 *",
+			reference:  "2024-06-01T10:00:00Z",
+			expected:   []string{},
+		},
+		{name: "value out of range", expression: "61 * * * *", wantErr: true, errCode: ErrCodeInvalidCron},
+		{name: "too few fields", expression: "* * *", wantErr: true, errCode: ErrCodeInvalidCron},
+		{name: "unknown macro", expression: "@reboot", wantErr: true, errCode: ErrCodeInvalidCron},
+		{name: "backwards range", expression: "0 0 * * 5-1", wantErr: true, errCode: ErrCodeInvalidCron},
+		{name: "quartz with both days", expression: "0 0 12 * * MON", opts: CronOptions{Dialect: "quartz"}, wantErr: true, errCode: ErrCodeInvalidCron},
+		{name: "invalid count", expression: "* * * * *", opts: CronOptions{Count: 101}, wantErr: true, errCode: ErrCodeTimeOperation},
+		{name: "invalid direction", expression: "* * * * *", opts: CronOptions{Direction: "sideways"}, wantErr: true, errCode: ErrCodeTimeOperation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.CronRuns(tt.expression, tt.reference, tt.opts)
+
+			if tt.wantErr {
+				var tsErr *TimeServiceError
+				if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+					t.Errorf("Expected error code %d, got %+v, %v", tt.errCode, result, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if tt.dialect != "" && result.Dialect != tt.dialect {
+				t.Errorf("Expected dialect %s, got %s", tt.dialect, result.Dialect)
+			}
+			if tt.description != "" && result.Description != tt.description {
+				t.Errorf("Expected description %q, got %q", tt.description, result.Description)
+			}
+			if len(result.Runs) != len(tt.expected) {
+				t.Fatalf("Expected %d runs, got %+v", len(tt.expected), result.Runs)
+			}
+			for i, expected := range tt.expected {
+				if result.Runs[i].Time != expected {
+					t.Errorf("Run %d: expected %s, got %s", i, expected, result.Runs[i].Time)
+				}
+				if tt.notes != nil && (result.Runs[i].Note != "") != tt.notes[i] {
+					t.Errorf("Run %d: unexpected note %q", i, result.Runs[i].Note)
+				}
+			}
+		})
+	}
+}
+
+func TestNearestWeekday(t *testing.T) {
+	tests := []struct {
+		year, month, day int
+		expected         int
+	}{
+		{2024, 6, 15, 14}, // Saturday to Friday
+		{2024, 6, 16, 17}, // Sunday to Monday
+		{2024, 6, 1, 3},   // Saturday the 1st moves forward to Monday
+		{2024, 3, 31, 29}, // Sunday the 31st moves back to Friday
+		{2024, 6, 12, 12},
+	}
+
+	for _, tt := range tests {
+		if got := nearestWeekday(tt.year, time.Month(tt.month), tt.day); got != tt.expected {
+			t.Errorf("%d-%02d-%02d: expected %d, got %d", tt.year, tt.month, tt.day, tt.expected, got)
+		}
+	}
+}
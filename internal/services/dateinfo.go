@@ -0,0 +1,141 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateInfo holds the calendar facts of a date. The ISO week runs Monday to
+// Sunday and week 1 contains the year's first Thursday; the US week runs
+// Sunday to Saturday and week 1 contains 1 January; the broadcast week runs
+// Monday to Sunday and week 1 contains 1 January, so a broadcast year can
+// begin in late December.
+type DateInfo struct {
+	Date            string           `json:"date"`
+	Timezone        string           `json:"timezone"`
+	Weekday         string           `json:"weekday"`
+	ISOWeekday      int              `json:"isoWeekday"`
+	ISOYear         int              `json:"isoYear"`
+	ISOWeek         int              `json:"isoWeek"`
+	ISOWeekDate     string           `json:"isoWeekDate"`
+	USWeek          int              `json:"usWeek"`
+	BroadcastYear   int              `json:"broadcastYear"`
+	BroadcastWeek   int              `json:"broadcastWeek"`
+	DayOfYear       int              `json:"dayOfYear"`
+	OrdinalDate     string           `json:"ordinalDate"`
+	Quarter         int              `json:"quarter"`
+	DaysInMonth     int              `json:"daysInMonth"`
+	DaysInYear      int              `json:"daysInYear"`
+	IsLeapYear      bool             `json:"isLeapYear"`
+	WeekStart       string           `json:"weekStart"`
+	WeekEnd         string           `json:"weekEnd"`
+	MonthStart      string           `json:"monthStart"`
+	MonthEnd        string           `json:"monthEnd"`
+	QuarterStart    string           `json:"quarterStart"`
+	QuarterEnd      string           `json:"quarterEnd"`
+	YearStart       string           `json:"yearStart"`
+	YearEnd         string           `json:"yearEnd"`
+	HoursInDay      float64          `json:"hoursInDay"`
+	IsDSTTransition bool             `json:"isDstTransition"`
+	Transitions     []ZoneTransition `json:"transitions,omitempty"`
+}
+
+// DateInfo returns the weekday, week numbers, ordinal day, quarter, lengths
+// and period boundaries of a date read in a timezone, and whether the zone
+// changes its offset that day. date defaults to today; weekStart, a weekday
+// name defaulting to Monday, sets the bounds of the week.
+func (ts *timeService) DateInfo(date, timezone, weekStart string) (*DateInfo, error) {
+	loc, err := ts.loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	firstDay := time.Monday
+	if weekStart != "" {
+		var ok bool
+		if firstDay, ok = parseWeekday(weekStart); !ok {
+			return nil, NewTimeServiceError(
+				ErrCodeTimeOperation,
+				fmt.Sprintf("invalid week start '%s': expected a weekday name", weekStart),
+				"weekStart",
+				nil,
+			)
+		}
+	}
+
+	if strings.TrimSpace(date) == "" {
+		date = "today"
+	}
+	day, err := ts.parseCivilDate(date, timezone)
+	if err != nil {
+		return nil, err
+	}
+	year, month, dom := day.Date()
+
+	isoYear, isoWeek := day.ISOWeek()
+	isoWeekday := (int(day.Weekday())+6)%7 + 1
+
+	// Week 1 of the US and broadcast calendars is the week holding 1 January
+	jan1 := civilDate(year, time.January, 1)
+	usWeek := (day.YearDay()+int(jan1.Weekday())-1)/7 + 1
+	broadcastYear := year
+	if next := broadcastYearStart(year + 1); !day.Before(next) {
+		broadcastYear++
+	}
+	broadcastWeek := int(day.Sub(broadcastYearStart(broadcastYear)).Hours()/24)/7 + 1
+
+	quarter := (int(month)-1)/3 + 1
+	monthStart := civilDate(year, month, 1)
+	quarterStart := civilDate(year, time.Month(3*quarter-2), 1)
+	weekStartDay := day.AddDate(0, 0, -((int(day.Weekday()) - int(firstDay) + 7) % 7))
+	daysInYear := civilDate(year, time.December, 31).YearDay()
+
+	// The local day runs from midnight to the next midnight, which DST can
+	// bring closer together or push apart
+	dayStart := time.Date(year, month, dom, 0, 0, 0, 0, loc)
+	nextDayStart := time.Date(year, month, dom+1, 0, 0, 0, 0, loc)
+	transitions := zoneTransitions(dayStart.Add(-time.Nanosecond), nextDayStart.Add(-time.Nanosecond))
+
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	const layout = "2006-01-02"
+	return &DateInfo{
+		Date:            day.Format(layout),
+		Timezone:        timezone,
+		Weekday:         day.Weekday().String(),
+		ISOWeekday:      isoWeekday,
+		ISOYear:         isoYear,
+		ISOWeek:         isoWeek,
+		ISOWeekDate:     fmt.Sprintf("%04d-W%02d-%d", isoYear, isoWeek, isoWeekday),
+		USWeek:          usWeek,
+		BroadcastYear:   broadcastYear,
+		BroadcastWeek:   broadcastWeek,
+		DayOfYear:       day.YearDay(),
+		OrdinalDate:     fmt.Sprintf("%04d-%03d", year, day.YearDay()),
+		Quarter:         quarter,
+		DaysInMonth:     monthStart.AddDate(0, 1, -1).Day(),
+		DaysInYear:      daysInYear,
+		IsLeapYear:      daysInYear == 366,
+		WeekStart:       weekStartDay.Format(layout),
+		WeekEnd:         weekStartDay.AddDate(0, 0, 6).Format(layout),
+		MonthStart:      monthStart.Format(layout),
+		MonthEnd:        monthStart.AddDate(0, 1, -1).Format(layout),
+		QuarterStart:    quarterStart.Format(layout),
+		QuarterEnd:      quarterStart.AddDate(0, 3, -1).Format(layout),
+		YearStart:       jan1.Format(layout),
+		YearEnd:         civilDate(year, time.December, 31).Format(layout),
+		HoursInDay:      nextDayStart.Sub(dayStart).Hours(),
+		IsDSTTransition: len(transitions) > 0,
+		Transitions:     transitions,
+	}, nil
+}
+
+// broadcastYearStart returns the Monday on or before 1 January of a year,
+// the first day of its broadcast calendar
+func broadcastYearStart(year int) time.Time {
+	jan1 := civilDate(year, time.January, 1)
+	return jan1.AddDate(0, 0, -((int(jan1.Weekday()) + 6) % 7))
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimeService_DateInfo(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name      string
+		date      string
+		timezone  string
+		weekStart string
+		expected  DateInfo
+		wantErr   bool
+		errCode   int
+	}{
+		{
+			name:     "spring forward in New York",
+			date:     "2027-03-14",
+			timezone: "America/New_York",
+			expected: DateInfo{
+				Date: "2027-03-14", Weekday: "Sunday", ISOWeekday: 7, ISOYear: 2027, ISOWeek: 10, ISOWeekDate: "2027-W10-7",
+				USWeek: 12, BroadcastYear: 2027, BroadcastWeek: 11, DayOfYear: 73, OrdinalDate: "2027-073",
+				Quarter: 1, DaysInMonth: 31, DaysInYear: 365, WeekStart: "2027-03-08", WeekEnd: "2027-03-14",
+				MonthStart: "2027-03-01", MonthEnd: "2027-03-31", QuarterStart: "2027-01-01", QuarterEnd: "2027-03-31",
+				YearStart: "2027-01-01", YearEnd: "2027-12-31", HoursInDay: 23, IsDSTTransition: true,
+			},
+		},
+		{
+			name:      "week starting on Sunday",
+			date:      "2027-03-14T12:00:00Z",
+			weekStart: "sun",
+			expected: DateInfo{
+				Date: "2027-03-14", Weekday: "Sunday", ISOWeekday: 7, ISOYear: 2027, ISOWeek: 10, ISOWeekDate: "2027-W10-7",
+				USWeek: 12, BroadcastYear: 2027, BroadcastWeek: 11, DayOfYear: 73, OrdinalDate: "2027-073",
+				Quarter: 1, DaysInMonth: 31, DaysInYear: 365, WeekStart: "2027-03-14", WeekEnd: "2027-03-20",
+				MonthStart: "2027-03-01", MonthEnd: "2027-03-31", QuarterStart: "2027-01-01", QuarterEnd: "2027-03-31",
+				YearStart: "2027-01-01", YearEnd: "2027-12-31", HoursInDay: 24,
+			},
+		},
+		{
+			name: "last days of a leap year in next year's weeks",
+			date: "2024-12-30",
+			expected: DateInfo{
+				Date: "2024-12-30", Weekday: "Monday", ISOWeekday: 1, ISOYear: 2025, ISOWeek: 1, ISOWeekDate: "2025-W01-1",
+				USWeek: 53, BroadcastYear: 2025, BroadcastWeek: 1, DayOfYear: 365, OrdinalDate: "2024-365",
+				Quarter: 4, DaysInMonth: 31, DaysInYear: 366, IsLeapYear: true, WeekStart: "2024-12-30", WeekEnd: "2025-01-05",
+				MonthStart: "2024-12-01", MonthEnd: "2024-12-31", QuarterStart: "2024-10-01", QuarterEnd: "2024-12-31",
+				YearStart: "2024-01-01", YearEnd: "2024-12-31", HoursInDay: 24,
+			},
+		},
+		{
+			name:     "fall back in New York",
+			date:     "2024-11-03",
+			timezone: "America/New_York",
+			expected: DateInfo{
+				Date: "2024-11-03", Weekday: "Sunday", ISOWeekday: 7, ISOYear: 2024, ISOWeek: 44, ISOWeekDate: "2024-W44-7",
+				USWeek: 45, BroadcastYear: 2024, BroadcastWeek: 44, DayOfYear: 308, OrdinalDate: "2024-308",
+				Quarter: 4, DaysInMonth: 30, DaysInYear: 366, IsLeapYear: true, WeekStart: "2024-10-28", WeekEnd: "2024-11-03",
+				MonthStart: "2024-11-01", MonthEnd: "2024-11-30", QuarterStart: "2024-10-01", QuarterEnd: "2024-12-31",
+				YearStart: "2024-01-01", YearEnd: "2024-12-31", HoursInDay: 25, IsDSTTransition: true,
+			},
+		},
+		{name: "invalid week start", date: "2024-01-01", weekStart: "someday", wantErr: true, errCode: ErrCodeTimeOperation},
+		{name: "invalid date", date: "2024-02-30", wantErr: true, errCode: ErrCodeInvalidTime},
+		{name: "invalid timezone", date: "2024-01-01", timezone: "Mars/Olympus", wantErr: true, errCode: ErrCodeInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.DateInfo(tt.date, tt.timezone, tt.weekStart)
+
+			if tt.wantErr {
+				var tsErr *TimeServiceError
+				if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+					t.Errorf("Expected error code %d, got %+v, %v", tt.errCode, result, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if (len(result.Transitions) == 1) != tt.expected.IsDSTTransition {
+				t.Errorf("Expected DST transition %v, got %+v", tt.expected.IsDSTTransition, result.Transitions)
+			}
+			got := *result
+			got.Timezone, got.Transitions = "", nil
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTimeService_DateInfoToday(t *testing.T) {
+	ts := NewTimeService()
+	loc, _ := time.LoadLocation("Pacific/Kiritimati")
+
+	for _, date := range []string{"", "today", "Today"} {
+		before := time.Now().In(loc).Format("2006-01-02")
+		result, err := ts.DateInfo(date, "Pacific/Kiritimati", "")
+		after := time.Now().In(loc).Format("2006-01-02")
+
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", date, err)
+		}
+		if result.Date != before && result.Date != after {
+			t.Errorf("%q: expected %s, got %s", date, before, result.Date)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import "time"
+
+// CalendarBreakdown expresses a difference in calendar units
+type CalendarBreakdown struct {
+	Years   int `json:"years"`
+	Months  int `json:"months"`
+	Days    int `json:"days"`
+	Hours   int `json:"hours"`
+	Minutes int `json:"minutes"`
+	Seconds int `json:"seconds"`
+}
+
+// TimeDifference describes the difference between two instants
+type TimeDifference struct {
+	From         string            `json:"from"`
+	To           string            `json:"to"`
+	Negative     bool              `json:"negative"`
+	Duration     string            `json:"duration"`
+	TotalSeconds float64           `json:"totalSeconds"`
+	TotalHours   float64           `json:"totalHours"`
+	TotalDays    float64           `json:"totalDays"`
+	Calendar     CalendarBreakdown `json:"calendar"`
+}
+
+// Diff returns the difference b - a. The exact totals are independent of the
+// timezone, while the calendar breakdown is computed on wall-clock time in the
+// given timezone so that a day spanning a DST change still counts as one day.
+func (ts *timeService) Diff(a, b, timezone string) (*TimeDifference, error) {
+	loc, err := ts.loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := ts.parseInstant(a, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := ts.parseInstant(b, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	from = from.In(loc)
+	to = to.In(loc)
+
+	// Compute totals from Unix seconds so spans beyond time.Duration's range stay exact
+	totalSeconds := float64(to.Unix()-from.Unix()) + float64(to.Nanosecond()-from.Nanosecond())/1e9
+
+	start, end := from, to
+	negative := to.Before(from)
+	if negative {
+		start, end = to, from
+	}
+
+	return &TimeDifference{
+		From:         from.Format(time.RFC3339Nano),
+		To:           to.Format(time.RFC3339Nano),
+		Negative:     negative,
+		Duration:     to.Sub(from).String(),
+		TotalSeconds: totalSeconds,
+		TotalHours:   totalSeconds / 3600,
+		TotalDays:    totalSeconds / 86400,
+		Calendar:     calendarBreakdown(start, end),
+	}, nil
+}
+
+// calendarBreakdown splits the span from start to end (start <= end) into
+// whole months, whole wall-clock days and the remaining exact duration
+func calendarBreakdown(start, end time.Time) CalendarBreakdown {
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	for months > 0 && addMonthsClamped(start, months).After(end) {
+		months--
+	}
+	anchor := addMonthsClamped(start, months)
+
+	days := int(end.Sub(anchor).Hours() / 24)
+	for days > 0 && anchor.AddDate(0, 0, days).After(end) {
+		days--
+	}
+	for !anchor.AddDate(0, 0, days+1).After(end) {
+		days++
+	}
+	anchor = anchor.AddDate(0, 0, days)
+
+	rest := end.Sub(anchor)
+	hours := int(rest / time.Hour)
+	rest -= time.Duration(hours) * time.Hour
+	minutes := int(rest / time.Minute)
+	rest -= time.Duration(minutes) * time.Minute
+
+	return CalendarBreakdown{
+		Years:   months / 12,
+		Months:  months % 12,
+		Days:    days,
+		Hours:   hours,
+		Minutes: minutes,
+		Seconds: int(rest / time.Second),
+	}
+}
+
+// addMonthsClamped adds n months to t, clamping the day to the end of the
+// target month instead of overflowing into the next one (Jan 31 + 1 month is
+// Feb 28 or 29, not early March as with time.AddDate)
+func addMonthsClamped(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+
+	target := time.Date(year, month+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	if last := daysIn(target.Year(), target.Month()); day > last {
+		day = last
+	}
+
+	return time.Date(target.Year(), target.Month(), day, hour, min, sec, t.Nanosecond(), t.Location())
+}
+
+// daysIn returns the number of days in the given month
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
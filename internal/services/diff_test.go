@@ -0,0 +1,126 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTimeService_Diff(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name          string
+		a             string
+		b             string
+		timezone      string
+		expected      CalendarBreakdown
+		expectedHours float64
+		negative      bool
+	}{
+		{
+			name:          "Simple span",
+			a:             "2024-01-15T10:00:00Z",
+			b:             "2024-03-20T12:30:15Z",
+			expected:      CalendarBreakdown{Months: 2, Days: 5, Hours: 2, Minutes: 30, Seconds: 15},
+			expectedHours: 1562.5041666666667,
+		},
+		{
+			name:          "Across spring DST change counts one day",
+			a:             "2024-03-09T12:00:00",
+			b:             "2024-03-10T12:00:00",
+			timezone:      "America/New_York",
+			expected:      CalendarBreakdown{Days: 1},
+			expectedHours: 23,
+		},
+		{
+			name:          "Month end to month end",
+			a:             "2024-01-31",
+			b:             "2024-02-29",
+			expected:      CalendarBreakdown{Months: 1},
+			expectedHours: 696,
+		},
+		{
+			name:          "Multiple years",
+			a:             "2020-02-29",
+			b:             "2024-03-01",
+			expected:      CalendarBreakdown{Years: 4, Days: 1},
+			expectedHours: 35088,
+		},
+		{
+			name:          "Negative difference",
+			a:             "2024-01-02T00:00:00Z",
+			b:             "2024-01-01T00:00:00Z",
+			expected:      CalendarBreakdown{Days: 1},
+			expectedHours: -24,
+			negative:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.Diff(tt.a, tt.b, tt.timezone)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Calendar != tt.expected {
+				t.Errorf("Expected breakdown %+v, got %+v", tt.expected, result.Calendar)
+			}
+
+			if result.TotalHours != tt.expectedHours {
+				t.Errorf("Expected %v total hours, got %v", tt.expectedHours, result.TotalHours)
+			}
+
+			if result.Negative != tt.negative {
+				t.Errorf("Expected negative=%v, got %v", tt.negative, result.Negative)
+			}
+		})
+	}
+}
+
+func TestTimeService_DiffErrors(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		timezone string
+		code     int
+	}{
+		{
+			name: "Unparseable input",
+			a:    "yesterday-ish",
+			b:    "2024-01-01",
+			code: ErrCodeInvalidTime,
+		},
+		{
+			name: "Ambiguous input",
+			a:    "01/02/2024",
+			b:    "2024-01-01",
+			code: ErrCodeInvalidTime,
+		},
+		{
+			name:     "Invalid timezone",
+			a:        "2024-01-01",
+			b:        "2024-01-02",
+			timezone: "Invalid/Timezone",
+			code:     ErrCodeInvalidTimezone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ts.Diff(tt.a, tt.b, tt.timezone)
+
+			var tsErr *TimeServiceError
+			if !errors.As(err, &tsErr) {
+				t.Fatalf("Expected TimeServiceError, got %v", err)
+			}
+
+			if tsErr.Code != tt.code {
+				t.Errorf("Expected error code %d, got %d", tt.code, tsErr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeAddition describes the result of adding a duration to a time
+type TimeAddition struct {
+	Start    ZonedTime `json:"start"`
+	Duration string    `json:"duration"`
+	Result   ZonedTime `json:"result"`
+}
+
+// calendarDuration is a duration split into calendar units and an exact clock part
+type calendarDuration struct {
+	months int
+	days   int
+	clock  time.Duration
+}
+
+// Add adds a duration to a time in the given timezone.
+// The duration may be a Go duration ("90m", "-2h30m") or an ISO 8601 duration
+// ("P1Y2M10DT2H30M", "-P1D"). Years, months, weeks and days are applied on
+// wall-clock time in the timezone, so P1D across a DST change keeps the same
+// local time while PT24H adds exactly 24 hours. Months are clamped to the end
+// of the target month: Jan 31 + P1M is Feb 29 in a leap year and Feb 28
+// otherwise, rather than overflowing into March as time.AddDate does.
+func (ts *timeService) Add(t, duration, timezone string) (*TimeAddition, error) {
+	loc, err := ts.loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := ts.parseInstant(t, loc)
+	if err != nil {
+		return nil, err
+	}
+	start = start.In(loc)
+
+	d, err := parseCalendarDuration(duration)
+	if err != nil {
+		return nil, err
+	}
+
+	result := addMonthsClamped(start, d.months).AddDate(0, 0, d.days).Add(d.clock)
+
+	return &TimeAddition{
+		Start:    newZonedTime(start, timezone),
+		Duration: duration,
+		Result:   newZonedTime(result, timezone),
+	}, nil
+}
+
+// parseCalendarDuration parses a Go or ISO 8601 duration string
+func parseCalendarDuration(duration string) (calendarDuration, error) {
+	s := strings.ToUpper(strings.TrimSpace(duration))
+	if s == "" {
+		return calendarDuration{}, NewInvalidDurationError(duration, "duration cannot be empty")
+	}
+
+	negative := false
+	if s[0] == '-' || s[0] == '+' {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	if !strings.HasPrefix(s, "P") {
+		d, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil {
+			return calendarDuration{}, NewInvalidDurationError(duration, "expected a Go duration (e.g., '1h30m') or ISO 8601 duration (e.g., 'P1DT2H')")
+		}
+		return calendarDuration{clock: d}, nil
+	}
+
+	d, err := parseISODuration(s[1:])
+	if err != nil {
+		return calendarDuration{}, NewInvalidDurationError(duration, err.Error())
+	}
+
+	if negative {
+		d.months, d.days, d.clock = -d.months, -d.days, -d.clock
+	}
+
+	return d, nil
+}
+
+// parseISODuration parses the part of an ISO 8601 duration after the leading 'P'
+func parseISODuration(s string) (calendarDuration, error) {
+	var d calendarDuration
+	if s == "" || s == "T" {
+		return d, errors.New("duration has no components")
+	}
+
+	inTime := false
+	seen := make(map[string]bool)
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			if inTime {
+				return d, errors.New("duplicate 'T' designator")
+			}
+			inTime = true
+			s = s[1:]
+			if s == "" {
+				return d, errors.New("'T' designator must be followed by a time component")
+			}
+			continue
+		}
+
+		i := 0
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.' || s[i] == ',') {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return d, errors.New("expected a number followed by a unit designator")
+		}
+
+		number := strings.Replace(s[:i], ",", ".", 1)
+		unit := s[i]
+		s = s[i+1:]
+
+		key := string(unit)
+		if inTime {
+			key = "T" + key
+		}
+		if seen[key] {
+			return d, fmt.Errorf("duplicate '%c' component", unit)
+		}
+		seen[key] = true
+
+		if inTime {
+			value, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return d, fmt.Errorf("invalid number '%s'", number)
+			}
+			switch unit {
+			case 'H':
+				d.clock += time.Duration(value * float64(time.Hour))
+			case 'M':
+				d.clock += time.Duration(value * float64(time.Minute))
+			case 'S':
+				d.clock += time.Duration(value * float64(time.Second))
+			default:
+				return d, fmt.Errorf("unknown time unit '%c'", unit)
+			}
+			continue
+		}
+
+		value, err := strconv.Atoi(number)
+		if err != nil {
+			return d, fmt.Errorf("calendar units must be whole numbers, got '%s'", number)
+		}
+		switch unit {
+		case 'Y':
+			d.months += value * 12
+		case 'M':
+			d.months += value
+		case 'W':
+			d.days += value * 7
+		case 'D':
+			d.days += value
+		default:
+			return d, fmt.Errorf("unknown date unit '%c'", unit)
+		}
+	}
+
+	return d, nil
+}
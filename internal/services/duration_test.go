@@ -0,0 +1,132 @@
+package services
+
+import "testing"
+
+func TestTimeService_Add(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name     string
+		time     string
+		duration string
+		timezone string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Go duration",
+			time:     "2024-01-15T10:00:00Z",
+			duration: "1h30m",
+			expected: "2024-01-15T11:30:00Z",
+		},
+		{
+			name:     "Negative Go duration",
+			time:     "2024-01-15T10:00:00Z",
+			duration: "-90m",
+			expected: "2024-01-15T08:30:00Z",
+		},
+		{
+			name:     "ISO 8601 duration",
+			time:     "2024-01-15T10:00:00Z",
+			duration: "P1Y2M10DT2H30M",
+			expected: "2025-03-25T12:30:00Z",
+		},
+		{
+			name:     "ISO 8601 weeks",
+			time:     "2024-01-15T10:00:00Z",
+			duration: "P2W",
+			expected: "2024-01-29T10:00:00Z",
+		},
+		{
+			name:     "Negative ISO 8601 duration",
+			time:     "2024-03-01T00:00:00Z",
+			duration: "-P1D",
+			expected: "2024-02-29T00:00:00Z",
+		},
+		{
+			name:     "Fractional seconds",
+			time:     "2024-01-15T10:00:00Z",
+			duration: "PT1.5S",
+			expected: "2024-01-15T10:00:01.5Z",
+		},
+		{
+			name:     "One day across DST keeps wall-clock time",
+			time:     "2024-03-09T09:00:00",
+			duration: "P1D",
+			timezone: "America/Chicago",
+			expected: "2024-03-10T09:00:00-05:00",
+		},
+		{
+			name:     "24 hours across DST is exact",
+			time:     "2024-03-09T09:00:00",
+			duration: "PT24H",
+			timezone: "America/Chicago",
+			expected: "2024-03-10T10:00:00-05:00",
+		},
+		{
+			name:     "Month end clamps in leap year",
+			time:     "2024-01-31T12:00:00Z",
+			duration: "P1M",
+			expected: "2024-02-29T12:00:00Z",
+		},
+		{
+			name:     "Month end clamps in common year",
+			time:     "2023-01-31T12:00:00Z",
+			duration: "P1M",
+			expected: "2023-02-28T12:00:00Z",
+		},
+		{
+			name:     "Leap day plus one year clamps",
+			time:     "2024-02-29T00:00:00Z",
+			duration: "P1Y",
+			expected: "2025-02-28T00:00:00Z",
+		},
+		{
+			name:     "Ninety days in timezone",
+			time:     "2024-01-01T00:00:00",
+			duration: "P90D",
+			timezone: "America/Chicago",
+			expected: "2024-03-31T00:00:00-05:00",
+		},
+		{
+			name:     "Invalid duration",
+			time:     "2024-01-15T10:00:00Z",
+			duration: "three days",
+			wantErr:  true,
+		},
+		{
+			name:     "Fractional calendar unit",
+			time:     "2024-01-15T10:00:00Z",
+			duration: "P1.5D",
+			wantErr:  true,
+		},
+		{
+			name:     "Empty ISO 8601 duration",
+			time:     "2024-01-15T10:00:00Z",
+			duration: "PT",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.Add(tt.time, tt.duration, tt.timezone)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for duration %s, but got none", tt.duration)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error for duration %s: %v", tt.duration, err)
+				return
+			}
+
+			if result.Result.Time != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result.Result.Time)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package services
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // TimeServiceError represents time service-related errors
 type TimeServiceError struct {
@@ -25,9 +28,19 @@ func (e *TimeServiceError) Unwrap() error {
 
 // Time service error codes (2000-2999 range)
 const (
-	ErrCodeInvalidTimezone = 2001
-	ErrCodeInvalidFormat   = 2002
-	ErrCodeTimeOperation   = 2003
+	ErrCodeInvalidTimezone    = 2001
+	ErrCodeInvalidFormat      = 2002
+	ErrCodeTimeOperation      = 2003
+	ErrCodeInvalidTime        = 2004
+	ErrCodeInvalidDuration    = 2005
+	ErrCodeInvalidCalendar    = 2006
+	ErrCodeAmbiguousZone      = 2007
+	ErrCodeInvalidLocale      = 2008
+	ErrCodeInvalidCron        = 2009
+	ErrCodeInvalidRecurrence  = 2010
+	ErrCodeInvalidCoordinates = 2011
+	ErrCodeInvalidTimestamp   = 2012
+	ErrCodeInvalidID          = 2013
 )
 
 // NewTimeServiceError creates a new time service error
@@ -50,6 +63,17 @@ func NewInvalidTimezoneError(timezone string, err error) *TimeServiceError {
 	)
 }
 
+// NewAmbiguousTimezoneError creates an error for an abbreviation matching
+// several zones, listing the candidates so the caller can pick one
+func NewAmbiguousTimezoneError(abbreviation, reason string, candidates []string) *TimeServiceError {
+	return NewTimeServiceError(
+		ErrCodeAmbiguousZone,
+		fmt.Sprintf("ambiguous timezone abbreviation '%s': %s; candidates: %s", abbreviation, reason, strings.Join(candidates, "; ")),
+		"timezone",
+		nil,
+	)
+}
+
 // NewInvalidFormatError creates an error for invalid format
 func NewInvalidFormatError(format, reason string) *TimeServiceError {
 	return NewTimeServiceError(
@@ -59,3 +83,96 @@ func NewInvalidFormatError(format, reason string) *TimeServiceError {
 		nil,
 	)
 }
+
+// NewInvalidTimeError creates an error for a time value that cannot be parsed
+func NewInvalidTimeError(input, reason string, err error) *TimeServiceError {
+	return NewTimeServiceError(
+		ErrCodeInvalidTime,
+		fmt.Sprintf("invalid time '%s': %s", input, reason),
+		"time",
+		err,
+	)
+}
+
+// NewInvalidDurationError creates an error for a duration that cannot be parsed
+func NewInvalidDurationError(duration, reason string) *TimeServiceError {
+	return NewTimeServiceError(
+		ErrCodeInvalidDuration,
+		fmt.Sprintf("invalid duration '%s': %s", duration, reason),
+		"duration",
+		nil,
+	)
+}
+
+// NewInvalidCalendarError creates an error for an unknown or malformed business
+// calendar or an unknown calendar system
+func NewInvalidCalendarError(calendar, reason string, err error) *TimeServiceError {
+	return NewTimeServiceError(
+		ErrCodeInvalidCalendar,
+		fmt.Sprintf("invalid calendar '%s': %s", calendar, reason),
+		"calendar",
+		err,
+	)
+}
+
+// NewInvalidLocaleError creates an error for an unsupported locale
+func NewInvalidLocaleError(locale, reason string) *TimeServiceError {
+	return NewTimeServiceError(
+		ErrCodeInvalidLocale,
+		fmt.Sprintf("invalid locale '%s': %s", locale, reason),
+		"locale",
+		nil,
+	)
+}
+
+// NewInvalidCronError creates an error for a cron expression that cannot be parsed
+func NewInvalidCronError(expression, reason string) *TimeServiceError {
+	return NewTimeServiceError(
+		ErrCodeInvalidCron,
+		fmt.Sprintf("invalid cron expression '%s': %s", expression, reason),
+		"expression",
+		nil,
+	)
+}
+
+// NewInvalidRecurrenceError creates an error for a recurrence rule that cannot be parsed
+func NewInvalidRecurrenceError(rule, reason string) *TimeServiceError {
+	return NewTimeServiceError(
+		ErrCodeInvalidRecurrence,
+		fmt.Sprintf("invalid recurrence rule '%s': %s", rule, reason),
+		"rrule",
+		nil,
+	)
+}
+
+// NewInvalidCoordinatesError creates an error for a latitude or longitude out of range
+func NewInvalidCoordinatesError(latitude, longitude float64, reason string) *TimeServiceError {
+	return NewTimeServiceError(
+		ErrCodeInvalidCoordinates,
+		fmt.Sprintf("invalid coordinates (%g, %g): %s", latitude, longitude, reason),
+		"latitude",
+		nil,
+	)
+}
+
+// NewInvalidTimestampError creates an error for a value that cannot be read
+// in a timestamp scale
+func NewInvalidTimestampError(value, scale, reason string) *TimeServiceError {
+	return NewTimeServiceError(
+		ErrCodeInvalidTimestamp,
+		fmt.Sprintf("invalid %s timestamp '%s': %s", scale, value, reason),
+		"value",
+		nil,
+	)
+}
+
+// NewInvalidIDError creates an error for an identifier whose timestamp cannot
+// be decoded
+func NewInvalidIDError(id, reason string) *TimeServiceError {
+	return NewTimeServiceError(
+		ErrCodeInvalidID,
+		fmt.Sprintf("invalid ID '%s': %s", id, reason),
+		"id",
+		nil,
+	)
+}
@@ -0,0 +1,1483 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateExpression is a natural-language date expression resolved to an instant.
+// Ambiguous expressions are resolved to their most likely reading, with the
+// other readings listed in Alternatives.
+type DateExpression struct {
+	Expression     string                  `json:"expression"`
+	Result         ZonedTime               `json:"result"`
+	Interpretation string                  `json:"interpretation"`
+	Precision      string                  `json:"precision"`
+	Confidence     float64                 `json:"confidence"`
+	Ambiguous      bool                    `json:"ambiguous"`
+	Alternatives   []DateExpressionReading `json:"alternatives,omitempty"`
+	Reference      ZonedTime               `json:"reference"`
+}
+
+// DateExpressionReading is another reading of an ambiguous expression
+type DateExpressionReading struct {
+	Result         ZonedTime `json:"result"`
+	Interpretation string    `json:"interpretation"`
+	Confidence     float64   `json:"confidence"`
+}
+
+// genericZones maps common names for US timezones to their IANA zones
+var genericZones = map[string]string{
+	"pacific":  "America/Los_Angeles",
+	"pt":       "America/Los_Angeles",
+	"mountain": "America/Denver",
+	"mt":       "America/Denver",
+	"central":  "America/Chicago",
+	"ct":       "America/Chicago",
+	"eastern":  "America/New_York",
+	"et":       "America/New_York",
+	"alaska":   "America/Anchorage",
+	"hawaii":   "Pacific/Honolulu",
+}
+
+// ResolveDateExpression resolves an English date expression such as "next
+// Friday at 9am Pacific", "end of month", "in two weeks" or "the second
+// Tuesday of March" against a reference instant (now when empty). Relative
+// days are counted in the timezone named in the expression, if any, and in
+// the given timezone otherwise.
+func (ts *timeService) ResolveDateExpression(expression, reference, timezone string) (*DateExpression, error) {
+	loc, err := ts.loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var ref time.Time
+	if reference == "" {
+		if ref, err = ts.GetCurrentTime(timezone); err != nil {
+			return nil, err
+		}
+	} else if ref, err = ts.parseInstant(reference, loc); err != nil {
+		return nil, err
+	}
+	ref = ref.In(loc)
+
+	tokens := lexExpression(expression)
+	if len(tokens) == 0 {
+		return nil, NewInvalidTimeError(expression, "expression cannot be empty", nil)
+	}
+
+	zoneName := timezone
+	tokens, zoneLoc, err := ts.expressionZone(expression, tokens)
+	if err != nil {
+		return nil, err
+	}
+	if zoneLoc != nil {
+		loc, zoneName = zoneLoc, zoneLoc.String()
+	}
+
+	p := newExprParser(expression, tokens, ref.In(loc))
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+
+	readings, err := p.readings()
+	if err != nil {
+		return nil, err
+	}
+
+	primary := readings[0]
+	result := &DateExpression{
+		Expression:     expression,
+		Result:         newZonedTime(primary.t, zoneName),
+		Interpretation: primary.describe(zoneLoc),
+		Precision:      primary.precision,
+		Confidence:     primary.confidence,
+		Ambiguous:      len(readings) > 1,
+		Reference:      newZonedTime(ref, timezone),
+	}
+	for _, r := range readings[1:] {
+		result.Alternatives = append(result.Alternatives, DateExpressionReading{
+			Result:         newZonedTime(r.t, zoneName),
+			Interpretation: r.describe(zoneLoc),
+			Confidence:     r.confidence,
+		})
+	}
+
+	return result, nil
+}
+
+// exprTokenKind classifies expression tokens
+type exprTokenKind int
+
+const (
+	exprWord exprTokenKind = iota
+	exprNumber
+	exprSymbol
+)
+
+// exprToken is a word, number or symbol of an expression, with its byte
+// offsets in the expression
+type exprToken struct {
+	kind    exprTokenKind
+	text    string
+	value   int
+	digits  int
+	ordinal bool // number with an ordinal suffix, e.g. "2nd"
+	spaced  bool // preceded by whitespace or at the start
+	start   int
+	end     int
+}
+
+// lexExpression splits a lowercased expression into tokens. "a.m." and "p.m."
+// become the words "am" and "pm", and ordinal suffixes are folded into numbers.
+func lexExpression(expression string) []exprToken {
+	s := strings.ToLower(expression)
+	s = strings.NewReplacer("a.m.", "am  ", "p.m.", "pm  ").Replace(s)
+
+	var tokens []exprToken
+	spaced := true
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			spaced = true
+			i++
+			continue
+
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			value, err := strconv.Atoi(s[i:j])
+			if err != nil || j-i > 9 {
+				value = -1
+			}
+			tok := exprToken{kind: exprNumber, text: s[i:j], value: value, digits: j - i, spaced: spaced, start: i, end: j}
+			for _, suffix := range []string{"st", "nd", "rd", "th"} {
+				if strings.HasPrefix(s[j:], suffix) && (j+2 == len(s) || !isWordByte(s[j+2])) {
+					tok.ordinal, tok.end = true, j+2
+					j += 2
+					break
+				}
+			}
+			tokens = append(tokens, tok)
+			i = j
+
+		case isWordByte(c):
+			j := i
+			for j < len(s) && (isWordByte(s[j]) || s[j] == '\'') {
+				j++
+			}
+			tokens = append(tokens, exprToken{kind: exprWord, text: s[i:j], spaced: spaced, start: i, end: j})
+			i = j
+
+		default:
+			tokens = append(tokens, exprToken{kind: exprSymbol, text: s[i : i+1], spaced: spaced, start: i, end: i + 1})
+			i++
+		}
+		spaced = false
+	}
+
+	return tokens
+}
+
+// isWordByte reports whether c may be part of a word: letters, '_' and any
+// non-ASCII byte
+func isWordByte(c byte) bool {
+	return isASCIILetter(c) || c == '_' || c >= 0x80
+}
+
+// expressionZone removes a trailing timezone, such as "Pacific", "PST",
+// "Europe/Paris", "UTC+2" or "Tokyo time", from the tokens and resolves it
+func (ts *timeService) expressionZone(expression string, tokens []exprToken) ([]exprToken, *time.Location, error) {
+	n := len(tokens)
+	if n > 1 && tokens[n-1].text == "time" {
+		n--
+	}
+
+	for k := min(n, 4); k >= 1; k-- {
+		first := tokens[n-k]
+		if first.kind == exprNumber || (first.kind == exprSymbol && (!first.spaced || (first.text != "+" && first.text != "-"))) {
+			continue
+		}
+		if k == n && n == len(tokens) {
+			// The whole expression is not a timezone
+			continue
+		}
+
+		name := strings.TrimSpace(expression[first.start:tokens[n-1].end])
+		loc, err := ts.lookupExpressionZone(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if loc == nil {
+			continue
+		}
+
+		rest := tokens[:n-k]
+		if len(rest) > 1 && rest[len(rest)-1].text == "in" {
+			rest = rest[:len(rest)-1]
+		}
+		return rest, loc, nil
+	}
+
+	return tokens, nil, nil
+}
+
+// lookupExpressionZone resolves a timezone name from an expression. It returns
+// nil for text that is not a timezone, and an error only for an ambiguous
+// abbreviation.
+func (ts *timeService) lookupExpressionZone(name string) (*time.Location, error) {
+	key := strings.ToLower(name)
+	if zone, ok := genericZones[key]; ok {
+		return time.LoadLocation(zone)
+	}
+
+	loc, err := ts.zones.Resolve(name)
+	if err == nil {
+		return loc, nil
+	}
+	var tsErr *TimeServiceError
+	if errors.As(err, &tsErr) && tsErr.Code == ErrCodeAmbiguousZone {
+		return nil, err
+	}
+
+	// City names, e.g. "Tokyo" or "New York"
+	city := normalizeZoneText(name)
+	for _, entry := range getZoneCatalog().entries {
+		if entry.linkTarget != "" {
+			continue
+		}
+		if i := strings.LastIndexByte(entry.name, '/'); i >= 0 && normalizeZoneText(entry.name[i+1:]) == city {
+			return entry.loc, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Words understood by the expression parser
+var (
+	exprMonths = map[string]time.Month{
+		"january": time.January, "jan": time.January, "february": time.February, "feb": time.February,
+		"march": time.March, "mar": time.March, "april": time.April, "apr": time.April, "may": time.May,
+		"june": time.June, "jun": time.June, "july": time.July, "jul": time.July,
+		"august": time.August, "aug": time.August, "september": time.September, "sep": time.September,
+		"sept": time.September, "october": time.October, "oct": time.October,
+		"november": time.November, "nov": time.November, "december": time.December, "dec": time.December,
+	}
+	exprWeekdays = map[string]time.Weekday{
+		"sunday": time.Sunday, "sun": time.Sunday, "monday": time.Monday, "mon": time.Monday,
+		"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday, "thursday": time.Thursday,
+		"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday, "saturday": time.Saturday, "sat": time.Saturday,
+	}
+	exprNumbers = map[string]int{
+		"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8,
+		"nine": 9, "ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+		"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19, "twenty": 20, "thirty": 30,
+		"forty": 40, "fifty": 50, "sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+	}
+	exprOrdinals = map[string]int{
+		"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "last": -1,
+	}
+	// exprSpans are the periods usable in "next week", "end of month" etc.
+	exprSpans = map[string]string{
+		"day": "day", "week": "week", "month": "month", "quarter": "quarter", "year": "year",
+	}
+	// exprDayParts maps parts of the day to their default time and half of the day
+	exprDayParts = map[string]struct {
+		hour int
+		pm   bool
+	}{
+		"morning": {9, false}, "afternoon": {15, true}, "evening": {19, true}, "night": {21, true},
+	}
+)
+
+// exprUnit is a unit of a relative offset such as "2 weeks"
+type exprUnit struct {
+	name      string
+	duration  calendarDuration
+	precision string
+}
+
+var exprUnits = map[string]exprUnit{}
+
+func init() {
+	units := []struct {
+		names     []string
+		duration  calendarDuration
+		precision string
+	}{
+		{[]string{"second", "seconds", "sec", "secs"}, calendarDuration{clock: time.Second}, "second"},
+		{[]string{"minute", "minutes", "min", "mins"}, calendarDuration{clock: time.Minute}, "second"},
+		{[]string{"hour", "hours", "hr", "hrs"}, calendarDuration{clock: time.Hour}, "second"},
+		{[]string{"day", "days"}, calendarDuration{days: 1}, "day"},
+		{[]string{"week", "weeks", "wk", "wks"}, calendarDuration{days: 7}, "day"},
+		{[]string{"fortnight", "fortnights"}, calendarDuration{days: 14}, "day"},
+		{[]string{"month", "months"}, calendarDuration{months: 1}, "day"},
+		{[]string{"quarter", "quarters"}, calendarDuration{months: 3}, "day"},
+		{[]string{"year", "years", "yr", "yrs"}, calendarDuration{months: 12}, "day"},
+	}
+	for _, u := range units {
+		for _, name := range u.names {
+			exprUnits[name] = exprUnit{name: u.names[1], duration: u.duration, precision: u.precision}
+		}
+	}
+}
+
+// Precisions of resolved expressions, from finest to coarsest
+var exprPrecisions = []string{"second", "minute", "hour", "day", "week", "month", "quarter", "year"}
+
+// dateOption is one reading of the date part of an expression: a day, or the
+// first day of a longer span such as a week or month
+type dateOption struct {
+	start      time.Time
+	span       string
+	desc       string
+	confidence float64
+}
+
+// clockOption is one reading of the time of day
+type clockOption struct {
+	hour, minute, second int
+	precision            string
+	label                string // the time as written
+	desc                 string
+	confidence           float64
+}
+
+// exprOffset is a relative offset such as "in 2 weeks" or "3 days ago"
+type exprOffset struct {
+	duration   calendarDuration
+	precision  string
+	desc       string
+	anchored   bool // counted from a date in the expression rather than the reference
+	confidence float64
+}
+
+// exprParser parses an expression into date, time and offset options
+type exprParser struct {
+	expression string
+	tokens     []exprToken
+	pos        int
+	ref        time.Time
+	today      time.Time
+
+	now      bool
+	dates    []dateOption
+	clocks   []clockOption
+	offset   *exprOffset
+	boundary string
+	dayPart  string
+}
+
+func newExprParser(expression string, tokens []exprToken, ref time.Time) *exprParser {
+	y, m, d := ref.Date()
+	return &exprParser{
+		expression: expression,
+		tokens:     tokens,
+		ref:        ref,
+		today:      time.Date(y, m, d, 0, 0, 0, 0, ref.Location()),
+	}
+}
+
+// peek returns the token k positions ahead, or an empty token past the end
+func (p *exprParser) peek(k int) exprToken {
+	if p.pos+k < len(p.tokens) {
+		return p.tokens[p.pos+k]
+	}
+	return exprToken{kind: exprSymbol, start: len(p.expression)}
+}
+
+// word returns the text of the word k positions ahead, or "" if it is not a word
+func (p *exprParser) word(k int) string {
+	if tok := p.peek(k); tok.kind == exprWord {
+		return tok.text
+	}
+	return ""
+}
+
+// accept consumes the current token when it is one of the given words or symbols
+func (p *exprParser) accept(texts ...string) bool {
+	tok := p.peek(0)
+	if tok.kind == exprNumber {
+		return false
+	}
+	for _, text := range texts {
+		if tok.text == text && text != "" {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+// errorf reports a parse error at the current token
+func (p *exprParser) errorf(format string, args ...interface{}) error {
+	return NewInvalidTimeError(p.expression, fmt.Sprintf(format, args...)+fmt.Sprintf(" at position %d", p.peek(0).start+1), nil)
+}
+
+// parse reads the whole expression. Each part of an expression may appear once.
+func (p *exprParser) parse() error {
+	parts := []func() (bool, error){
+		p.parseOffset, p.parseNow, p.parseBoundary, p.parseOrdinalDay,
+		p.parseRelative, p.parseDayWord, p.parseNumericDate, p.parseMonthDay,
+		p.parseWeekday, p.parseClock, p.parseDayPart, p.parseYear,
+	}
+
+	for p.pos < len(p.tokens) {
+		matched := false
+		for _, part := range parts {
+			ok, err := part()
+			if err != nil {
+				return err
+			}
+			if ok {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		// Filler words between parts
+		if p.accept("at", "on", "the", "by", "in", "of", ",", ".") {
+			continue
+		}
+		return p.errorf("unrecognized '%s'", p.peek(0).text)
+	}
+
+	if p.offset != nil && p.offset.anchored && p.dates == nil {
+		return NewInvalidTimeError(p.expression, "expected a date to count the offset from", nil)
+	}
+	if p.boundary != "" && p.clocks != nil {
+		return NewInvalidTimeError(p.expression, fmt.Sprintf("a time cannot be combined with the %s of a period", p.boundary), nil)
+	}
+	if !p.now && p.dates == nil && p.clocks == nil && p.offset == nil && p.dayPart == "" {
+		return NewInvalidTimeError(p.expression, "no date or time found", nil)
+	}
+
+	return nil
+}
+
+// setDate records the date part, rejecting a second one
+func (p *exprParser) setDate(options ...dateOption) error {
+	if p.dates != nil || p.now {
+		return p.errorf("more than one date")
+	}
+	p.dates = options
+	return nil
+}
+
+// setClock records the time of day, rejecting a second one
+func (p *exprParser) setClock(options ...clockOption) error {
+	if p.clocks != nil {
+		return p.errorf("more than one time of day")
+	}
+	p.clocks = options
+	return nil
+}
+
+// quantity reads a count such as "2", "two", "twenty-five", "a" or "a couple of"
+func (p *exprParser) quantity() (int, int, float64, bool) {
+	tok := p.peek(0)
+	switch {
+	case tok.kind == exprNumber && !tok.ordinal && tok.value >= 0:
+		return tok.value, 1, 1, true
+	case exprNumbers[tok.text] > 0:
+		n, width := p.numberWords()
+		return n, width, 1, true
+	case tok.text == "couple" && p.word(1) == "of":
+		return 2, 2, 1, true
+	case tok.text == "a" || tok.text == "an":
+		switch {
+		case p.word(1) == "couple" && p.word(2) == "of":
+			return 2, 3, 1, true
+		case p.word(1) == "few":
+			return 3, 2, 0.5, true
+		}
+		return 1, 1, 1, true
+	}
+	return 0, 0, 0, false
+}
+
+// numberWords reads a number word, joining tens and units written as
+// "twenty five" or "twenty-five"
+func (p *exprParser) numberWords() (int, int) {
+	n := exprNumbers[p.word(0)]
+	if n < 20 || n%10 != 0 {
+		return n, 1
+	}
+	if unit := exprNumbers[p.word(1)]; unit > 0 && unit < 10 {
+		return n + unit, 2
+	}
+	if hyphen, next := p.peek(1), p.peek(2); hyphen.text == "-" && !hyphen.spaced && !next.spaced {
+		if unit := exprNumbers[p.word(2)]; unit > 0 && unit < 10 {
+			return n + unit, 3
+		}
+	}
+	return n, 1
+}
+
+// parseOffset reads "in 2 weeks", "3 days ago", "2 hours from now" and
+// "a week from tomorrow"
+func (p *exprParser) parseOffset() (bool, error) {
+	start := p.pos
+	in := p.accept("in")
+
+	n, width, confidence, ok := p.quantity()
+	if !ok {
+		p.pos = start
+		return false, nil
+	}
+	unit, ok := exprUnits[p.word(width)]
+	if !ok {
+		p.pos = start
+		return false, nil
+	}
+	if p.offset != nil {
+		return false, p.errorf("more than one offset")
+	}
+	p.pos += width + 1
+
+	offset := &exprOffset{precision: unit.precision, confidence: confidence}
+	amount := fmt.Sprintf("%d %s", n, unit.name)
+	if n == 1 {
+		amount = "1 " + strings.TrimSuffix(unit.name, "s")
+	}
+
+	sign := 1
+	switch {
+	case in:
+		offset.desc = "in " + amount
+	case p.accept("ago", "earlier"):
+		sign = -1
+		offset.desc = amount + " ago"
+	case p.accept("later", "hence"):
+		offset.desc = "in " + amount
+	case p.word(0) == "from" && p.word(1) == "now":
+		p.pos += 2
+		offset.desc = "in " + amount
+	case p.accept("from", "after"):
+		offset.anchored = true
+		offset.desc = amount + " after"
+	case p.accept("before"):
+		sign = -1
+		offset.anchored = true
+		offset.desc = amount + " before"
+	default:
+		p.pos = start
+		return false, nil
+	}
+
+	offset.duration = calendarDuration{
+		months: sign * n * unit.duration.months,
+		days:   sign * n * unit.duration.days,
+		clock:  time.Duration(sign*n) * unit.duration.clock,
+	}
+	p.offset = offset
+	return true, nil
+}
+
+// parseNow reads "now"
+func (p *exprParser) parseNow() (bool, error) {
+	if p.word(0) != "now" {
+		return false, nil
+	}
+	if p.dates != nil || p.now {
+		return false, p.errorf("more than one date")
+	}
+	p.pos++
+	p.now = true
+	return true, nil
+}
+
+// parseBoundary reads "end of month", "start of next week", "beginning of
+// March" and the abbreviations "eod", "eow", "eom", "eoq" and "eoy"
+func (p *exprParser) parseBoundary() (bool, error) {
+	abbreviations := map[string]string{"eod": "day", "eow": "week", "eom": "month", "eoq": "quarter", "eoy": "year"}
+	if span, ok := abbreviations[p.word(0)]; ok {
+		p.pos++
+		p.boundary = "end"
+		return true, p.setDate(p.currentSpan(span, 0, "this "+span))
+	}
+
+	var boundary string
+	switch p.word(0) {
+	case "end":
+		boundary = "end"
+	case "start", "beginning":
+		boundary = "start"
+	default:
+		return false, nil
+	}
+	if p.word(1) != "of" {
+		return false, nil
+	}
+	p.pos += 2
+	p.accept("the")
+
+	options, ok, err := p.spanReference()
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, p.errorf("expected a period after '%s of'", boundary)
+	}
+	if p.boundary != "" {
+		return false, p.errorf("more than one period boundary")
+	}
+	p.boundary = boundary
+	return true, p.setDate(options...)
+}
+
+// spanReference reads a period: "day", "this week", "next month", "last
+// year", "today", "tomorrow", a month with an optional year, or a year
+func (p *exprParser) spanReference() ([]dateOption, bool, error) {
+	shift, label := 0, "this "
+	switch {
+	case p.accept("this", "current"):
+	case p.accept("next", "coming"):
+		shift, label = 1, "next "
+	case p.accept("last", "previous", "past"):
+		shift, label = -1, "last "
+	}
+
+	if span, ok := exprSpans[p.word(0)]; ok {
+		p.pos++
+		return []dateOption{p.currentSpan(span, shift, label+span)}, true, nil
+	}
+	if shift == 0 {
+		switch p.word(0) {
+		case "today", "tomorrow", "yesterday":
+			word := p.word(0)
+			p.pos++
+			days := map[string]int{"today": 0, "tomorrow": 1, "yesterday": -1}[word]
+			day := p.today.AddDate(0, 0, days)
+			return []dateOption{{start: day, span: "day", desc: dayDesc(word, day), confidence: 1}}, true, nil
+		}
+		if _, ok := exprMonths[p.word(0)]; ok {
+			options, err := p.monthSpan()
+			return options, true, err
+		}
+		if tok := p.peek(0); tok.kind == exprNumber && tok.digits == 4 && !tok.ordinal {
+			p.pos++
+			return []dateOption{p.yearSpan(tok.value)}, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// currentSpan returns the span of the given kind containing the reference
+// day, shifted by a number of spans
+func (p *exprParser) currentSpan(span string, shift int, label string) dateOption {
+	start := spanStart(p.today, span)
+	switch span {
+	case "day":
+		start = start.AddDate(0, 0, shift)
+	case "week":
+		start = start.AddDate(0, 0, 7*shift)
+	case "month":
+		start = start.AddDate(0, shift, 0)
+	case "quarter":
+		start = start.AddDate(0, 3*shift, 0)
+	case "year":
+		start = start.AddDate(shift, 0, 0)
+	}
+	return dateOption{start: start, span: span, desc: spanDesc(label, start, span), confidence: 1}
+}
+
+// yearSpan returns the span of a whole year
+func (p *exprParser) yearSpan(year int) dateOption {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, p.today.Location())
+	return dateOption{start: start, span: "year", desc: strconv.Itoa(year), confidence: 1}
+}
+
+// monthSpan reads a month name with an optional year. Without a year a past
+// month most likely means next year's, with this year's as an alternative.
+func (p *exprParser) monthSpan() ([]dateOption, error) {
+	month := exprMonths[p.word(0)]
+	p.pos++
+
+	if year, ok := p.optionalYear(); ok {
+		start := time.Date(year, month, 1, 0, 0, 0, 0, p.today.Location())
+		return []dateOption{{start: start, span: "month", desc: spanDesc("", start, "month"), confidence: 1}}, nil
+	}
+
+	this := time.Date(p.today.Year(), month, 1, 0, 0, 0, 0, p.today.Location())
+	if month >= p.today.Month() {
+		return []dateOption{{start: this, span: "month", desc: spanDesc("", this, "month"), confidence: 1}}, nil
+	}
+	next := this.AddDate(1, 0, 0)
+	return []dateOption{
+		{start: next, span: "month", desc: spanDesc("next ", next, "month"), confidence: 0.6},
+		{start: this, span: "month", desc: spanDesc("earlier this year, ", this, "month"), confidence: 0.4},
+	}, nil
+}
+
+// optionalYear reads a four-digit year, optionally after a comma
+func (p *exprParser) optionalYear() (int, bool) {
+	k := 0
+	if p.peek(0).text == "," {
+		k = 1
+	}
+	if tok := p.peek(k); tok.kind == exprNumber && tok.digits == 4 && !tok.ordinal {
+		p.pos += k + 1
+		return tok.value, true
+	}
+	return 0, false
+}
+
+// parseOrdinalDay reads "the second Tuesday of March", "the last Friday of
+// the month" and "the first day of next month"
+func (p *exprParser) parseOrdinalDay() (bool, error) {
+	k := 0
+	if p.word(0) == "the" {
+		k = 1
+	}
+
+	tok := p.peek(k)
+	nth, ok := exprOrdinals[tok.text]
+	if tok.kind == exprNumber && tok.ordinal && tok.value >= 1 && tok.value <= 5 {
+		nth, ok = tok.value, true
+	}
+	if !ok {
+		return false, nil
+	}
+
+	weekday, isWeekday := exprWeekdays[p.word(k+1)]
+	if !isWeekday && p.word(k+1) != "day" {
+		return false, nil
+	}
+	if of := p.word(k + 2); of != "of" && of != "in" {
+		return false, nil
+	}
+	p.pos += k + 3
+	p.accept("the")
+
+	months, ok, err := p.spanReference()
+	if err != nil {
+		return false, err
+	}
+	if !ok || months[0].span != "month" {
+		return false, p.errorf("expected a month after '%s'", p.tokens[p.pos-1].text)
+	}
+
+	label := p.expression[tok.start:p.tokens[p.pos-1].end]
+	var options []dateOption
+	for _, month := range months {
+		day, ok := nthDayOfMonth(month.start, nth, weekday, isWeekday)
+		if !ok {
+			return false, NewInvalidTimeError(p.expression, fmt.Sprintf("%s does not exist", month.desc), nil)
+		}
+		options = append(options, dateOption{start: day, span: "day", desc: dayDesc(label, day), confidence: month.confidence})
+	}
+	return true, p.setDate(options...)
+}
+
+// nthDayOfMonth returns the nth (or last, for -1) weekday of the month
+// starting at first, or its nth or last day when isWeekday is false
+func nthDayOfMonth(first time.Time, nth int, weekday time.Weekday, isWeekday bool) (time.Time, bool) {
+	last := first.AddDate(0, 1, -1)
+	switch {
+	case !isWeekday && nth == -1:
+		return last, true
+	case !isWeekday:
+		return first.AddDate(0, 0, nth-1), nth <= last.Day()
+	case nth == -1:
+		return last.AddDate(0, 0, -((int(last.Weekday())-int(weekday))+7)%7), true
+	}
+
+	day := first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+7*(nth-1))
+	return day, day.Month() == first.Month()
+}
+
+// parseRelative reads "next week", "last month", "this Friday", "next March"
+// and "this morning"
+func (p *exprParser) parseRelative() (bool, error) {
+	var modifier string
+	switch p.word(0) {
+	case "this", "next", "last", "coming", "previous", "past":
+		modifier = p.word(0)
+	default:
+		return false, nil
+	}
+
+	next := p.word(1)
+	if _, ok := exprSpans[next]; ok {
+		options, _, err := p.spanReference()
+		if err != nil {
+			return false, err
+		}
+		return true, p.setDate(options...)
+	}
+
+	if weekday, ok := exprWeekdays[next]; ok {
+		p.pos += 2
+		return true, p.setDate(p.weekdayOptions(modifier, weekday)...)
+	}
+
+	if month, ok := exprMonths[next]; ok {
+		p.pos += 2
+		start := time.Date(p.today.Year(), month, 1, 0, 0, 0, 0, p.today.Location())
+		switch {
+		case (modifier == "next" || modifier == "coming") && month <= p.today.Month():
+			start = start.AddDate(1, 0, 0)
+		case (modifier == "last" || modifier == "previous" || modifier == "past") && month >= p.today.Month():
+			start = start.AddDate(-1, 0, 0)
+		}
+		return true, p.setDate(dateOption{start: start, span: "month", desc: spanDesc(modifier+" "+month.String(), start, "month"), confidence: 1})
+	}
+
+	if _, ok := exprDayParts[next]; ok && modifier == "this" {
+		p.pos++
+		return p.parseDayPart()
+	}
+
+	return false, nil
+}
+
+// weekdayOptions returns the readings of "this", "next", "last" or a bare
+// weekday. "Next Friday" said early in the week may mean the coming Friday or
+// the one a week later; the coming Friday is preferred.
+func (p *exprParser) weekdayOptions(modifier string, weekday time.Weekday) []dateOption {
+	name := weekday.String()
+	ahead := (int(weekday) - int(p.today.Weekday()) + 7) % 7
+
+	day := func(days int) time.Time { return p.today.AddDate(0, 0, days) }
+	option := func(days int, label string, confidence float64) dateOption {
+		return dateOption{start: day(days), span: "day", desc: dayDesc(label, day(days)), confidence: confidence}
+	}
+
+	switch modifier {
+	case "this":
+		return []dateOption{option(ahead, "this "+name, 1)}
+	case "", "next", "coming":
+		if ahead == 0 {
+			ahead = 7
+		}
+		if modifier == "" && ahead == 7 {
+			return []dateOption{option(7, name+" next week", 0.6), option(0, "today, "+name, 0.4)}
+		}
+		if modifier == "next" && sameWeek(day(ahead), p.today) {
+			return []dateOption{option(ahead, "the coming "+name, 0.6), option(ahead+7, name+" of next week", 0.4)}
+		}
+		if modifier == "" {
+			return []dateOption{option(ahead, name, 1)}
+		}
+		return []dateOption{option(ahead, "next "+name, 1)}
+	}
+
+	behind := (int(p.today.Weekday()) - int(weekday) + 7) % 7
+	if behind == 0 {
+		behind = 7
+	}
+	if sameWeek(day(-behind), p.today) {
+		return []dateOption{option(-behind, "the most recent "+name, 0.6), option(-behind-7, name+" of last week", 0.4)}
+	}
+	return []dateOption{option(-behind, "last "+name, 1)}
+}
+
+// sameWeek reports whether two days fall in the same Monday-based week
+func sameWeek(a, b time.Time) bool {
+	return spanStart(a, "week").Equal(spanStart(b, "week"))
+}
+
+// parseDayWord reads "today", "tonight", "tomorrow", "yesterday", "the day
+// after tomorrow" and "the day before yesterday"
+func (p *exprParser) parseDayWord() (bool, error) {
+	days, label := 0, p.word(0)
+	switch label {
+	case "today":
+	case "tonight":
+		p.dayPart = "night"
+	case "tomorrow", "tmrw":
+		days, label = 1, "tomorrow"
+	case "yesterday":
+		days = -1
+	case "day":
+		switch {
+		case p.word(1) == "after" && p.word(2) == "tomorrow":
+			days, label = 2, "the day after tomorrow"
+		case p.word(1) == "before" && p.word(2) == "yesterday":
+			days, label = -2, "the day before yesterday"
+		default:
+			return false, nil
+		}
+		p.pos += 2
+	default:
+		return false, nil
+	}
+	p.pos++
+
+	day := p.today.AddDate(0, 0, days)
+	return true, p.setDate(dateOption{start: day, span: "day", desc: dayDesc(label, day), confidence: 1})
+}
+
+// parseNumericDate reads "2024-03-15", "15.03.2024" and "3/15" or
+// "03/04/2024", where both the US and the European reading are offered when
+// the day and month could be swapped
+func (p *exprParser) parseNumericDate() (bool, error) {
+	first := p.peek(0)
+	sep := p.peek(1)
+	if first.kind != exprNumber || first.ordinal || sep.spaced || (sep.text != "-" && sep.text != "/" && sep.text != ".") {
+		return false, nil
+	}
+
+	var parts []int
+	k := 0
+	for {
+		tok := p.peek(k)
+		if tok.kind != exprNumber || tok.ordinal || (k > 0 && tok.spaced) {
+			break
+		}
+		parts = append(parts, tok.value)
+		if next := p.peek(k + 1); next.text != sep.text || next.spaced {
+			k++
+			break
+		}
+		k += 2
+	}
+	if len(parts) < 2 || len(parts) > 3 || (sep.text != "/" && len(parts) != 3) {
+		return false, nil
+	}
+	p.pos += k
+
+	loc := p.today.Location()
+	build := func(year, month, day int, label string, confidence float64) (dateOption, bool) {
+		if year < 100 {
+			year += 2000
+		}
+		if month < 1 || month > 12 || day < 1 || day > daysIn(year, time.Month(month)) {
+			return dateOption{}, false
+		}
+		start := time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+		return dateOption{start: start, span: "day", desc: dayDesc(label, start), confidence: confidence}, true
+	}
+
+	var options []dateOption
+	add := func(year, month, day int, label string, confidence float64) {
+		if option, ok := build(year, month, day, label, confidence); ok {
+			options = append(options, option)
+		}
+	}
+
+	switch {
+	case sep.text == "-" || (sep.text == "/" && first.digits == 4):
+		add(parts[0], parts[1], parts[2], "ISO date", 1)
+	case sep.text == ".":
+		add(parts[2], parts[1], parts[0], "day.month.year", 1)
+	default:
+		year := p.today.Year()
+		if len(parts) == 3 {
+			year = parts[2]
+		}
+		if parts[0] == parts[1] || parts[0] > 12 || parts[1] > 12 {
+			add(year, parts[0], parts[1], "month/day", 1)
+			add(year, parts[1], parts[0], "day/month", 1)
+		} else {
+			add(year, parts[0], parts[1], "month/day (US)", 0.55)
+			add(year, parts[1], parts[0], "day/month", 0.45)
+		}
+	}
+
+	if len(options) == 0 {
+		return false, NewInvalidTimeError(p.expression, fmt.Sprintf("'%s' is not a valid date", p.expression[first.start:p.tokens[p.pos-1].end]), nil)
+	}
+	return true, p.setDate(options...)
+}
+
+// parseMonthDay reads "March 12", "12th of March 2025", "the 15th" and a bare
+// month such as "March"
+func (p *exprParser) parseMonthDay() (bool, error) {
+	start := p.pos
+	var month time.Month
+	day := 0
+
+	tok := p.peek(0)
+	switch {
+	case exprMonths[tok.text] != 0:
+		month = exprMonths[tok.text]
+		if next := p.peek(1); next.kind == exprNumber && next.digits <= 2 && !p.isClock(1) {
+			day = next.value
+			p.pos += 2
+		} else {
+			options, err := p.monthSpan()
+			if err != nil {
+				return false, err
+			}
+			return true, p.setDate(options...)
+		}
+	case tok.kind == exprNumber && tok.digits <= 2 && (tok.ordinal || exprMonths[p.word(1)] != 0 || (p.word(1) == "of" && exprMonths[p.word(2)] != 0)):
+		day = tok.value
+		p.pos++
+		p.accept("of")
+		if m, ok := exprMonths[p.word(0)]; ok {
+			month = m
+			p.pos++
+		} else if !tok.ordinal {
+			p.pos = start
+			return false, nil
+		}
+	default:
+		return false, nil
+	}
+
+	loc := p.today.Location()
+	label := strings.TrimSpace(p.expression[tok.start:p.tokens[p.pos-1].end])
+
+	// "the 15th": the next 15th, or this month's when it is still to come
+	if month == 0 {
+		this := time.Date(p.today.Year(), p.today.Month(), day, 0, 0, 0, 0, loc)
+		if day < 1 || day > 31 {
+			return false, NewInvalidTimeError(p.expression, fmt.Sprintf("'%s' is not a day of the month", label), nil)
+		}
+		if day > daysIn(this.Year(), this.Month()) || this.Before(p.today) {
+			next := time.Date(p.today.Year(), p.today.Month()+1, day, 0, 0, 0, 0, loc)
+			options := []dateOption{{start: next, span: "day", desc: dayDesc("the "+label+" of next month", next), confidence: 0.6}}
+			if day <= daysIn(this.Year(), this.Month()) {
+				options = append(options, dateOption{start: this, span: "day", desc: dayDesc("the "+label+" of this month", this), confidence: 0.4})
+			} else {
+				options[0].confidence = 1
+			}
+			return true, p.setDate(options...)
+		}
+		return true, p.setDate(dateOption{start: this, span: "day", desc: dayDesc("the "+label, this), confidence: 1})
+	}
+
+	year, hasYear := p.optionalYear()
+	if !hasYear {
+		year = p.today.Year()
+	}
+	if day < 1 || day > daysIn(year, month) {
+		return false, NewInvalidTimeError(p.expression, fmt.Sprintf("%s %d does not exist", month, day), nil)
+	}
+
+	date := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if hasYear || !date.Before(p.today) {
+		return true, p.setDate(dateOption{start: date, span: "day", desc: dayDesc(label, date), confidence: 1})
+	}
+
+	// A past date without a year most likely means next year's
+	next := date.AddDate(1, 0, 0)
+	if next.Month() != month {
+		return true, p.setDate(dateOption{start: date, span: "day", desc: dayDesc(label, date), confidence: 1})
+	}
+	return true, p.setDate(
+		dateOption{start: next, span: "day", desc: dayDesc(label+" next year", next), confidence: 0.6},
+		dateOption{start: date, span: "day", desc: dayDesc(label+" this year", date), confidence: 0.4},
+	)
+}
+
+// parseWeekday reads a bare weekday such as "Friday"
+func (p *exprParser) parseWeekday() (bool, error) {
+	weekday, ok := exprWeekdays[p.word(0)]
+	if !ok {
+		return false, nil
+	}
+	p.pos++
+	return true, p.setDate(p.weekdayOptions("", weekday)...)
+}
+
+// isClock reports whether the number k tokens ahead starts a time of day
+func (p *exprParser) isClock(k int) bool {
+	next := p.peek(k + 1)
+	switch {
+	case next.text == ":" && !next.spaced:
+		return true
+	case next.text == "am" || next.text == "pm" || next.text == "o'clock" || next.text == "tonight":
+		return true
+	}
+	for _, filler := range []string{"in", "the"} {
+		if p.word(k+1) == filler {
+			k++
+		}
+	}
+	if _, ok := exprDayParts[p.word(k+1)]; ok {
+		return true
+	}
+	return p.pos+k > 0 && p.tokens[p.pos+k-1].text == "at"
+}
+
+// parseClock reads "9am", "9:30 pm", "21:00", "at 9", "noon" and "midnight".
+// An hour from 1 to 12 without am or pm is ambiguous: 7 to 11 are read as
+// morning and 1 to 6 as afternoon, with the other half of the day as an alternative.
+func (p *exprParser) parseClock() (bool, error) {
+	switch p.word(0) {
+	case "noon", "midday":
+		p.pos++
+		return true, p.setClock(clockOption{hour: 12, precision: "minute", desc: "noon", confidence: 1})
+	case "midnight":
+		p.pos++
+		return true, p.setClock(clockOption{precision: "minute", desc: "midnight", confidence: 1})
+	}
+
+	tok := p.peek(0)
+	if tok.kind != exprNumber || tok.ordinal || tok.digits > 2 || !p.isClock(0) {
+		return false, nil
+	}
+	start := tok.start
+	p.pos++
+
+	hour, minute, second := tok.value, 0, 0
+	precision := "minute"
+	padded := tok.digits == 2 && tok.text[0] == '0'
+	hasMinutes := false
+	for _, field := range []*int{&minute, &second} {
+		if colon := p.peek(0); colon.text != ":" || colon.spaced {
+			break
+		}
+		value := p.peek(1)
+		if value.kind != exprNumber || value.digits != 2 || value.spaced {
+			return false, p.errorf("expected two digits after ':'")
+		}
+		*field = value.value
+		p.pos += 2
+		if field == &second {
+			precision = "second"
+		}
+		hasMinutes = true
+	}
+
+	meridiem := ""
+	if p.accept("am", "pm") {
+		meridiem = p.tokens[p.pos-1].text
+	} else {
+		p.accept("o'clock")
+	}
+
+	label := p.expression[start:p.tokens[p.pos-1].end]
+	if minute > 59 || second > 59 {
+		return false, NewInvalidTimeError(p.expression, fmt.Sprintf("'%s' is not a valid time", label), nil)
+	}
+
+	switch {
+	case meridiem != "":
+		if hour < 1 || hour > 12 {
+			return false, NewInvalidTimeError(p.expression, fmt.Sprintf("'%s' is not a valid time", label), nil)
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+		return true, p.setClock(clockOption{hour: hour, minute: minute, second: second, precision: precision, desc: label, confidence: 1})
+
+	case hour > 23:
+		return false, NewInvalidTimeError(p.expression, fmt.Sprintf("'%s' is not a valid time", label), nil)
+
+	case hour == 0 || hour > 12 || padded || (hour == 12 && hasMinutes) || hour == 12:
+		return true, p.setClock(clockOption{hour: hour, minute: minute, second: second, precision: precision, desc: label, confidence: 1})
+	}
+
+	morning := clockOption{hour: hour, minute: minute, second: second, precision: precision, label: label, desc: label + " in the morning"}
+	afternoon := clockOption{hour: hour + 12, minute: minute, second: second, precision: precision, label: label, desc: label + " in the afternoon or evening"}
+	if hour >= 7 {
+		morning.confidence, afternoon.confidence = 0.7, 0.3
+		return true, p.setClock(morning, afternoon)
+	}
+	morning.confidence, afternoon.confidence = 0.3, 0.7
+	return true, p.setClock(afternoon, morning)
+}
+
+// parseDayPart reads "morning", "afternoon", "evening" and "night", which
+// settle am or pm for an ambiguous time or stand for a typical time of day
+func (p *exprParser) parseDayPart() (bool, error) {
+	part := p.word(0)
+	if _, ok := exprDayParts[part]; !ok {
+		return false, nil
+	}
+	if p.dayPart != "" {
+		return false, p.errorf("more than one part of the day")
+	}
+	p.pos++
+	p.dayPart = part
+	return true, nil
+}
+
+// parseYear reads a bare four-digit year, e.g. "in 2025"
+func (p *exprParser) parseYear() (bool, error) {
+	tok := p.peek(0)
+	if tok.kind != exprNumber || tok.digits != 4 || tok.ordinal {
+		return false, nil
+	}
+	p.pos++
+	return true, p.setDate(p.yearSpan(tok.value))
+}
+
+// exprReading is a fully resolved reading of an expression
+type exprReading struct {
+	t          time.Time
+	precision  string
+	parts      []string
+	confidence float64
+}
+
+// describe joins the parts of a reading and the timezone into a sentence
+func (r exprReading) describe(zone *time.Location) string {
+	desc := strings.Join(r.parts, " ")
+	if zone != nil {
+		desc += " in " + zone.String()
+	}
+	return desc
+}
+
+// readings resolves the primary reading first, followed by the readings that
+// swap in one alternative option, most likely first
+func (p *exprParser) readings() ([]exprReading, error) {
+	p.applyDayPart()
+	p.defaultDate()
+
+	primary, err := p.resolve(0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	readings := []exprReading{primary}
+	for i := 1; i < len(p.dates); i++ {
+		r, err := p.resolve(i, 0)
+		if err != nil {
+			return nil, err
+		}
+		readings = append(readings, r)
+	}
+	for i := 1; i < len(p.clocks); i++ {
+		r, err := p.resolve(0, i)
+		if err != nil {
+			return nil, err
+		}
+		readings = append(readings, r)
+	}
+
+	sort.SliceStable(readings[1:], func(i, j int) bool {
+		return readings[i+1].confidence > readings[j+1].confidence
+	})
+	return readings, nil
+}
+
+// applyDayPart settles an ambiguous time with a part of the day, or uses the
+// part's typical time when no time was given
+func (p *exprParser) applyDayPart() {
+	if p.dayPart == "" {
+		return
+	}
+	part := exprDayParts[p.dayPart]
+
+	if p.clocks == nil {
+		p.clocks = []clockOption{{hour: part.hour, precision: "hour", desc: fmt.Sprintf("%02d:00 (%s)", part.hour, p.dayPart), confidence: 0.8}}
+		return
+	}
+
+	for _, c := range p.clocks {
+		if (c.hour >= 12) == part.pm {
+			c.desc = fmt.Sprintf("%s in the %s (%02d:%02d)", c.label, p.dayPart, c.hour, c.minute)
+			c.confidence = 1
+			p.clocks = []clockOption{c}
+			return
+		}
+	}
+}
+
+// defaultDate picks the day for a time without a date: today, or tomorrow
+// when the time has already passed today
+func (p *exprParser) defaultDate() {
+	if p.dates != nil || p.now || p.offset != nil || p.clocks == nil {
+		return
+	}
+
+	c := p.clocks[0]
+	today := dateOption{start: p.today, span: "day", desc: dayDesc("today", p.today), confidence: 1}
+	if !atClock(p.today, c).Before(p.ref) {
+		p.dates = []dateOption{today}
+		return
+	}
+
+	tomorrow := p.today.AddDate(0, 0, 1)
+	today.confidence = 0.4
+	p.dates = []dateOption{
+		{start: tomorrow, span: "day", desc: dayDesc("tomorrow", tomorrow), confidence: 0.6},
+		today,
+	}
+}
+
+// resolve computes the reading using the given date and clock options
+func (p *exprParser) resolve(dateIndex, clockIndex int) (exprReading, error) {
+	r := exprReading{confidence: 1}
+
+	var date *dateOption
+	if p.dates != nil {
+		date = &p.dates[dateIndex]
+		r.confidence *= date.confidence
+	}
+	var clock *clockOption
+	if p.clocks != nil {
+		clock = &p.clocks[clockIndex]
+		r.confidence *= clock.confidence
+	}
+
+	switch {
+	case p.boundary == "end":
+		r.t = spanEnd(date.start, date.span).Add(-time.Second)
+		r.precision = "second"
+		r.parts = append(r.parts, "end of "+date.desc)
+	case p.boundary == "start":
+		r.t = date.start
+		r.precision = "day"
+		r.parts = append(r.parts, "start of "+date.desc)
+
+	case p.offset != nil:
+		r.confidence *= p.offset.confidence
+		base := p.ref
+		if p.offset.anchored {
+			base = date.start
+		}
+		r.t = addMonthsClamped(base, p.offset.duration.months).AddDate(0, 0, p.offset.duration.days).Add(p.offset.duration.clock)
+		r.precision = p.offset.precision
+
+		r.parts = append(r.parts, p.offset.desc)
+		switch {
+		case p.offset.anchored:
+			r.parts = append(r.parts, fmt.Sprintf("%s, which is %s", date.desc, r.t.Format("Mon 2006-01-02")))
+		default:
+			r.parts = append(r.parts, fmt.Sprintf("(%s)", r.t.Format("Mon 2006-01-02")))
+		}
+
+		if clock != nil {
+			r.t = atClock(r.t, *clock)
+			r.precision = clock.precision
+			r.parts = append(r.parts, "at "+clock.desc)
+		}
+
+	case p.now:
+		r.t = p.ref
+		r.precision = "second"
+		r.parts = append(r.parts, "now")
+
+	default:
+		r.t = date.start
+		r.precision = date.span
+		r.parts = append(r.parts, date.desc)
+		if clock != nil {
+			if date.span != "day" {
+				return r, NewInvalidTimeError(p.expression, fmt.Sprintf("a time of day needs a single day, not a %s", date.span), nil)
+			}
+			r.t = atClock(date.start, *clock)
+			r.precision = clock.precision
+			r.parts = append(r.parts, "at "+clock.desc)
+		}
+	}
+
+	if r.precision == "" || indexOf(exprPrecisions, r.precision) < 0 {
+		r.precision = "day"
+	}
+	r.confidence = float64(int(r.confidence*100+0.5)) / 100
+	return r, nil
+}
+
+// atClock returns the day at a time of day
+func atClock(day time.Time, c clockOption) time.Time {
+	y, m, d := day.Date()
+	return localTime(y, m, d, c.hour, c.minute, c.second, day.Location())
+}
+
+// localTime returns a wall-clock time in loc. A time skipped by a daylight
+// saving gap is moved forward by the length of the gap, and a time repeated by
+// an overlap resolves to its first occurrence.
+func localTime(year int, month time.Month, day, hour, minute, second int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, hour, minute, second, 0, loc)
+	wall := time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+
+	shown := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	if gap := wall.Sub(shown); gap > 0 {
+		// Read with the offset after the gap; use the one before it
+		return t.Add(gap)
+	}
+	if !shown.Equal(wall) {
+		return t
+	}
+
+	if start, _ := t.ZoneBounds(); !start.IsZero() {
+		_, offset := start.Add(-time.Second).Zone()
+		if earlier := wall.Add(-time.Duration(offset) * time.Second).In(loc); earlier.Before(t) && sameWallClock(earlier, wall) {
+			return earlier
+		}
+	}
+	return t
+}
+
+// spanStart returns the first day of the span of the given kind containing day
+func spanStart(day time.Time, span string) time.Time {
+	y, m, d := day.Date()
+	loc := day.Location()
+	switch span {
+	case "week":
+		return time.Date(y, m, d-(int(day.Weekday())+6)%7, 0, 0, 0, 0, loc)
+	case "month":
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc)
+	case "quarter":
+		return time.Date(y, m-(m-1)%3, 1, 0, 0, 0, 0, loc)
+	case "year":
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, loc)
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// spanEnd returns the first day after the span starting at start
+func spanEnd(start time.Time, span string) time.Time {
+	switch span {
+	case "week":
+		return start.AddDate(0, 0, 7)
+	case "month":
+		return start.AddDate(0, 1, 0)
+	case "quarter":
+		return start.AddDate(0, 3, 0)
+	case "year":
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// dayDesc describes a day reading, e.g. "next Friday (Fri 2024-03-22)"
+func dayDesc(label string, day time.Time) string {
+	return fmt.Sprintf("%s (%s)", label, day.Format("Mon 2006-01-02"))
+}
+
+// spanDesc describes a span reading, e.g. "next month (2024-04)"
+func spanDesc(label string, start time.Time, span string) string {
+	var value string
+	switch span {
+	case "week":
+		value = "week of " + start.Format("Mon 2006-01-02")
+	case "month":
+		value = start.Format("2006-01")
+	case "quarter":
+		value = fmt.Sprintf("Q%d %d", (int(start.Month())-1)/3+1, start.Year())
+	case "year":
+		value = strconv.Itoa(start.Year())
+	default:
+		value = start.Format("Mon 2006-01-02")
+	}
+
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return value
+	}
+	return fmt.Sprintf("%s (%s)", label, value)
+}
+
+// indexOf returns the index of s in list, or -1
+func indexOf(list []string, s string) int {
+	for i, item := range list {
+		if item == s {
+			return i
+		}
+	}
+	return -1
+}
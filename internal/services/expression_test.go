@@ -0,0 +1,131 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTimeService_ResolveDateExpression(t *testing.T) {
+	ts := NewTimeService()
+	// Thursday 14 March 2024, 10:00 in Los Angeles
+	reference := "2024-03-14T10:00:00-07:00"
+	timezone := "America/Los_Angeles"
+
+	tests := []struct {
+		name         string
+		expression   string
+		expected     string
+		zone         string
+		precision    string
+		confidence   float64
+		alternatives []string
+		desc         string
+		wantErr      bool
+		errCode      int
+	}{
+		{name: "next weekday with time and zone", expression: "next Friday at 9am Pacific", expected: "2024-03-15T09:00:00-07:00", zone: "America/Los_Angeles", precision: "minute", confidence: 0.6, alternatives: []string{"2024-03-22T09:00:00-07:00"}},
+		{name: "next weekday in the following week", expression: "next Monday", expected: "2024-03-18T00:00:00-07:00", precision: "day", confidence: 1},
+		{name: "end of month", expression: "end of month", expected: "2024-03-31T23:59:59-07:00", precision: "second", confidence: 1},
+		{name: "end of next quarter", expression: "end of the next quarter", expected: "2024-06-30T23:59:59-07:00", precision: "second", confidence: 1},
+		{name: "start of next week", expression: "start of next week", expected: "2024-03-18T00:00:00-07:00", precision: "day", confidence: 1},
+		{name: "abbreviated boundary", expression: "EOY", expected: "2024-12-31T23:59:59-08:00", precision: "second", confidence: 1},
+		{name: "offset in words", expression: "in two weeks", expected: "2024-03-28T10:00:00-07:00", precision: "day", confidence: 1},
+		{name: "offset in compound words", expression: "in twenty five minutes", expected: "2024-03-14T10:25:00-07:00", precision: "second", confidence: 1},
+		{name: "offset in hyphenated words", expression: "forty-five minutes ago", expected: "2024-03-14T09:15:00-07:00", precision: "second", confidence: 1},
+		{name: "offset ago", expression: "3 hours ago", expected: "2024-03-14T07:00:00-07:00", precision: "second", confidence: 1},
+		{name: "offset from anchor", expression: "a week from tomorrow at noon", expected: "2024-03-22T12:00:00-07:00", precision: "minute", confidence: 1},
+		{name: "offset from anchor shows the result", expression: "a week from tomorrow", expected: "2024-03-22T00:00:00-07:00", precision: "day", confidence: 1, desc: "1 week after tomorrow (Fri 2024-03-15), which is Fri 2024-03-22"},
+		{name: "offset before date", expression: "10 days before April 1", expected: "2024-03-22T00:00:00-07:00", precision: "day", confidence: 1},
+		{name: "ordinal weekday", expression: "the second Tuesday of March", expected: "2024-03-12T00:00:00-07:00", precision: "day", confidence: 1},
+		{name: "ordinal weekday next year", expression: "second Tuesday of January", expected: "2025-01-14T00:00:00-08:00", precision: "day", confidence: 0.6, alternatives: []string{"2024-01-09T00:00:00-08:00"}},
+		{name: "last weekday of month", expression: "the last Friday of the month", expected: "2024-03-29T00:00:00-07:00", precision: "day", confidence: 1},
+		{name: "last day of next month", expression: "last day of next month", expected: "2024-04-30T00:00:00-07:00", precision: "day", confidence: 1},
+		{name: "past month day", expression: "March 12", expected: "2025-03-12T00:00:00-07:00", precision: "day", confidence: 0.6, alternatives: []string{"2024-03-12T00:00:00-07:00"}},
+		{name: "day of month with year", expression: "12th of March, 2025", expected: "2025-03-12T00:00:00-07:00", precision: "day", confidence: 1},
+		{name: "iso date and time", expression: "2024-03-20 14:30", expected: "2024-03-20T14:30:00-07:00", precision: "minute", confidence: 1},
+		{name: "us or european date", expression: "4/5", expected: "2024-04-05T00:00:00-07:00", precision: "day", confidence: 0.55, alternatives: []string{"2024-05-04T00:00:00-07:00"}},
+		{name: "european date", expression: "25/12", expected: "2024-12-25T00:00:00-08:00", precision: "day", confidence: 1},
+		{name: "hour without meridiem", expression: "tomorrow at 5", expected: "2024-03-15T17:00:00-07:00", precision: "minute", confidence: 0.7, alternatives: []string{"2024-03-15T05:00:00-07:00"}},
+		{name: "part of day settles meridiem", expression: "Friday 8 in the evening", expected: "2024-03-15T20:00:00-07:00", precision: "minute", confidence: 1},
+		{name: "part of day", expression: "tomorrow morning", expected: "2024-03-15T09:00:00-07:00", precision: "hour", confidence: 0.8},
+		{name: "passed time today", expression: "9:30", expected: "2024-03-15T09:30:00-07:00", precision: "minute", confidence: 0.42},
+		{name: "city zone", expression: "tomorrow 9:30am Tokyo time", expected: "2024-03-16T09:30:00+09:00", zone: "Asia/Tokyo", precision: "minute", confidence: 1},
+		{name: "offset zone", expression: "noon tomorrow UTC+2", expected: "2024-03-15T12:00:00+02:00", precision: "minute", confidence: 1},
+		{name: "dst gap", expression: "March 10 2024 2:30am", expected: "2024-03-10T03:30:00-07:00", precision: "minute", confidence: 1},
+		{name: "period", expression: "next month", expected: "2024-04-01T00:00:00-07:00", precision: "month", confidence: 1},
+		{name: "now", expression: "now", expected: "2024-03-14T10:00:00-07:00", precision: "second", confidence: 1},
+		{name: "ambiguous abbreviation", expression: "tomorrow at 9am IST", wantErr: true, errCode: ErrCodeAmbiguousZone},
+		{name: "unknown word", expression: "next blursday", wantErr: true, errCode: ErrCodeInvalidTime},
+		{name: "invalid date", expression: "February 30", wantErr: true, errCode: ErrCodeInvalidTime},
+		{name: "fifth weekday that does not exist", expression: "fifth Monday of February 2024", wantErr: true, errCode: ErrCodeInvalidTime},
+		{name: "time with a month", expression: "next month at 9am", wantErr: true, errCode: ErrCodeInvalidTime},
+		{name: "empty", expression: "  ", wantErr: true, errCode: ErrCodeInvalidTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.ResolveDateExpression(tt.expression, reference, timezone)
+
+			if tt.wantErr {
+				var tsErr *TimeServiceError
+				if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+					t.Errorf("Expected error code %d, got %+v, %v", tt.errCode, result, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Result.Time != tt.expected {
+				t.Errorf("Expected %s, got %s (%s)", tt.expected, result.Result.Time, result.Interpretation)
+			}
+			if tt.desc != "" && result.Interpretation != tt.desc {
+				t.Errorf("Expected interpretation %q, got %q", tt.desc, result.Interpretation)
+			}
+			if tt.zone != "" && result.Result.Timezone != tt.zone {
+				t.Errorf("Expected timezone %s, got %s", tt.zone, result.Result.Timezone)
+			}
+			if result.Precision != tt.precision {
+				t.Errorf("Expected precision %s, got %s", tt.precision, result.Precision)
+			}
+			if result.Confidence != tt.confidence {
+				t.Errorf("Expected confidence %.2f, got %.2f", tt.confidence, result.Confidence)
+			}
+			if tt.alternatives != nil {
+				if len(result.Alternatives) != len(tt.alternatives) {
+					t.Fatalf("Expected %d alternatives, got %+v", len(tt.alternatives), result.Alternatives)
+				}
+				for i, alt := range tt.alternatives {
+					if result.Alternatives[i].Result.Time != alt {
+						t.Errorf("Expected alternative %s, got %s", alt, result.Alternatives[i].Result.Time)
+					}
+				}
+			}
+			if result.Ambiguous != (len(result.Alternatives) > 0) {
+				t.Errorf("Ambiguous is %v with %d alternatives", result.Ambiguous, len(result.Alternatives))
+			}
+		})
+	}
+}
+
+func TestLexExpression(t *testing.T) {
+	tokens := lexExpression("Next Fri, 9:30 p.m. 2nd")
+
+	expected := []string{"next", "fri", ",", "9", ":", "30", "pm", "2"}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %+v", len(expected), tokens)
+	}
+	for i, text := range expected {
+		if tokens[i].text != text {
+			t.Errorf("Token %d: expected %q, got %q", i, text, tokens[i].text)
+		}
+	}
+	if !tokens[7].ordinal || tokens[7].value != 2 {
+		t.Errorf("Expected ordinal 2, got %+v", tokens[7])
+	}
+	if tokens[4].spaced || !tokens[3].spaced {
+		t.Errorf("Unexpected spacing: %+v", tokens[3:5])
+	}
+}
@@ -0,0 +1,366 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Format dialects accepted for format strings
+const (
+	DialectDefault  = "default"
+	DialectGo       = "go"
+	DialectStrftime = "strftime"
+	DialectJava     = "java"
+	DialectMoment   = "moment"
+)
+
+// formatDialects compiles format strings of each dialect. ICU and CLDR
+// patterns share the Java pattern letters.
+var formatDialects = map[string]func(format string) (*compiledFormat, error){
+	DialectDefault:  compileDefault,
+	DialectGo:       compileGo,
+	DialectStrftime: compileStrftime,
+	DialectJava:     compileJava,
+	"icu":           compileJava,
+	"cldr":          compileJava,
+	DialectMoment:   compileMoment,
+}
+
+// layoutProbe is a time whose formatting differs from every Go layout element,
+// used to detect literal text that Go would read as part of a layout
+var layoutProbe = time.Date(1999, 12, 31, 11, 59, 58, 123456789, time.FixedZone("XYZ", 3660))
+
+// formatToken is one piece of a compiled format: literal text, a Go layout
+// element, a fraction of a second, or a field rendered by a function when Go
+// layouts have no equivalent. Month and weekday names and AM/PM markers also
+// record their kind of name so they can be rendered in other languages;
+// ordinals are marked english as they have no translation.
+type formatToken struct {
+	literal  string
+	layout   string
+	fraction int
+	render   func(t time.Time) string
+	names    nameKind
+	english  bool
+	source   string
+}
+
+// compiledFormat is a format string translated into tokens. It is immutable
+// once compiled, so cached formats are shared between requests.
+type compiledFormat struct {
+	source string
+	tokens []formatToken
+}
+
+// maxCachedFormats bounds the compiled format cache; it is emptied when full
+const maxCachedFormats = 512
+
+// formatCacheKey identifies a compiled format
+type formatCacheKey struct {
+	dialect string
+	format  string
+}
+
+var (
+	formatCacheMu sync.Mutex
+	formatCache   = make(map[formatCacheKey]*compiledFormat)
+)
+
+// compileFormat compiles a format string in the given dialect, reusing
+// previously compiled formats. An empty dialect selects strftime when the
+// format contains '%' and the default pattern language otherwise.
+func compileFormat(format, dialect string) (*compiledFormat, error) {
+	dialect = strings.ToLower(strings.TrimSpace(dialect))
+	if dialect == "" {
+		dialect = DialectDefault
+		if strings.Contains(format, "%") {
+			dialect = DialectStrftime
+		}
+	}
+
+	key := formatCacheKey{dialect, format}
+	formatCacheMu.Lock()
+	compiled, ok := formatCache[key]
+	formatCacheMu.Unlock()
+	if ok {
+		return compiled, nil
+	}
+
+	compile, ok := formatDialects[dialect]
+	if !ok {
+		return nil, NewInvalidFormatError(format, fmt.Sprintf("unknown format dialect '%s': expected default, go, strftime, java, icu or moment", dialect))
+	}
+
+	compiled, err := compile(format)
+	if err != nil {
+		return nil, err
+	}
+
+	formatCacheMu.Lock()
+	if len(formatCache) >= maxCachedFormats {
+		clear(formatCache)
+	}
+	formatCache[key] = compiled
+	formatCacheMu.Unlock()
+
+	return compiled, nil
+}
+
+// Format renders a time using the compiled format, with English names
+func (f *compiledFormat) Format(t time.Time) string {
+	return f.FormatLocale(t, nil)
+}
+
+// FormatLocale renders a time using the compiled format, taking month and
+// weekday names and AM/PM markers from a locale. A nil locale renders English.
+func (f *compiledFormat) FormatLocale(t time.Time, locale *localeData) string {
+	var b strings.Builder
+	for _, tok := range f.tokens {
+		switch {
+		case tok.names != namesNone && locale != nil:
+			b.WriteString(locale.name(tok.names, t))
+		case tok.render != nil:
+			b.WriteString(tok.render(t))
+		case tok.fraction > 0:
+			b.WriteString(fractionDigits(t, tok.fraction))
+		case tok.layout != "":
+			b.WriteString(t.Format(tok.layout))
+		default:
+			b.WriteString(tok.literal)
+		}
+	}
+	return b.String()
+}
+
+// checkLocale fails when the format has tokens that only render in English,
+// such as ordinals, and the locale is another language
+func (f *compiledFormat) checkLocale(locale *localeData) error {
+	if locale == nil {
+		return nil
+	}
+	if language, _, _ := strings.Cut(locale.Tag, "-"); language == "en" {
+		return nil
+	}
+	for _, tok := range f.tokens {
+		if tok.english {
+			return NewInvalidFormatError(f.source, fmt.Sprintf("'%s' renders English ordinals and cannot be used with locale '%s'", tok.source, locale.Tag))
+		}
+	}
+	return nil
+}
+
+// Layout returns the equivalent Go layout for parsing. It fails when the format
+// contains fields Go cannot parse, such as week numbers, or literal text that
+// Go would read as a layout element.
+func (f *compiledFormat) Layout() (string, error) {
+	var b strings.Builder
+	for _, tok := range f.tokens {
+		switch {
+		case tok.render != nil:
+			return "", NewInvalidFormatError(f.source, fmt.Sprintf("'%s' can be formatted but not parsed", tok.source))
+		case tok.fraction > 9:
+			return "", NewInvalidFormatError(f.source, fmt.Sprintf("'%s' is finer than nanoseconds and cannot be parsed", tok.source))
+		case tok.fraction > 0:
+			// Go fractions must directly follow a '.' or ',' separator
+			if s := b.String(); !strings.HasSuffix(s, ".") && !strings.HasSuffix(s, ",") {
+				return "", NewInvalidFormatError(f.source, fmt.Sprintf("'%s' can only be parsed after a '.' or ','", tok.source))
+			}
+			b.WriteString(strings.Repeat("0", tok.fraction))
+		case tok.layout != "":
+			b.WriteString(tok.layout)
+		default:
+			if layoutProbe.Format(tok.literal) != tok.literal {
+				return "", NewInvalidFormatError(f.source, fmt.Sprintf("literal text '%s' cannot be used for parsing", tok.literal))
+			}
+			b.WriteString(tok.literal)
+		}
+	}
+	return b.String(), nil
+}
+
+// formatBuilder accumulates tokens, merging adjacent literal text
+type formatBuilder struct {
+	f       *compiledFormat
+	literal strings.Builder
+}
+
+func newFormatBuilder(source string) *formatBuilder {
+	return &formatBuilder{f: &compiledFormat{source: source}}
+}
+
+// text appends literal text
+func (b *formatBuilder) text(s string) {
+	b.literal.WriteString(s)
+}
+
+// add appends a field token
+func (b *formatBuilder) add(tok formatToken) {
+	b.flush()
+	b.f.tokens = append(b.f.tokens, tok)
+}
+
+// append appends a token, merging literal text into the current literal run
+func (b *formatBuilder) append(tok formatToken) {
+	if tok.layout == "" && tok.render == nil && tok.fraction == 0 {
+		b.text(tok.literal)
+		return
+	}
+	b.add(tok)
+}
+
+// flush ends the current literal run
+func (b *formatBuilder) flush() {
+	if b.literal.Len() > 0 {
+		b.f.tokens = append(b.f.tokens, formatToken{literal: b.literal.String()})
+		b.literal.Reset()
+	}
+}
+
+// build returns the compiled format
+func (b *formatBuilder) build() *compiledFormat {
+	b.flush()
+	return b.f
+}
+
+// compileGo accepts a native Go layout such as "2006-01-02 15:04"
+func compileGo(format string) (*compiledFormat, error) {
+	return &compiledFormat{source: format, tokens: []formatToken{{layout: format, source: format}}}, nil
+}
+
+// numericField is a numeric date or time field with its Go layouts, if any
+type numericField struct {
+	value  func(t time.Time) int
+	width  int
+	layout string // zero-padded layout
+	short  string // unpadded layout
+	spaced string // space-padded layout
+}
+
+// Numeric fields shared by the dialects
+var (
+	fieldYear      = numericField{value: func(t time.Time) int { return t.Year() }, width: 4, layout: "2006"}
+	fieldYear2     = numericField{value: func(t time.Time) int { return t.Year() % 100 }, width: 2, layout: "06"}
+	fieldCentury   = numericField{value: func(t time.Time) int { return t.Year() / 100 }, width: 2}
+	fieldMonth     = numericField{value: func(t time.Time) int { return int(t.Month()) }, width: 2, layout: "01", short: "1"}
+	fieldDay       = numericField{value: func(t time.Time) int { return t.Day() }, width: 2, layout: "02", short: "2", spaced: "_2"}
+	fieldYearDay   = numericField{value: func(t time.Time) int { return t.YearDay() }, width: 3, layout: "002", spaced: "__2"}
+	fieldHour      = numericField{value: func(t time.Time) int { return t.Hour() }, width: 2, layout: "15"}
+	fieldHour12    = numericField{value: func(t time.Time) int { return (t.Hour()+11)%12 + 1 }, width: 2, layout: "03", short: "3"}
+	fieldHour24    = numericField{value: func(t time.Time) int { return (t.Hour()+23)%24 + 1 }, width: 2}
+	fieldHour11    = numericField{value: func(t time.Time) int { return t.Hour() % 12 }, width: 2}
+	fieldMinute    = numericField{value: func(t time.Time) int { return t.Minute() }, width: 2, layout: "04", short: "4"}
+	fieldSecond    = numericField{value: func(t time.Time) int { return t.Second() }, width: 2, layout: "05", short: "5"}
+	fieldQuarter   = numericField{value: func(t time.Time) int { return (int(t.Month())-1)/3 + 1 }, width: 1}
+	fieldWeekday   = numericField{value: func(t time.Time) int { return int(t.Weekday()) }, width: 1}
+	fieldISODay    = numericField{value: func(t time.Time) int { return (int(t.Weekday())+6)%7 + 1 }, width: 1}
+	fieldISOWeek   = numericField{value: func(t time.Time) int { _, w := t.ISOWeek(); return w }, width: 2}
+	fieldISOYear   = numericField{value: func(t time.Time) int { y, _ := t.ISOWeek(); return y }, width: 4}
+	fieldISOYear2  = numericField{value: func(t time.Time) int { y, _ := t.ISOWeek(); return y % 100 }, width: 2}
+	fieldSundayWk  = numericField{value: func(t time.Time) int { return (t.YearDay() + 6 - int(t.Weekday())) / 7 }, width: 2}
+	fieldMondayWk  = numericField{value: func(t time.Time) int { return (t.YearDay() + 6 - (int(t.Weekday())+6)%7) / 7 }, width: 2}
+	fieldMonthWeek = numericField{value: func(t time.Time) int { return (t.Day()+int(t.AddDate(0, 0, 1-t.Day()).Weekday())-1)/7 + 1 }, width: 1}
+	fieldUnix      = numericField{value: func(t time.Time) int { return int(t.Unix()) }, width: 1}
+	fieldUnixMilli = numericField{value: func(t time.Time) int { return int(t.UnixMilli()) }, width: 1}
+	fieldDayMilli  = numericField{value: func(t time.Time) int { return (t.Hour()*3600+t.Minute()*60+t.Second())*1000 + t.Nanosecond()/1e6 }, width: 1}
+	fieldNanos     = numericField{value: func(t time.Time) int { return t.Nanosecond() }, width: 1}
+)
+
+// token renders the field with '0' (zero), '-' (no) or '_' (space) padding,
+// preferring Go layouts so the format remains parseable
+func (f numericField) token(source string, pad byte) formatToken {
+	return f.tokenWidth(source, pad, f.width)
+}
+
+// tokenWidth is token with an explicit minimum width for zero padding
+func (f numericField) tokenWidth(source string, pad byte, width int) formatToken {
+	switch {
+	case pad == '0' && width == f.width && f.layout != "":
+		return formatToken{layout: f.layout, source: source}
+	case pad == '-' && f.short != "":
+		return formatToken{layout: f.short, source: source}
+	case pad == '_' && f.spaced != "":
+		return formatToken{layout: f.spaced, source: source}
+	}
+
+	value := f.value
+	return formatToken{source: source, render: func(t time.Time) string {
+		n := value(t)
+		switch pad {
+		case '-':
+			return strconv.Itoa(n)
+		case '_':
+			return fmt.Sprintf("%*d", width, n)
+		}
+		return fmt.Sprintf("%0*d", width, n)
+	}}
+}
+
+// textToken renders a field using a Go layout, e.g. "Jan" or "Monday"
+func textToken(source, layout string) formatToken {
+	return formatToken{layout: layout, source: source}
+}
+
+// nameToken renders a month or weekday name or an AM/PM marker, using a Go
+// layout for English and the locale's names otherwise
+func nameToken(source, layout string, kind nameKind) formatToken {
+	return formatToken{layout: layout, names: kind, source: source}
+}
+
+// renderToken renders a field Go layouts cannot express
+func renderToken(source string, render func(t time.Time) string) formatToken {
+	return formatToken{source: source, render: render}
+}
+
+// fractionDigits returns the first n digits of the fractional second
+func fractionDigits(t time.Time, n int) string {
+	digits := fmt.Sprintf("%09d", t.Nanosecond())
+	if n <= 9 {
+		return digits[:n]
+	}
+	return digits + strings.Repeat("0", n-9)
+}
+
+// ordinal returns a number with its English ordinal suffix, e.g. "2nd"
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// gmtOffset renders an offset as "GMT", "GMT+5" or "GMT+05:30" (long form)
+func gmtOffset(t time.Time, long bool) string {
+	_, offset := t.Zone()
+	if offset == 0 {
+		return "GMT"
+	}
+
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	hours, minutes := offset/3600, offset%3600/60
+
+	switch {
+	case long:
+		return fmt.Sprintf("GMT%c%02d:%02d", sign, hours, minutes)
+	case minutes != 0:
+		return fmt.Sprintf("GMT%c%d:%02d", sign, hours, minutes)
+	}
+	return fmt.Sprintf("GMT%c%d", sign, hours)
+}
+
+// unknownTokenError reports an unknown format token at a 1-based position
+func unknownTokenError(format, token string, pos int) error {
+	return NewInvalidFormatError(format, fmt.Sprintf("unknown token '%s' at position %d", token, pos+1))
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultTokens are the tokens of the default pattern language. A token is a
+// run of one repeated letter, so lookups never depend on matching order.
+var defaultTokens = map[string]formatToken{
+	"YYYY": textToken("YYYY", "2006"),
+	"yyyy": textToken("yyyy", "2006"),
+	"YY":   textToken("YY", "06"),
+	"yy":   textToken("yy", "06"),
+	"MMMM": nameToken("MMMM", "January", namesMonth),
+	"MMM":  nameToken("MMM", "Jan", namesMonthAbbr),
+	"MM":   textToken("MM", "01"),
+	"M":    textToken("M", "1"),
+	"DD":   textToken("DD", "02"),
+	"dd":   textToken("dd", "02"),
+	"D":    textToken("D", "2"),
+	"d":    textToken("d", "2"),
+	"dddd": nameToken("dddd", "Monday", namesWeekday),
+	"ddd":  nameToken("ddd", "Mon", namesWeekdayAbbr),
+	"HH":   textToken("HH", "15"),
+	"H":    fieldHour.token("H", '-'),
+	"hh":   textToken("hh", "03"),
+	"h":    textToken("h", "3"),
+	"mm":   textToken("mm", "04"),
+	"m":    textToken("m", "4"),
+	"ss":   textToken("ss", "05"),
+	"s":    textToken("s", "5"),
+	"A":    nameToken("A", "PM", namesDayPeriod),
+	"a":    nameToken("a", "pm", namesDayPeriodLower),
+	"ZZ":   textToken("ZZ", "-0700"),
+	"Z":    textToken("Z", "Z07:00"),
+	"z":    textToken("z", "MST"),
+}
+
+// compileDefault compiles the default pattern language, e.g. "YYYY-MM-DD HH:mm:ss".
+//
+// Every ASCII letter belongs to a token except 'T', which is kept as the ISO
+// 8601 date/time separator. Other literal text must be escaped with square
+// brackets ("[Day] DD") or single quotes ("'Day' DD"), so that words such as
+// "Day" or "Month" are never read as tokens.
+func compileDefault(format string) (*compiledFormat, error) {
+	b := newFormatBuilder(format)
+
+	for i := 0; i < len(format); {
+		c := format[i]
+
+		switch {
+		case c == '[':
+			end := strings.IndexByte(format[i:], ']')
+			if end < 0 {
+				return nil, NewInvalidFormatError(format, fmt.Sprintf("unterminated '[' at position %d", i+1))
+			}
+			b.text(format[i+1 : i+end])
+			i += end + 1
+
+		case c == '\'':
+			if strings.HasPrefix(format[i:], "''") {
+				b.text("'")
+				i += 2
+				continue
+			}
+			end, text, ok := quotedLiteral(format, i)
+			if !ok {
+				return nil, NewInvalidFormatError(format, fmt.Sprintf("unterminated quote at position %d", i+1))
+			}
+			b.text(text)
+			i = end
+
+		case c == 'T':
+			b.text("T")
+			i++
+
+		case isASCIILetter(c):
+			n := 1
+			for i+n < len(format) && format[i+n] == c {
+				n++
+			}
+			source := format[i : i+n]
+
+			// Fractional seconds take any number of 'S', e.g. "SSS" for milliseconds
+			if c == 'S' {
+				b.add(formatToken{fraction: n, source: source})
+			} else if tok, ok := defaultTokens[source]; ok {
+				b.add(tok)
+			} else {
+				return nil, unknownTokenError(format, source, i)
+			}
+			i += n
+
+		default:
+			b.text(format[i : i+1])
+			i++
+		}
+	}
+
+	return b.build(), nil
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// compileJava compiles a Java DateTimeFormatter / ICU pattern such as
+// "yyyy-MM-dd'T'HH:mm" or "EEE, d MMMM yyyy". Every ASCII letter is a pattern
+// letter, text in single quotes is literal and a doubled quote is a literal quote.
+func compileJava(format string) (*compiledFormat, error) {
+	b := newFormatBuilder(format)
+
+	for i := 0; i < len(format); {
+		c := format[i]
+
+		switch {
+		case c == '\'':
+			if strings.HasPrefix(format[i:], "''") {
+				b.text("'")
+				i += 2
+				continue
+			}
+
+			end, text, ok := quotedLiteral(format, i)
+			if !ok {
+				return nil, NewInvalidFormatError(format, fmt.Sprintf("unterminated quote at position %d", i+1))
+			}
+			b.text(text)
+			i = end
+
+		case isASCIILetter(c):
+			n := 1
+			for i+n < len(format) && format[i+n] == c {
+				n++
+			}
+
+			source := format[i : i+n]
+			tok, ok := javaToken(source, c, n)
+			if !ok {
+				return nil, unknownTokenError(format, source, i)
+			}
+			b.add(tok)
+			i += n
+
+		default:
+			b.text(format[i : i+1])
+			i++
+		}
+	}
+
+	return b.build(), nil
+}
+
+// quotedLiteral reads the quoted text starting at format[start], where a
+// doubled quote inside the quotes is an escaped quote. It returns the index
+// after the closing quote.
+func quotedLiteral(format string, start int) (int, string, bool) {
+	var text strings.Builder
+	for i := start + 1; i < len(format); i++ {
+		if format[i] != '\'' {
+			text.WriteByte(format[i])
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '\'' {
+			text.WriteByte('\'')
+			i++
+			continue
+		}
+		return i + 1, text.String(), true
+	}
+	return 0, "", false
+}
+
+// isASCIILetter reports whether c is an ASCII letter
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// numericByCount renders a numeric field padded to the letter count, unpadded for a single letter
+func numericByCount(field numericField, source string, n int) formatToken {
+	if n == 1 {
+		return field.token(source, '-')
+	}
+	return field.tokenWidth(source, '0', n)
+}
+
+// javaToken returns the token for a run of n pattern letters c
+func javaToken(source string, c byte, n int) (formatToken, bool) {
+	switch c {
+	case 'G':
+		if n == 4 {
+			return renderToken(source, func(t time.Time) string { return eraName(t, true) }), true
+		}
+		return renderToken(source, func(t time.Time) string { return eraName(t, false) }), true
+	case 'y', 'u':
+		switch n {
+		case 1:
+			return fieldYear.token(source, '-'), true
+		case 2:
+			return fieldYear2.token(source, '0'), true
+		}
+		return fieldYear.tokenWidth(source, '0', max(n, 4)), true
+	case 'Y':
+		if n == 2 {
+			return fieldISOYear2.token(source, '0'), true
+		}
+		return numericByCount(fieldISOYear, source, n), true
+	case 'M', 'L':
+		switch n {
+		case 1, 2:
+			return numericByCount(fieldMonth, source, n), true
+		case 3:
+			if c == 'L' {
+				return nameToken(source, "Jan", namesMonthStandaloneAbbr), true
+			}
+			return nameToken(source, "Jan", namesMonthAbbr), true
+		case 4:
+			if c == 'L' {
+				return nameToken(source, "January", namesMonthStandalone), true
+			}
+			return nameToken(source, "January", namesMonth), true
+		case 5:
+			return renderToken(source, func(t time.Time) string { return t.Month().String()[:1] }), true
+		}
+	case 'Q', 'q':
+		switch n {
+		case 1, 2:
+			return numericByCount(fieldQuarter, source, n), true
+		case 3:
+			return renderToken(source, func(t time.Time) string { return fmt.Sprintf("Q%d", fieldQuarter.value(t)) }), true
+		case 4:
+			tok := renderToken(source, func(t time.Time) string { return ordinal(fieldQuarter.value(t)) + " quarter" })
+			tok.english = true
+			return tok, true
+		}
+	case 'w':
+		if n <= 2 {
+			return numericByCount(fieldISOWeek, source, n), true
+		}
+	case 'W':
+		if n == 1 {
+			return fieldMonthWeek.token(source, '-'), true
+		}
+	case 'd':
+		if n <= 2 {
+			return numericByCount(fieldDay, source, n), true
+		}
+	case 'D':
+		if n <= 3 {
+			return numericByCount(fieldYearDay, source, n), true
+		}
+	case 'E':
+		switch n {
+		case 1, 2, 3:
+			return nameToken(source, "Mon", namesWeekdayAbbr), true
+		case 4:
+			return nameToken(source, "Monday", namesWeekday), true
+		case 5:
+			return renderToken(source, func(t time.Time) string { return t.Weekday().String()[:1] }), true
+		}
+	case 'e', 'c':
+		if n <= 2 {
+			return numericByCount(fieldISODay, source, n), true
+		}
+		return javaToken(source, 'E', n)
+	case 'a':
+		return nameToken(source, "PM", namesDayPeriod), true
+	case 'h':
+		if n <= 2 {
+			return numericByCount(fieldHour12, source, n), true
+		}
+	case 'H':
+		if n <= 2 {
+			return numericByCount(fieldHour, source, n), true
+		}
+	case 'k':
+		if n <= 2 {
+			return numericByCount(fieldHour24, source, n), true
+		}
+	case 'K':
+		if n <= 2 {
+			return numericByCount(fieldHour11, source, n), true
+		}
+	case 'm':
+		if n <= 2 {
+			return numericByCount(fieldMinute, source, n), true
+		}
+	case 's':
+		if n <= 2 {
+			return numericByCount(fieldSecond, source, n), true
+		}
+	case 'S':
+		return formatToken{fraction: n, source: source}, true
+	case 'A':
+		return numericByCount(fieldDayMilli, source, n), true
+	case 'n':
+		return numericByCount(fieldNanos, source, n), true
+	case 'z':
+		if n <= 3 {
+			return textToken(source, "MST"), true
+		}
+		if n == 4 {
+			return renderToken(source, func(t time.Time) string { return t.Location().String() }), true
+		}
+	case 'V':
+		if n == 2 {
+			return renderToken(source, func(t time.Time) string { return t.Location().String() }), true
+		}
+	case 'Z':
+		switch n {
+		case 1, 2, 3:
+			return textToken(source, "-0700"), true
+		case 4:
+			return renderToken(source, func(t time.Time) string { return gmtOffset(t, true) }), true
+		case 5:
+			return textToken(source, "Z07:00"), true
+		}
+	case 'O':
+		switch n {
+		case 1:
+			return renderToken(source, func(t time.Time) string { return gmtOffset(t, false) }), true
+		case 4:
+			return renderToken(source, func(t time.Time) string { return gmtOffset(t, true) }), true
+		}
+	case 'X':
+		switch n {
+		case 1:
+			return textToken(source, "Z07"), true
+		case 2, 4:
+			return textToken(source, "Z0700"), true
+		case 3, 5:
+			return textToken(source, "Z07:00"), true
+		}
+	case 'x':
+		switch n {
+		case 1:
+			return textToken(source, "-07"), true
+		case 2, 4:
+			return textToken(source, "-0700"), true
+		case 3, 5:
+			return textToken(source, "-07:00"), true
+		}
+	}
+
+	return formatToken{}, false
+}
+
+// eraName returns the era of a time, "AD" or "BC" ("Anno Domini" or "Before Christ" in full)
+func eraName(t time.Time, full bool) string {
+	switch {
+	case t.Year() > 0 && full:
+		return "Anno Domini"
+	case t.Year() > 0:
+		return "AD"
+	case full:
+		return "Before Christ"
+	}
+	return "BC"
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// momentTokens lists the moment.js tokens, longest first so that "MMMM" is
+// matched before "MMM", "MM" and "M"
+var momentTokens = []string{
+	"YYYY", "GGGG", "gggg", "MMMM", "DDDD", "dddd", "DDDo",
+	"MMM", "DDD", "ddd",
+	"YY", "GG", "gg", "MM", "Mo", "DD", "Do", "dd", "do", "WW", "Wo", "ww", "wo",
+	"HH", "hh", "kk", "mm", "ss", "ZZ", "zz", "Qo",
+	"Y", "Q", "M", "D", "d", "E", "e", "W", "w", "H", "h", "k", "m", "s",
+	"A", "a", "Z", "z", "X", "x",
+}
+
+// momentLocaleWeekTokens maps the tokens of moment's locale week, whose
+// first day and week 1 depend on the locale, to their ISO equivalents.
+// Locales carry no week rules here, so the locale tokens are rejected rather
+// than rendered as ISO weeks.
+var momentLocaleWeekTokens = map[string]string{
+	"gggg": "GGGG", "gg": "GG",
+	"ww": "WW", "wo": "Wo", "w": "W",
+	"e": "E",
+}
+
+// compileMoment compiles a moment.js / Day.js format such as
+// "YYYY-MM-DD [at] HH:mm". Text in square brackets is literal, as is any text
+// that is not a token.
+func compileMoment(format string) (*compiledFormat, error) {
+	b := newFormatBuilder(format)
+
+	for i := 0; i < len(format); {
+		if format[i] == '[' {
+			end := strings.IndexByte(format[i:], ']')
+			if end < 0 {
+				return nil, NewInvalidFormatError(format, fmt.Sprintf("unterminated '[' at position %d", i+1))
+			}
+			b.text(format[i+1 : i+end])
+			i += end + 1
+			continue
+		}
+
+		// Fractional seconds take any number of 'S'
+		if format[i] == 'S' {
+			n := 1
+			for i+n < len(format) && format[i+n] == 'S' {
+				n++
+			}
+			b.add(formatToken{fraction: n, source: format[i : i+n]})
+			i += n
+			continue
+		}
+
+		matched := false
+		for _, token := range momentTokens {
+			if strings.HasPrefix(format[i:], token) {
+				if iso, ok := momentLocaleWeekTokens[token]; ok {
+					return nil, NewInvalidFormatError(format, fmt.Sprintf("locale week token '%s' at position %d is not supported; use the ISO week token '%s'", token, i+1, iso))
+				}
+				b.add(momentToken(token))
+				i += len(token)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.text(format[i : i+1])
+			i++
+		}
+	}
+
+	return b.build(), nil
+}
+
+// ordinalToken renders a numeric field with an English ordinal suffix
+func ordinalToken(source string, field numericField) formatToken {
+	tok := renderToken(source, func(t time.Time) string { return ordinal(field.value(t)) })
+	tok.english = true
+	return tok
+}
+
+// momentToken returns the token for a moment.js token from momentTokens
+func momentToken(source string) formatToken {
+	switch source {
+	case "YYYY":
+		return fieldYear.token(source, '0')
+	case "YY":
+		return fieldYear2.token(source, '0')
+	case "Y":
+		return fieldYear.token(source, '-')
+	case "GGGG":
+		return fieldISOYear.token(source, '0')
+	case "GG":
+		return fieldISOYear2.token(source, '0')
+	case "Q":
+		return fieldQuarter.token(source, '-')
+	case "Qo":
+		return ordinalToken(source, fieldQuarter)
+	case "MMMM":
+		return nameToken(source, "January", namesMonth)
+	case "MMM":
+		return nameToken(source, "Jan", namesMonthAbbr)
+	case "MM":
+		return fieldMonth.token(source, '0')
+	case "Mo":
+		return ordinalToken(source, fieldMonth)
+	case "M":
+		return fieldMonth.token(source, '-')
+	case "DDDD":
+		return fieldYearDay.token(source, '0')
+	case "DDDo":
+		return ordinalToken(source, fieldYearDay)
+	case "DDD":
+		return fieldYearDay.token(source, '-')
+	case "DD":
+		return fieldDay.token(source, '0')
+	case "Do":
+		return ordinalToken(source, fieldDay)
+	case "D":
+		return fieldDay.token(source, '-')
+	case "dddd":
+		return nameToken(source, "Monday", namesWeekday)
+	case "ddd":
+		return nameToken(source, "Mon", namesWeekdayAbbr)
+	case "dd":
+		return renderToken(source, func(t time.Time) string { return t.Weekday().String()[:2] })
+	case "do":
+		return ordinalToken(source, fieldWeekday)
+	case "d":
+		return fieldWeekday.token(source, '-')
+	case "E":
+		return fieldISODay.token(source, '-')
+	case "WW":
+		return fieldISOWeek.token(source, '0')
+	case "Wo":
+		return ordinalToken(source, fieldISOWeek)
+	case "W":
+		return fieldISOWeek.token(source, '-')
+	case "HH":
+		return fieldHour.token(source, '0')
+	case "H":
+		return fieldHour.token(source, '-')
+	case "hh":
+		return fieldHour12.token(source, '0')
+	case "h":
+		return fieldHour12.token(source, '-')
+	case "kk":
+		return fieldHour24.token(source, '0')
+	case "k":
+		return fieldHour24.token(source, '-')
+	case "mm":
+		return fieldMinute.token(source, '0')
+	case "m":
+		return fieldMinute.token(source, '-')
+	case "ss":
+		return fieldSecond.token(source, '0')
+	case "s":
+		return fieldSecond.token(source, '-')
+	case "A":
+		return nameToken(source, "PM", namesDayPeriod)
+	case "a":
+		return nameToken(source, "pm", namesDayPeriodLower)
+	case "ZZ":
+		return textToken(source, "-0700")
+	case "Z":
+		return textToken(source, "-07:00")
+	case "zz", "z":
+		return textToken(source, "MST")
+	case "X":
+		return fieldUnix.token(source, '-')
+	case "x":
+		return fieldUnixMilli.token(source, '-')
+	}
+
+	// momentTokens and this switch list the same tokens
+	panic("unhandled moment token " + strconv.Quote(source))
+}
@@ -0,0 +1,155 @@
+package services
+
+import "strings"
+
+// compileStrftime compiles a C strftime format such as "%Y-%m-%d %H:%M:%S %z".
+// The GNU flags '-' (no padding), '_' (space padding) and '0' (zero padding)
+// may follow '%', and "%:z" gives an offset with a colon.
+func compileStrftime(format string) (*compiledFormat, error) {
+	b := newFormatBuilder(format)
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.text(format[i : i+1])
+			continue
+		}
+
+		start := i
+		i++
+		pad := byte('0')
+		if i < len(format) && strings.IndexByte("-_0:", format[i]) >= 0 {
+			pad = format[i]
+			i++
+		}
+		if i >= len(format) {
+			return nil, unknownTokenError(format, format[start:], start)
+		}
+
+		source := format[start : i+1]
+		if pad == ':' {
+			if format[i] != 'z' {
+				return nil, unknownTokenError(format, source, start)
+			}
+			b.add(textToken(source, "-07:00"))
+			continue
+		}
+
+		if expansion, ok := strftimeComposites[format[i]]; ok {
+			compiled, err := compileStrftime(expansion)
+			if err != nil {
+				return nil, err
+			}
+			for _, tok := range compiled.tokens {
+				b.append(tok)
+			}
+			continue
+		}
+
+		tok, ok := strftimeToken(source, format[i], pad)
+		if !ok {
+			return nil, unknownTokenError(format, source, start)
+		}
+		b.append(tok)
+	}
+
+	return b.build(), nil
+}
+
+// strftimeComposites are conversions that expand to other conversions
+var strftimeComposites = map[byte]string{
+	'D': "%m/%d/%y",
+	'F': "%Y-%m-%d",
+	'T': "%H:%M:%S",
+	'R': "%H:%M",
+	'r': "%I:%M:%S %p",
+	'c': "%a %b %e %H:%M:%S %Y",
+	'x': "%m/%d/%y",
+	'X': "%H:%M:%S",
+}
+
+// strftimeToken returns the token for a single strftime conversion
+func strftimeToken(source string, conv, pad byte) (formatToken, bool) {
+	switch conv {
+	case 'Y':
+		return fieldYear.token(source, pad), true
+	case 'y':
+		return fieldYear2.token(source, pad), true
+	case 'C':
+		return fieldCentury.token(source, pad), true
+	case 'G':
+		return fieldISOYear.token(source, pad), true
+	case 'g':
+		return fieldISOYear2.token(source, pad), true
+	case 'm':
+		return fieldMonth.token(source, pad), true
+	case 'd':
+		return fieldDay.token(source, pad), true
+	case 'e':
+		if pad == '0' {
+			pad = '_'
+		}
+		return fieldDay.token(source, pad), true
+	case 'j':
+		return fieldYearDay.token(source, pad), true
+	case 'H':
+		return fieldHour.token(source, pad), true
+	case 'k':
+		if pad == '0' {
+			pad = '_'
+		}
+		return fieldHour.token(source, pad), true
+	case 'I':
+		return fieldHour12.token(source, pad), true
+	case 'l':
+		if pad == '0' {
+			pad = '_'
+		}
+		return fieldHour12.token(source, pad), true
+	case 'M':
+		return fieldMinute.token(source, pad), true
+	case 'S':
+		return fieldSecond.token(source, pad), true
+	case 'f':
+		return formatToken{fraction: 6, source: source}, true
+	case 'L':
+		return formatToken{fraction: 3, source: source}, true
+	case 'N':
+		return formatToken{fraction: 9, source: source}, true
+	case 'u':
+		return fieldISODay.token(source, pad), true
+	case 'w':
+		return fieldWeekday.token(source, pad), true
+	case 'U':
+		return fieldSundayWk.token(source, pad), true
+	case 'W':
+		return fieldMondayWk.token(source, pad), true
+	case 'V':
+		return fieldISOWeek.token(source, pad), true
+	case 's':
+		return fieldUnix.token(source, '-'), true
+	case 'a':
+		return nameToken(source, "Mon", namesWeekdayAbbr), true
+	case 'A':
+		return nameToken(source, "Monday", namesWeekday), true
+	case 'b', 'h':
+		return nameToken(source, "Jan", namesMonthAbbr), true
+	case 'B':
+		return nameToken(source, "January", namesMonth), true
+	case 'p':
+		return nameToken(source, "PM", namesDayPeriod), true
+	case 'P':
+		return nameToken(source, "pm", namesDayPeriodLower), true
+	case 'Z':
+		return textToken(source, "MST"), true
+	case 'z':
+		return textToken(source, "-0700"), true
+	case 'n':
+		return formatToken{literal: "\n"}, true
+	case 't':
+		return formatToken{literal: "\t"}, true
+	case '%':
+		return formatToken{literal: "%"}, true
+	}
+
+	return formatToken{}, false
+}
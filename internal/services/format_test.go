@@ -0,0 +1,237 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeService_FormatTimeDialects(t *testing.T) {
+	ts := NewTimeService()
+	// Sunday 7 January 2024, 09:05:03.123456789 in New York
+	loc, _ := time.LoadLocation("America/New_York")
+	testTime := time.Date(2024, 1, 7, 9, 5, 3, 123456789, loc)
+
+	tests := []struct {
+		name     string
+		format   string
+		dialect  string
+		expected string
+		wantErr  bool
+	}{
+		{name: "strftime date and time", format: "%Y-%m-%d %H:%M:%S %z", dialect: "strftime", expected: "2024-01-07 09:05:03 -0500"},
+		{name: "strftime detected from %", format: "%a %d %B %Y", expected: "Sun 07 January 2024"},
+		{name: "strftime day of year and weeks", format: "%j %U %W %V %u %w", dialect: "strftime", expected: "007 01 01 01 7 0"},
+		{name: "strftime flags", format: "%-d/%-m %e %-I%p %:z", dialect: "strftime", expected: "7/1  7 9AM -05:00"},
+		{name: "strftime composites", format: "%F %T", dialect: "strftime", expected: "2024-01-07 09:05:03"},
+		{name: "strftime fraction and percent", format: "%S.%f 100%%", dialect: "strftime", expected: "03.123456 100%"},
+		{name: "strftime unknown conversion", format: "%Y-%Q", dialect: "strftime", wantErr: true},
+		{name: "strftime trailing percent", format: "%Y%", dialect: "strftime", wantErr: true},
+		{name: "java with quoted literal", format: "yyyy-MM-dd'T'HH:mm", dialect: "java", expected: "2024-01-07T09:05"},
+		{name: "java names", format: "EEE, d MMMM yyyy", dialect: "java", expected: "Sun, 7 January 2024"},
+		{name: "java escaped quote", format: "h 'o''clock' a", dialect: "icu", expected: "9 o'clock AM"},
+		{name: "java zone and fraction", format: "HH:mm:ss.SSS XXX zzzz", dialect: "java", expected: "09:05:03.123 -05:00 America/New_York"},
+		{name: "java quarter and day of year", format: "QQQ D", dialect: "java", expected: "Q1 7"},
+		{name: "java unknown letter", format: "yyyy-MM-dd b", dialect: "java", wantErr: true},
+		{name: "java unterminated quote", format: "yyyy 'at", dialect: "java", wantErr: true},
+		{name: "moment with bracket literal", format: "YYYY-MM-DD [at] HH:mm", dialect: "moment", expected: "2024-01-07 at 09:05"},
+		{name: "moment ordinals and names", format: "dddd, MMMM Do YYYY, h:mm:ss a", dialect: "moment", expected: "Sunday, January 7th 2024, 9:05:03 am"},
+		{name: "moment literal T", format: "YYYY-MM-DDTHH:mm:ssZ", dialect: "moment", expected: "2024-01-07T09:05:03-05:00"},
+		{name: "moment iso week", format: "GGGG-[W]WW-E Wo", dialect: "moment", expected: "2024-W01-7 1st"},
+		{name: "moment locale week", format: "gggg-[w]ww", dialect: "moment", wantErr: true},
+		{name: "moment locale weekday", format: "YYYY-MM-DD e", dialect: "moment", wantErr: true},
+		{name: "go layout", format: "Mon Jan 2 15:04:05 MST 2006", dialect: "go", expected: "Sun Jan 7 09:05:03 EST 2024"},
+		{name: "unknown dialect", format: "YYYY", dialect: "cobol", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.FormatTime(testTime, tt.format, tt.dialect, "")
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for format %s, but got %q", tt.format, result)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error for format %s: %v", tt.format, err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestTimeService_ParseTimeDialects(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name     string
+		input    string
+		format   string
+		dialect  string
+		expected string
+		wantErr  bool
+	}{
+		{name: "strftime", input: "2024-03-15 14:30:00", format: "%Y-%m-%d %H:%M:%S", expected: "2024-03-15T14:30:00Z"},
+		{name: "strftime fraction", input: "15/03/2024 14:30:00.250", format: "%d/%m/%Y %H:%M:%S.%L", dialect: "strftime", expected: "2024-03-15T14:30:00.25Z"},
+		{name: "java", input: "Fri, 15 Mar 2024 14:30", format: "EEE, d MMM yyyy HH:mm", dialect: "java", expected: "2024-03-15T14:30:00Z"},
+		{name: "moment", input: "2024-03-15T14:30:00+02:00", format: "YYYY-MM-DDTHH:mm:ssZ", dialect: "moment", expected: "2024-03-15T12:30:00Z"},
+		{name: "week numbers cannot be parsed", input: "2024-11", format: "%Y-%U", dialect: "strftime", wantErr: true},
+		{name: "literal that Go would read as a layout", input: "2024 Monday", format: "yyyy 'Monday'", dialect: "java", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.ParseTime(tt.input, tt.format, tt.dialect, "UTC")
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, but got %+v", result)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if got := result.Result.RFC3339; got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCompileDefault(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 14, 30, 45, 120000000, time.UTC)
+
+	tests := []struct {
+		format   string
+		expected string
+		errorPos string
+	}{
+		{format: "YYYY-MM-DD", expected: "2024-01-15"},
+		{format: "YYYYMMDD", expected: "20240115"},
+		{format: "YYYY-MM-DDTHH:mm:ss.SSSZ", expected: "2024-01-15T14:30:45.120Z"},
+		{format: "hh:mm A", expected: "02:30 PM"},
+		{format: "dddd D MMMM YY", expected: "Monday 15 January 24"},
+		{format: "[Day] D [of] MMMM", expected: "Day 15 of January"},
+		{format: "'Month' MM, 'o''clock'", expected: "Month 01, o'clock"},
+		{format: "Day DD", errorPos: "unknown token 'y' at position 3"},
+		{format: "Month", errorPos: "unknown token 'o' at position 2"},
+		{format: "DDD", errorPos: "unknown token 'DDD' at position 1"},
+		{format: "YYYY [at", errorPos: "unterminated '[' at position 6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			compiled, err := compileDefault(tt.format)
+
+			if tt.errorPos != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorPos) {
+					t.Errorf("Expected error containing %q, got %v", tt.errorPos, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if got := compiled.Format(testTime); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCompileFormat_Cached(t *testing.T) {
+	first, err := compileFormat("YYYY-MM-DD", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	second, err := compileFormat("YYYY-MM-DD", "default")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if first != second {
+		t.Errorf("Expected the compiled format to be reused from the cache")
+	}
+}
+
+// fuzzDialects are the dialects exercised by the fuzz tests
+var fuzzDialects = []string{DialectDefault, DialectStrftime, DialectJava, DialectMoment, DialectGo}
+
+func FuzzCompileFormat(f *testing.F) {
+	seeds := []string{
+		"YYYY-MM-DD", "YYYY-MM-DDTHH:mm:ss.SSSZ", "[Day] D", "'Month' MM", "MMYYYYYY",
+		"%Y-%m-%d %H:%M:%S %z", "%-d/%-m %e %j %U", "%",
+		"EEE, d MMM yyyy 'at' HH:mm", "yyyy 'unterminated",
+		"dddd [the] Do", "Mon Jan 2 15:04:05 MST 2006",
+	}
+	for _, seed := range seeds {
+		for i := range fuzzDialects {
+			f.Add(seed, uint8(i))
+		}
+	}
+
+	testTime := time.Date(2024, 1, 15, 14, 30, 45, 123456789, time.FixedZone("XYZ", 19800))
+
+	f.Fuzz(func(t *testing.T, format string, dialectIndex uint8) {
+		dialect := fuzzDialects[int(dialectIndex)%len(fuzzDialects)]
+
+		cached, cachedErr := compileFormat(format, dialect)
+		fresh, freshErr := formatDialects[dialect](format)
+
+		if (cachedErr == nil) != (freshErr == nil) {
+			t.Fatalf("Compiling %q twice disagreed: %v vs %v", format, cachedErr, freshErr)
+		}
+		if cachedErr != nil {
+			if cachedErr.Error() != freshErr.Error() {
+				t.Fatalf("Unstable error for %q: %v vs %v", format, cachedErr, freshErr)
+			}
+			return
+		}
+
+		output := cached.Format(testTime)
+		if again := fresh.Format(testTime); again != output {
+			t.Fatalf("Unstable output for %q: %q vs %q", format, output, again)
+		}
+
+		layout, layoutErr := cached.Layout()
+		againLayout, againErr := fresh.Layout()
+		if layout != againLayout || (layoutErr == nil) != (againErr == nil) {
+			t.Fatalf("Unstable layout for %q: %q vs %q", format, layout, againLayout)
+		}
+	})
+}
+
+func FuzzCompileDefault_Escaping(f *testing.F) {
+	for _, seed := range []string{"Day", "Month", "YYYY", "2006-01-02", "at 'noon'", "%Y"} {
+		f.Add(seed)
+	}
+
+	testTime := time.Date(2024, 1, 15, 14, 30, 45, 0, time.UTC)
+
+	f.Fuzz(func(t *testing.T, text string) {
+		if strings.Contains(text, "]") {
+			t.Skip()
+		}
+
+		compiled, err := compileDefault("[" + text + "]")
+		if err != nil {
+			t.Fatalf("Unexpected error escaping %q: %v", text, err)
+		}
+
+		if got := compiled.Format(testTime); got != text {
+			t.Errorf("Escaped text %q rendered as %q", text, got)
+		}
+	})
+}
@@ -0,0 +1,481 @@
+package services
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed holidays/*.json
+var holidayFiles embed.FS
+
+// Holiday rule types
+const (
+	HolidayRuleFixed      = "fixed"       // Same month and day every year
+	HolidayRuleNthWeekday = "nth_weekday" // Nth (or last, with nth -1) weekday of a month
+	HolidayRuleEaster     = "easter"      // Offset in days from Western Easter Sunday
+	HolidayRuleDate       = "date"        // One-off date
+)
+
+// Observance rules for holidays falling on a weekend
+const (
+	ObservedNone           = ""                // Not moved
+	ObservedNearestWeekday = "nearest_weekday" // Saturday to Friday, Sunday to Monday
+	ObservedNextWeekday    = "next_weekday"    // Next working day not already a holiday
+)
+
+// HolidayRule describes how to compute a holiday's date for a given year
+type HolidayRule struct {
+	Name         string `json:"name" yaml:"name"`
+	Type         string `json:"type" yaml:"type"`
+	Month        int    `json:"month,omitempty" yaml:"month,omitempty"`
+	Day          int    `json:"day,omitempty" yaml:"day,omitempty"`
+	Weekday      string `json:"weekday,omitempty" yaml:"weekday,omitempty"`
+	Nth          int    `json:"nth,omitempty" yaml:"nth,omitempty"`
+	Offset       int    `json:"offset,omitempty" yaml:"offset,omitempty"`
+	Date         string `json:"date,omitempty" yaml:"date,omitempty"`
+	Observed     string `json:"observed,omitempty" yaml:"observed,omitempty"`
+	FromYear     int    `json:"fromYear,omitempty" yaml:"fromYear,omitempty"`
+	ToYear       int    `json:"toYear,omitempty" yaml:"toYear,omitempty"`
+	ExcludeYears []int  `json:"excludeYears,omitempty" yaml:"excludeYears,omitempty"`
+}
+
+// HolidayCalendarFile is the on-disk format of a holiday set
+type HolidayCalendarFile struct {
+	ID       string        `json:"id" yaml:"id"`
+	Name     string        `json:"name" yaml:"name"`
+	Country  string        `json:"country,omitempty" yaml:"country,omitempty"`
+	Weekend  []string      `json:"weekend,omitempty" yaml:"weekend,omitempty"`
+	Holidays []HolidayRule `json:"holidays" yaml:"holidays"`
+}
+
+// Holiday is a holiday observed on a specific date
+type Holiday struct {
+	Date     string `json:"date"`
+	Name     string `json:"name"`
+	Observed bool   `json:"observed"`
+	Calendar string `json:"calendar"`
+}
+
+// BusinessCalendar is a validated holiday set with its weekend definition
+type BusinessCalendar struct {
+	id      string
+	name    string
+	weekend [7]bool
+	rules   []compiledHolidayRule
+	mu      sync.Mutex
+	byYear  map[int][]holidayDate
+}
+
+// compiledHolidayRule is a HolidayRule with its fields parsed
+type compiledHolidayRule struct {
+	HolidayRule
+	weekday time.Weekday
+	date    time.Time
+}
+
+// holidayDate is a holiday resolved to a civil date (midnight UTC)
+type holidayDate struct {
+	date     time.Time
+	name     string
+	observed bool
+}
+
+// weekendsByCountry lists weekends that differ from Saturday and Sunday, by ISO 3166 country code
+var weekendsByCountry = map[string][]time.Weekday{
+	"AF": {time.Thursday, time.Friday},
+	"BD": {time.Friday, time.Saturday},
+	"BH": {time.Friday, time.Saturday},
+	"DJ": {time.Friday},
+	"DZ": {time.Friday, time.Saturday},
+	"EG": {time.Friday, time.Saturday},
+	"IL": {time.Friday, time.Saturday},
+	"IN": {time.Sunday},
+	"IQ": {time.Friday, time.Saturday},
+	"IR": {time.Friday},
+	"JO": {time.Friday, time.Saturday},
+	"KW": {time.Friday, time.Saturday},
+	"LY": {time.Friday, time.Saturday},
+	"MV": {time.Friday, time.Saturday},
+	"NP": {time.Saturday},
+	"OM": {time.Friday, time.Saturday},
+	"QA": {time.Friday, time.Saturday},
+	"SA": {time.Friday, time.Saturday},
+	"SD": {time.Friday, time.Saturday},
+	"SY": {time.Friday, time.Saturday},
+	"UG": {time.Sunday},
+	"YE": {time.Friday, time.Saturday},
+}
+
+// builtinCalendars holds the holiday sets embedded in the binary, keyed by ID
+var builtinCalendars = mustLoadBuiltinCalendars()
+
+// mustLoadBuiltinCalendars compiles the embedded holiday sets
+func mustLoadBuiltinCalendars() map[string]*BusinessCalendar {
+	entries, err := holidayFiles.ReadDir("holidays")
+	if err != nil {
+		panic(fmt.Sprintf("failed to read embedded holiday data: %v", err))
+	}
+
+	calendars := make(map[string]*BusinessCalendar)
+	for _, entry := range entries {
+		data, err := holidayFiles.ReadFile("holidays/" + entry.Name())
+		if err != nil {
+			panic(fmt.Sprintf("failed to read embedded holiday file %s: %v", entry.Name(), err))
+		}
+
+		var file HolidayCalendarFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			panic(fmt.Sprintf("failed to parse embedded holiday file %s: %v", entry.Name(), err))
+		}
+
+		cal, err := NewBusinessCalendar(file)
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded holiday file %s: %v", entry.Name(), err))
+		}
+		calendars[cal.id] = cal
+	}
+
+	return calendars
+}
+
+// LoadHolidayCalendarFile loads a custom holiday set from a JSON or YAML file.
+// The calendar ID defaults to "custom" when the file does not set one.
+func LoadHolidayCalendarFile(path string) (*BusinessCalendar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, NewInvalidCalendarError(path, "failed to read holiday file", err)
+	}
+
+	var file HolidayCalendarFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	default:
+		err = json.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, NewInvalidCalendarError(path, "failed to parse holiday file", err)
+	}
+
+	if file.ID == "" {
+		file.ID = "custom"
+	}
+
+	return NewBusinessCalendar(file)
+}
+
+// NewBusinessCalendar validates a holiday set and compiles its rules
+func NewBusinessCalendar(file HolidayCalendarFile) (*BusinessCalendar, error) {
+	id := strings.ToLower(strings.TrimSpace(file.ID))
+	if id == "" {
+		return nil, NewInvalidCalendarError(file.Name, "calendar id cannot be empty", nil)
+	}
+
+	cal := &BusinessCalendar{
+		id:     id,
+		name:   file.Name,
+		byYear: make(map[int][]holidayDate),
+	}
+
+	if len(file.Weekend) > 0 {
+		for _, name := range file.Weekend {
+			wd, ok := parseWeekday(name)
+			if !ok {
+				return nil, NewInvalidCalendarError(id, fmt.Sprintf("unknown weekend day '%s'", name), nil)
+			}
+			cal.weekend[wd] = true
+		}
+	} else {
+		cal.weekend = countryWeekend(file.Country)
+	}
+
+	for _, rule := range file.Holidays {
+		compiled, err := compileHolidayRule(rule)
+		if err != nil {
+			return nil, NewInvalidCalendarError(id, fmt.Sprintf("holiday '%s': %v", rule.Name, err), nil)
+		}
+		cal.rules = append(cal.rules, compiled)
+	}
+
+	return cal, nil
+}
+
+// ID returns the calendar identifier used to select it in tools
+func (c *BusinessCalendar) ID() string {
+	return c.id
+}
+
+// Name returns the human-readable calendar name
+func (c *BusinessCalendar) Name() string {
+	return c.name
+}
+
+// compileHolidayRule validates a rule and parses its weekday and date fields
+func compileHolidayRule(rule HolidayRule) (compiledHolidayRule, error) {
+	compiled := compiledHolidayRule{HolidayRule: rule}
+
+	if rule.Name == "" {
+		return compiled, fmt.Errorf("name cannot be empty")
+	}
+
+	switch rule.Type {
+	case HolidayRuleFixed:
+		if rule.Month < 1 || rule.Month > 12 || rule.Day < 1 || rule.Day > 31 {
+			return compiled, fmt.Errorf("fixed holidays need a valid month and day")
+		}
+	case HolidayRuleNthWeekday:
+		if rule.Month < 1 || rule.Month > 12 {
+			return compiled, fmt.Errorf("nth_weekday holidays need a valid month")
+		}
+		if rule.Nth == 0 || rule.Nth < -5 || rule.Nth > 5 {
+			return compiled, fmt.Errorf("nth must be 1-5, or -1 to -5 counting from the end of the month")
+		}
+		wd, ok := parseWeekday(rule.Weekday)
+		if !ok {
+			return compiled, fmt.Errorf("unknown weekday '%s'", rule.Weekday)
+		}
+		compiled.weekday = wd
+	case HolidayRuleEaster:
+	case HolidayRuleDate:
+		date, err := time.Parse("2006-01-02", rule.Date)
+		if err != nil {
+			return compiled, fmt.Errorf("date must be YYYY-MM-DD")
+		}
+		compiled.date = date
+	default:
+		return compiled, fmt.Errorf("unknown rule type '%s'", rule.Type)
+	}
+
+	switch rule.Observed {
+	case ObservedNone, ObservedNearestWeekday, ObservedNextWeekday:
+	default:
+		return compiled, fmt.Errorf("unknown observance '%s'", rule.Observed)
+	}
+
+	return compiled, nil
+}
+
+// IsWeekend reports whether the weekday is a weekend day in this calendar
+func (c *BusinessCalendar) IsWeekend(wd time.Weekday) bool {
+	return c.weekend[wd]
+}
+
+// holidaysOn returns the holidays observed on the civil date d
+func (c *BusinessCalendar) holidaysOn(d time.Time) []holidayDate {
+	var result []holidayDate
+	for _, h := range c.holidaysInYear(d.Year()) {
+		if h.date.Equal(d) {
+			result = append(result, h)
+		}
+	}
+	return result
+}
+
+// holidaysInYear returns the holidays observed in a year, sorted by date.
+// Neighbouring years are evaluated too, since an observance can cross a year
+// boundary (New Year's Day on a Saturday is observed on December 31).
+func (c *BusinessCalendar) holidaysInYear(year int) []holidayDate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.byYear[year]; ok {
+		return cached
+	}
+
+	var result []holidayDate
+	for y := year - 1; y <= year+1; y++ {
+		for _, h := range c.observedHolidays(y) {
+			if h.date.Year() == year {
+				result = append(result, h)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].date.Before(result[j].date)
+	})
+
+	c.byYear[year] = result
+	return result
+}
+
+// observedHolidays evaluates every rule for a year and applies weekend observance
+func (c *BusinessCalendar) observedHolidays(year int) []holidayDate {
+	type occurrence struct {
+		rule compiledHolidayRule
+		date time.Time
+	}
+
+	var occurrences []occurrence
+	taken := make(map[time.Time]bool)
+	for _, rule := range c.rules {
+		date, ok := rule.dateIn(year)
+		if !ok {
+			continue
+		}
+		occurrences = append(occurrences, occurrence{rule, date})
+		if !c.weekend[date.Weekday()] {
+			taken[date] = true
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].date.Before(occurrences[j].date)
+	})
+
+	result := make([]holidayDate, 0, len(occurrences))
+	for _, o := range occurrences {
+		date := o.date
+		if c.weekend[date.Weekday()] {
+			switch o.rule.Observed {
+			case ObservedNearestWeekday:
+				date = c.nearestWorkingDay(date)
+			case ObservedNextWeekday:
+				date = date.AddDate(0, 0, 1)
+				for c.weekend[date.Weekday()] || taken[date] {
+					date = date.AddDate(0, 0, 1)
+				}
+				taken[date] = true
+			}
+		}
+		result = append(result, holidayDate{date: date, name: o.rule.Name, observed: !date.Equal(o.date)})
+	}
+
+	return result
+}
+
+// nearestWorkingDay returns the closest non-weekend day, preferring the later day on ties
+func (c *BusinessCalendar) nearestWorkingDay(date time.Time) time.Time {
+	for i := 1; i < 7; i++ {
+		if next := date.AddDate(0, 0, i); !c.weekend[next.Weekday()] {
+			return next
+		}
+		if prev := date.AddDate(0, 0, -i); !c.weekend[prev.Weekday()] {
+			return prev
+		}
+	}
+	return date
+}
+
+// dateIn computes the actual date of the rule in a year, if it occurs that year
+func (r compiledHolidayRule) dateIn(year int) (time.Time, bool) {
+	if r.FromYear != 0 && year < r.FromYear {
+		return time.Time{}, false
+	}
+	if r.ToYear != 0 && year > r.ToYear {
+		return time.Time{}, false
+	}
+	for _, excluded := range r.ExcludeYears {
+		if excluded == year {
+			return time.Time{}, false
+		}
+	}
+
+	switch r.Type {
+	case HolidayRuleFixed:
+		if r.Day > daysIn(year, time.Month(r.Month)) {
+			return time.Time{}, false
+		}
+		return civilDate(year, time.Month(r.Month), r.Day).AddDate(0, 0, r.Offset), true
+	case HolidayRuleNthWeekday:
+		date, ok := nthWeekday(year, time.Month(r.Month), r.weekday, r.Nth)
+		if !ok {
+			return time.Time{}, false
+		}
+		return date.AddDate(0, 0, r.Offset), true
+	case HolidayRuleEaster:
+		return easterSunday(year).AddDate(0, 0, r.Offset), true
+	case HolidayRuleDate:
+		if r.date.Year() != year {
+			return time.Time{}, false
+		}
+		return r.date, true
+	}
+
+	return time.Time{}, false
+}
+
+// nthWeekday returns the nth weekday of a month; negative n counts from the end
+func nthWeekday(year int, month time.Month, wd time.Weekday, n int) (time.Time, bool) {
+	if n > 0 {
+		first := civilDate(year, month, 1)
+		shift := (int(wd) - int(first.Weekday()) + 7) % 7
+		day := 1 + shift + (n-1)*7
+		if day > daysIn(year, month) {
+			return time.Time{}, false
+		}
+		return civilDate(year, month, day), true
+	}
+
+	lastDay := daysIn(year, month)
+	last := civilDate(year, month, lastDay)
+	shift := (int(last.Weekday()) - int(wd) + 7) % 7
+	day := lastDay - shift + (n+1)*7
+	if day < 1 {
+		return time.Time{}, false
+	}
+	return civilDate(year, month, day), true
+}
+
+// easterSunday computes Western (Gregorian) Easter Sunday using the
+// anonymous Gregorian algorithm (Meeus/Jones/Butcher)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return civilDate(year, time.Month(month), day)
+}
+
+// civilDate returns midnight UTC on the given date, used as a timezone-free calendar day
+func civilDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// countryWeekend returns the weekend days for an ISO 3166 country code, defaulting to Saturday and Sunday
+func countryWeekend(country string) [7]bool {
+	var weekend [7]bool
+	days, ok := weekendsByCountry[strings.ToUpper(country)]
+	if !ok {
+		days = []time.Weekday{time.Saturday, time.Sunday}
+	}
+	for _, wd := range days {
+		weekend[wd] = true
+	}
+	return weekend
+}
+
+// parseWeekday parses a full or three-letter English weekday name
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) < 3 {
+		return 0, false
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		full := strings.ToLower(wd.String())
+		if name == full || name == full[:3] {
+			return wd, true
+		}
+	}
+	return 0, false
+}
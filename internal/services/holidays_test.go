@@ -0,0 +1,176 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEasterSunday(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected string
+	}{
+		{2019, "2019-04-21"},
+		{2024, "2024-03-31"},
+		{2025, "2025-04-20"},
+		{2038, "2038-04-25"},
+		{2285, "2285-03-22"},
+	}
+
+	for _, tt := range tests {
+		if got := easterSunday(tt.year).Format("2006-01-02"); got != tt.expected {
+			t.Errorf("Expected Easter %d on %s, got %s", tt.year, tt.expected, got)
+		}
+	}
+}
+
+func TestBusinessCalendar_Holidays(t *testing.T) {
+	tests := []struct {
+		name     string
+		calendar string
+		year     int
+		expected map[string]string
+	}{
+		{
+			name:     "US federal holidays 2021",
+			calendar: "us",
+			year:     2021,
+			expected: map[string]string{
+				"2021-01-01": "New Year's Day",
+				"2021-01-18": "Birthday of Martin Luther King, Jr.",
+				"2021-05-31": "Memorial Day",
+				"2021-06-18": "Juneteenth National Independence Day",
+				"2021-07-05": "Independence Day",
+				"2021-11-25": "Thanksgiving Day",
+				"2021-12-24": "Christmas Day",
+				"2021-12-31": "New Year's Day",
+			},
+		},
+		{
+			name:     "UK substitute days 2021",
+			calendar: "uk",
+			year:     2021,
+			expected: map[string]string{
+				"2021-04-02": "Good Friday",
+				"2021-04-05": "Easter Monday",
+				"2021-05-03": "Early May bank holiday",
+				"2021-05-31": "Spring bank holiday",
+				"2021-08-30": "Summer bank holiday",
+				"2021-12-27": "Christmas Day",
+				"2021-12-28": "Boxing Day",
+			},
+		},
+		{
+			name:     "UK Christmas on Sunday 2022",
+			calendar: "uk",
+			year:     2022,
+			expected: map[string]string{
+				"2022-06-02": "Spring bank holiday",
+				"2022-06-03": "Platinum Jubilee bank holiday",
+				"2022-12-26": "Boxing Day",
+				"2022-12-27": "Christmas Day",
+			},
+		},
+		{
+			name:     "TARGET2 2024",
+			calendar: "target2",
+			year:     2024,
+			expected: map[string]string{
+				"2024-01-01": "New Year's Day",
+				"2024-03-29": "Good Friday",
+				"2024-04-01": "Easter Monday",
+				"2024-05-01": "Labour Day",
+				"2024-12-25": "Christmas Day",
+				"2024-12-26": "Christmas Holiday",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal, ok := builtinCalendars[tt.calendar]
+			if !ok {
+				t.Fatalf("Calendar %s is not embedded", tt.calendar)
+			}
+
+			got := make(map[string]string)
+			for _, h := range cal.holidaysInYear(tt.year) {
+				got[h.date.Format("2006-01-02")] = h.name
+			}
+
+			for date, name := range tt.expected {
+				if got[date] != name {
+					t.Errorf("Expected %s on %s, got %q", name, date, got[date])
+				}
+			}
+		})
+	}
+}
+
+func TestBusinessCalendar_NoFixedHolidayOnUSWeekend(t *testing.T) {
+	cal := builtinCalendars["us"]
+
+	for year := 2000; year <= 2050; year++ {
+		for _, h := range cal.holidaysInYear(year) {
+			if wd := h.date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+				t.Errorf("%s observed on a weekend: %s", h.name, h.date.Format("2006-01-02"))
+			}
+		}
+	}
+}
+
+func TestLoadHolidayCalendarFile(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlFile := filepath.Join(dir, "company.yaml")
+	yamlData := `id: company
+name: Company shutdown days
+weekend: [Friday, Saturday]
+holidays:
+  - name: Founders Day
+    type: fixed
+    month: 3
+    day: 14
+  - name: Day after Thanksgiving
+    type: nth_weekday
+    month: 11
+    weekday: Thursday
+    nth: 4
+    offset: 1
+`
+	if err := os.WriteFile(yamlFile, []byte(yamlData), 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	cal, err := LoadHolidayCalendarFile(yamlFile)
+	if err != nil {
+		t.Fatalf("Unexpected error loading YAML calendar: %v", err)
+	}
+
+	if cal.ID() != "company" {
+		t.Errorf("Expected calendar ID company, got %s", cal.ID())
+	}
+
+	if !cal.IsWeekend(time.Friday) || cal.IsWeekend(time.Sunday) {
+		t.Errorf("Expected Friday/Saturday weekend")
+	}
+
+	if got := cal.holidaysOn(civilDate(2024, time.November, 29)); len(got) != 1 {
+		t.Errorf("Expected day after Thanksgiving on 2024-11-29, got %v", got)
+	}
+
+	jsonFile := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(jsonFile, []byte(`{"holidays": [{"name": "Bad", "type": "weekly"}]}`), 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	if _, err := LoadHolidayCalendarFile(jsonFile); err == nil {
+		t.Errorf("Expected error for unknown rule type, but got none")
+	}
+
+	if _, err := LoadHolidayCalendarFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("Expected error for missing file, but got none")
+	}
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Relative time styles
+const (
+	RelativeStyleAuto     = "auto"     // Numeric within the day, calendar phrases within a week
+	RelativeStyleNumeric  = "numeric"  // Always "in 3 hours" / "2 days ago"
+	RelativeStyleCalendar = "calendar" // "yesterday at 14:00", "last Tuesday", then a date
+)
+
+// Rounding modes for relative time values
+const (
+	RoundingRound = "round"
+	RoundingFloor = "floor"
+	RoundingCeil  = "ceil"
+)
+
+// relativeUnit is a unit of relative time with its average length in seconds
+type relativeUnit struct {
+	name    string
+	seconds float64
+}
+
+// relativeUnits lists the units from smallest to largest. Months and years
+// use their average Gregorian length.
+var relativeUnits = []relativeUnit{
+	{"second", 1},
+	{"minute", 60},
+	{"hour", 3600},
+	{"day", 86400},
+	{"week", 7 * 86400},
+	{"month", 30.436875 * 86400},
+	{"year", 365.2425 * 86400},
+}
+
+// Indexes of "hour" and "day" in relativeUnits
+const (
+	hourUnit = 2
+	dayUnit  = 3
+)
+
+// RelativeThresholds sets, for each unit, the value at which the next larger
+// unit is used instead, e.g. 45 seconds becomes "1 minute". Values of zero or
+// less select the default.
+type RelativeThresholds struct {
+	Seconds float64 `json:"seconds"` // Default 45
+	Minutes float64 `json:"minutes"` // Default 45
+	Hours   float64 `json:"hours"`   // Default 22
+	Days    float64 `json:"days"`    // Default 7
+	Weeks   float64 `json:"weeks"`   // Default 4
+	Months  float64 `json:"months"`  // Default 11
+}
+
+// limits returns the thresholds by unit index, filling in defaults
+func (rt RelativeThresholds) limits() [6]float64 {
+	limits := [6]float64{rt.Seconds, rt.Minutes, rt.Hours, rt.Days, rt.Weeks, rt.Months}
+	for i, def := range [6]float64{45, 45, 22, 7, 4, 11} {
+		if limits[i] <= 0 {
+			limits[i] = def
+		}
+	}
+	return limits
+}
+
+// HumanizeOptions controls how a relative time is phrased
+type HumanizeOptions struct {
+	Timezone    string             // Timezone for calendar days and times of day, default UTC
+	Style       string             // auto (default), numeric or calendar
+	Granularity string             // Smallest unit reported, default second
+	Rounding    string             // round (default), floor or ceil
+	Thresholds  RelativeThresholds // When to switch to the next larger unit
+}
+
+// RelativeTime is a time phrased relative to a reference time, with the
+// components of the phrase so callers can rephrase it
+type RelativeTime struct {
+	Phrase       string    `json:"phrase"`
+	Locale       string    `json:"locale"`
+	Kind         string    `json:"kind"`      // numeric, day, weekday or date
+	Direction    string    `json:"direction"` // past, future or present
+	Value        int       `json:"value"`     // Magnitude in unit; calendar phrases count calendar days
+	Unit         string    `json:"unit"`
+	Exact        float64   `json:"exact"`
+	TotalSeconds float64   `json:"totalSeconds"`
+	CalendarDays int       `json:"calendarDays"`
+	Weekday      string    `json:"weekday"`
+	TimeOfDay    string    `json:"timeOfDay,omitempty"`
+	Time         ZonedTime `json:"time"`
+	Reference    ZonedTime `json:"reference"`
+}
+
+// Humanize phrases t relative to reference (now when empty) in a locale, e.g.
+// "in 3 hours", "yesterday at 14:00" or "last Tuesday". The unit is the
+// smallest one, from the granularity upwards, whose rounded value is below its
+// threshold; a value that rounds to zero reads "now", "today", "this week" etc.
+func (ts *timeService) Humanize(t, reference, locale string, opts HumanizeOptions) (*RelativeTime, error) {
+	loc, err := ts.loadLocation(opts.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := ts.parseInstant(t, loc)
+	if err != nil {
+		return nil, err
+	}
+	target = target.In(loc)
+
+	ref := time.Now().In(loc)
+	if reference != "" {
+		if ref, err = ts.parseInstant(reference, loc); err != nil {
+			return nil, err
+		}
+		ref = ref.In(loc)
+	}
+
+	data, err := lookupLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		data = builtinLocales["en"]
+	}
+
+	granularity, err := parseGranularity(opts.Granularity)
+	if err != nil {
+		return nil, err
+	}
+	round, err := parseRounding(opts.Rounding)
+	if err != nil {
+		return nil, err
+	}
+	style := strings.ToLower(strings.TrimSpace(opts.Style))
+	if style == "" {
+		style = RelativeStyleAuto
+	}
+
+	totalSeconds := float64(target.Unix()-ref.Unix()) + float64(target.Nanosecond()-ref.Nanosecond())/1e9
+	limits := opts.Thresholds.limits()
+
+	// Pick the smallest unit whose rounded value stays below its threshold
+	unit := granularity
+	exact := math.Abs(totalSeconds) / relativeUnits[unit].seconds
+	value := int(round(exact))
+	for unit < len(relativeUnits)-1 && float64(value) >= limits[unit] {
+		unit++
+		exact = math.Abs(totalSeconds) / relativeUnits[unit].seconds
+		value = int(round(exact))
+	}
+
+	targetDay := civilDate(target.Date())
+	calendarDays := int(math.Round(targetDay.Sub(civilDate(ref.Date())).Hours() / 24))
+
+	result := &RelativeTime{
+		Locale:       data.Tag,
+		Value:        value,
+		Unit:         relativeUnits[unit].name,
+		Exact:        exact,
+		TotalSeconds: totalSeconds,
+		CalendarDays: calendarDays,
+		Weekday:      target.Weekday().String(),
+		Time:         newZonedTime(target, opts.Timezone),
+		Reference:    newZonedTime(ref, opts.Timezone),
+	}
+
+	switch {
+	case totalSeconds < 0:
+		result.Direction = "past"
+	case totalSeconds > 0:
+		result.Direction = "future"
+	default:
+		result.Direction = "present"
+	}
+
+	nearby := calendarDays >= -6 && calendarDays <= 6
+	switch style {
+	case RelativeStyleNumeric:
+		data.numericPhrase(result)
+	case RelativeStyleAuto:
+		// Hours on another calendar day read better as "yesterday at 14:00"
+		if nearby && (unit >= dayUnit || (unit >= hourUnit && calendarDays != 0)) {
+			err = data.calendarPhrase(result, target)
+		} else {
+			data.numericPhrase(result)
+		}
+	case RelativeStyleCalendar:
+		if nearby {
+			err = data.calendarPhrase(result, target)
+		} else {
+			err = data.datePhrase(result, target)
+		}
+	default:
+		return nil, NewTimeServiceError(
+			ErrCodeTimeOperation,
+			fmt.Sprintf("invalid style '%s': expected auto, numeric or calendar", opts.Style),
+			"style",
+			nil,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// parseGranularity returns the index of a unit name in relativeUnits,
+// accepting plurals such as "hours" and defaulting to seconds
+func parseGranularity(granularity string) (int, error) {
+	name := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(granularity)), "s")
+	if name == "" {
+		return 0, nil
+	}
+
+	for i, unit := range relativeUnits {
+		if unit.name == name {
+			return i, nil
+		}
+	}
+
+	return 0, NewTimeServiceError(
+		ErrCodeTimeOperation,
+		fmt.Sprintf("invalid granularity '%s': expected second, minute, hour, day, week, month or year", granularity),
+		"granularity",
+		nil,
+	)
+}
+
+// parseRounding returns the rounding function for a rounding mode
+func parseRounding(rounding string) (func(float64) float64, error) {
+	switch strings.ToLower(strings.TrimSpace(rounding)) {
+	case "", RoundingRound:
+		return math.Round, nil
+	case RoundingFloor:
+		return math.Floor, nil
+	case RoundingCeil:
+		return math.Ceil, nil
+	}
+
+	return nil, NewTimeServiceError(
+		ErrCodeTimeOperation,
+		fmt.Sprintf("invalid rounding '%s': expected round, floor or ceil", rounding),
+		"rounding",
+		nil,
+	)
+}
+
+// numericPhrase phrases the value and unit, e.g. "in 3 hours" or "now"
+func (l *localeData) numericPhrase(r *RelativeTime) {
+	r.Kind = "numeric"
+
+	if r.Value == 0 {
+		r.Direction = "present"
+		r.Phrase = l.RelativeTime.Current[r.Unit]
+		return
+	}
+
+	phrases := l.RelativeTime.Future
+	if r.Direction == "past" {
+		phrases = l.RelativeTime.Past
+	}
+	r.Phrase = strings.ReplaceAll(phrases[r.Unit][l.pluralCategory(r.Value)], "{0}", strconv.Itoa(r.Value))
+}
+
+// calendarPhrase phrases a time within six calendar days of the reference,
+// e.g. "yesterday at 14:00" or "last Tuesday"
+func (l *localeData) calendarPhrase(r *RelativeTime, t time.Time) error {
+	rt := l.RelativeTime
+
+	var day string
+	switch r.CalendarDays {
+	case -1:
+		day = rt.Yesterday
+	case 0:
+		day = rt.Today
+	case 1:
+		day = rt.Tomorrow
+	default:
+		r.Kind = "weekday"
+		r.setCalendarDays()
+		if r.CalendarDays < 0 {
+			r.Phrase = rt.LastWeekday[t.Weekday()]
+		} else {
+			r.Phrase = rt.NextWeekday[t.Weekday()]
+		}
+		return nil
+	}
+
+	timeOfDay, err := l.formatStyle(t, "time:short")
+	if err != nil {
+		return err
+	}
+
+	r.Kind = "day"
+	r.setCalendarDays()
+	r.TimeOfDay = timeOfDay
+	r.Phrase = strings.NewReplacer("{0}", day, "{1}", timeOfDay).Replace(rt.AtTime)
+	return nil
+}
+
+// datePhrase phrases a distant time as the locale's medium date
+func (l *localeData) datePhrase(r *RelativeTime, t time.Time) error {
+	date, err := l.formatStyle(t, "date:medium")
+	if err != nil {
+		return err
+	}
+
+	r.Kind = "date"
+	r.setCalendarDays()
+	r.Phrase = date
+	return nil
+}
+
+// setCalendarDays reports a calendar phrase as a number of calendar days,
+// so "yesterday" is 1 day in the past and "today" is the present
+func (r *RelativeTime) setCalendarDays() {
+	days := r.CalendarDays
+	r.Direction = "present"
+	switch {
+	case days < 0:
+		r.Direction = "past"
+		days = -days
+	case days > 0:
+		r.Direction = "future"
+	}
+	r.Value, r.Unit, r.Exact = days, relativeUnits[dayUnit].name, float64(days)
+}
+
+// formatStyle formats a time with one of the locale's style patterns
+func (l *localeData) formatStyle(t time.Time, style string) (string, error) {
+	pattern, _ := l.stylePattern(style)
+	compiled, err := compileFormat(pattern, DialectJava)
+	if err != nil {
+		return "", err
+	}
+	return compiled.FormatLocale(t, l), nil
+}
@@ -0,0 +1,119 @@
+package services
+
+import "testing"
+
+func TestTimeService_Humanize(t *testing.T) {
+	ts := NewTimeService()
+	// Thursday 14 March 2024, 10:00 in Berlin
+	reference := "2024-03-14T10:00:00+01:00"
+
+	tests := []struct {
+		name         string
+		time         string
+		locale       string
+		opts         HumanizeOptions
+		expected     string
+		kind         string
+		direction    string
+		value        int
+		unit         string
+		calendarDays int
+		wantErr      bool
+	}{
+		{name: "hours ahead", time: "2024-03-14T13:00:00+01:00", expected: "in 3 hours", kind: "numeric", direction: "future", value: 3, unit: "hour"},
+		{name: "minutes ago", time: "2024-03-14T09:55:00+01:00", expected: "5 minutes ago", kind: "numeric", direction: "past", value: 5, unit: "minute"},
+		{name: "one minute", time: "2024-03-14T10:01:00+01:00", expected: "in 1 minute", kind: "numeric", direction: "future", value: 1, unit: "minute"},
+		{name: "seconds round up to a minute", time: "2024-03-14T10:00:50+01:00", expected: "in 1 minute", kind: "numeric", direction: "future", value: 1, unit: "minute"},
+		{name: "same instant", time: reference, expected: "now", kind: "numeric", direction: "present", value: 0, unit: "second"},
+		{name: "yesterday with time", time: "2024-03-13T14:00:00+01:00", opts: HumanizeOptions{Timezone: "Europe/Berlin"}, expected: "yesterday at 2:00 PM", kind: "day", direction: "past", value: 1, unit: "day", calendarDays: -1},
+		{name: "last weekday", time: "2024-03-12T09:00:00+01:00", opts: HumanizeOptions{Timezone: "Europe/Berlin"}, expected: "last Tuesday", kind: "weekday", direction: "past", value: 2, unit: "day", calendarDays: -2},
+		{name: "next weekday", time: "2024-03-18T09:00:00+01:00", opts: HumanizeOptions{Timezone: "Europe/Berlin"}, expected: "next Monday", kind: "weekday", direction: "future", value: 4, unit: "day", calendarDays: 4},
+		{name: "weeks ago", time: "2024-02-29T10:00:00+01:00", expected: "2 weeks ago", kind: "numeric", direction: "past", value: 2, unit: "week", calendarDays: -14},
+		{name: "months ahead", time: "2024-07-14T10:00:00+02:00", expected: "in 4 months", kind: "numeric", direction: "future", value: 4, unit: "month", calendarDays: 122},
+		{name: "years ago", time: "2021-03-14T10:00:00+01:00", expected: "3 years ago", kind: "numeric", direction: "past", value: 3, unit: "year", calendarDays: -1096},
+		{name: "hours across midnight", time: "2024-03-13T22:00:00Z", opts: HumanizeOptions{Timezone: "UTC"}, expected: "yesterday at 10:00 PM", kind: "day", direction: "past", value: 1, unit: "day", calendarDays: -1},
+		{name: "numeric style", time: "2024-03-13T10:00:00+01:00", opts: HumanizeOptions{Style: "numeric"}, expected: "1 day ago", kind: "numeric", direction: "past", value: 1, unit: "day", calendarDays: -1},
+		{name: "calendar style today", time: "2024-03-14T16:30:00+01:00", opts: HumanizeOptions{Timezone: "Europe/Berlin", Style: "calendar"}, expected: "today at 4:30 PM", kind: "day", direction: "present", value: 0, unit: "day"},
+		{name: "calendar style distant date", time: "2024-01-02T10:00:00+01:00", opts: HumanizeOptions{Timezone: "Europe/Berlin", Style: "calendar"}, expected: "Jan 2, 2024", kind: "date", direction: "past", value: 72, unit: "day", calendarDays: -72},
+		{name: "day granularity", time: "2024-03-14T13:00:00+01:00", opts: HumanizeOptions{Granularity: "days", Style: "numeric"}, expected: "today", kind: "numeric", direction: "present", value: 0, unit: "day"},
+		{name: "floor rounding", time: "2024-03-14T12:50:00+01:00", opts: HumanizeOptions{Rounding: "floor"}, expected: "in 2 hours", kind: "numeric", direction: "future", value: 2, unit: "hour"},
+		{name: "custom threshold", time: "2024-03-14T10:50:00+01:00", opts: HumanizeOptions{Thresholds: RelativeThresholds{Minutes: 60}}, expected: "in 50 minutes", kind: "numeric", direction: "future", value: 50, unit: "minute"},
+		{name: "default threshold", time: "2024-03-14T10:50:00+01:00", expected: "in 1 hour", kind: "numeric", direction: "future", value: 1, unit: "hour"},
+		{name: "german", time: "2024-03-13T14:00:00+01:00", locale: "de", opts: HumanizeOptions{Timezone: "Europe/Berlin"}, expected: "gestern um 14:00", kind: "day", direction: "past", value: 1, unit: "day", calendarDays: -1},
+		{name: "german numeric", time: "2024-03-14T13:00:00+01:00", locale: "de", expected: "in 3 Stunden", kind: "numeric", direction: "future", value: 3, unit: "hour"},
+		{name: "brazilian feminine weekday", time: "2024-03-11T10:00:00+01:00", locale: "pt-BR", opts: HumanizeOptions{Timezone: "Europe/Berlin"}, expected: "segunda-feira passada", kind: "weekday", direction: "past", value: 3, unit: "day", calendarDays: -3},
+		{name: "japanese", time: "2024-03-14T07:00:00+01:00", locale: "ja", expected: "3 時間前", kind: "numeric", direction: "past", value: 3, unit: "hour"},
+		{name: "russian few", time: "2024-03-14T13:00:00+01:00", locale: "ru", expected: "через 3 часа", kind: "numeric", direction: "future", value: 3, unit: "hour"},
+		{name: "russian many", time: "2024-03-14T05:00:00+01:00", locale: "ru", expected: "5 часов назад", kind: "numeric", direction: "past", value: 5, unit: "hour"},
+		{name: "russian one after eleven", time: "2024-03-14T10:21:00+01:00", locale: "ru", expected: "через 21 минуту", kind: "numeric", direction: "future", value: 21, unit: "minute"},
+		{name: "polish many", time: "2024-03-14T10:12:00+01:00", locale: "pl", expected: "za 12 minut", kind: "numeric", direction: "future", value: 12, unit: "minute"},
+		{name: "french", time: "2024-03-14T11:00:00+01:00", locale: "fr", expected: "dans 1 heure", kind: "numeric", direction: "future", value: 1, unit: "hour"},
+		{name: "invalid granularity", time: reference, opts: HumanizeOptions{Granularity: "fortnight"}, wantErr: true},
+		{name: "invalid rounding", time: reference, opts: HumanizeOptions{Rounding: "up"}, wantErr: true},
+		{name: "invalid style", time: reference, opts: HumanizeOptions{Style: "poetic"}, wantErr: true},
+		{name: "invalid locale", time: reference, locale: "tlh", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.Humanize(tt.time, reference, tt.locale, tt.opts)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, but got %+v", result)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Phrase != tt.expected {
+				t.Errorf("Expected phrase %q, got %q", tt.expected, result.Phrase)
+			}
+			if result.Kind != tt.kind || result.Direction != tt.direction {
+				t.Errorf("Expected %s/%s, got %s/%s", tt.kind, tt.direction, result.Kind, result.Direction)
+			}
+			if result.Value != tt.value || result.Unit != tt.unit {
+				t.Errorf("Expected %d %s, got %d %s", tt.value, tt.unit, result.Value, result.Unit)
+			}
+			if tt.kind != "numeric" && result.Exact != float64(tt.value) {
+				t.Errorf("Expected exact %d for a calendar phrase, got %v", tt.value, result.Exact)
+			}
+			if result.CalendarDays != tt.calendarDays {
+				t.Errorf("Expected %d calendar days, got %d", tt.calendarDays, result.CalendarDays)
+			}
+		})
+	}
+}
+
+func TestLocalePluralCategory(t *testing.T) {
+	tests := []struct {
+		locale   string
+		n        int
+		expected string
+	}{
+		{"en", 1, "one"},
+		{"en", 0, "other"},
+		{"fr", 0, "one"},
+		{"pt", 1, "one"},
+		{"ru", 21, "one"},
+		{"ru", 11, "many"},
+		{"ru", 22, "few"},
+		{"ru", 12, "many"},
+		{"pl", 21, "many"},
+		{"pl", 22, "few"},
+		{"ja", 1, "other"},
+	}
+
+	for _, tt := range tests {
+		locale, err := lookupLocale(tt.locale)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := locale.pluralCategory(tt.n); got != tt.expected {
+			t.Errorf("%s %d: expected %s, got %s", tt.locale, tt.n, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ID types accepted by ExtractIDTimestamp
+const (
+	IDTypeAuto      = "auto"
+	IDTypeUUID      = "uuid"
+	IDTypeULID      = "ulid"
+	IDTypeKSUID     = "ksuid"
+	IDTypeObjectID  = "objectId"
+	IDTypeSnowflake = "snowflake"
+	IDTypeTwitter   = "twitter"
+	IDTypeDiscord   = "discord"
+)
+
+// idTypeAliases maps lowercase ID type names to ID types
+var idTypeAliases = map[string]string{
+	"": IDTypeAuto, "auto": IDTypeAuto,
+	"uuid": IDTypeUUID, "guid": IDTypeUUID,
+	"ulid":     IDTypeULID,
+	"ksuid":    IDTypeKSUID,
+	"objectid": IDTypeObjectID, "mongodb": IDTypeObjectID, "bson": IDTypeObjectID,
+	"snowflake": IDTypeSnowflake,
+	"twitter":   IDTypeTwitter, "x": IDTypeTwitter,
+	"discord": IDTypeDiscord,
+}
+
+// Epochs of the ID formats
+const (
+	uuidEpoch      = -12219292800 // 1582-10-15, in Unix seconds
+	ksuidEpoch     = 1400000000   // 2014-05-13T16:53:20Z, in Unix seconds
+	twitterEpochMs = 1288834974657
+	discordEpochMs = 1420070400000
+)
+
+// Alphabets of the text encodings of ULIDs and KSUIDs
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base62Alphabet    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// IDTimestamp is the creation time decoded from an identifier. Snowflake IDs
+// carry no marker of their epoch, so when the type is detected the Twitter
+// epoch is assumed and the Discord reading is listed in Alternatives.
+type IDTimestamp struct {
+	ID           string                 `json:"id"`
+	Type         string                 `json:"type"`
+	Time         ZonedTime              `json:"time"`
+	UnixMillis   int64                  `json:"unixMillis"`
+	Precision    string                 `json:"precision"`
+	Fields       map[string]interface{} `json:"fields"`
+	Ambiguous    bool                   `json:"ambiguous"`
+	Alternatives []IDReading            `json:"alternatives,omitempty"`
+}
+
+// IDReading is another reading of an ambiguous identifier
+type IDReading struct {
+	Type   string                 `json:"type"`
+	Time   ZonedTime              `json:"time"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// decodedID is the time and fields decoded from an identifier
+type decodedID struct {
+	kind      string
+	t         time.Time
+	precision string
+	fields    map[string]interface{}
+}
+
+// ExtractIDTimestamp decodes the creation time of a time-ordered identifier:
+// a UUID of version 1, 6 or 7, a ULID, a KSUID, a MongoDB ObjectID or a
+// Snowflake ID. The type is detected from the ID's shape unless given. For
+// Snowflake IDs, epoch sets a custom epoch as Unix milliseconds or a date;
+// otherwise the Twitter or Discord epoch is used. The time is shown in the
+// timezone.
+func (ts *timeService) ExtractIDTimestamp(id, idType, epoch, timezone string) (*IDTimestamp, error) {
+	id = strings.TrimSpace(id)
+	kind, ok := idTypeAliases[strings.ToLower(strings.TrimSpace(idType))]
+	if !ok {
+		return nil, NewTimeServiceError(ErrCodeInvalidID, fmt.Sprintf("unknown ID type '%s'", idType), "type", nil)
+	}
+	if id == "" {
+		return nil, NewInvalidIDError(id, "ID cannot be empty")
+	}
+
+	loc, err := ts.loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var customEpoch *time.Time
+	if epoch != "" {
+		t, err := ts.parseSnowflakeEpoch(epoch)
+		if err != nil {
+			return nil, err
+		}
+		customEpoch = &t
+	}
+
+	if kind == IDTypeAuto {
+		kind = detectIDType(id)
+		if kind == "" {
+			return nil, NewInvalidIDError(id, "not a UUID, ULID, KSUID, ObjectID or Snowflake ID")
+		}
+	}
+
+	decoded, err := decodeID(id, kind, customEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &IDTimestamp{
+		ID:         id,
+		Type:       decoded.kind,
+		Time:       newZonedTime(decoded.t.In(loc), timezone),
+		UnixMillis: decoded.t.UnixMilli(),
+		Precision:  decoded.precision,
+		Fields:     decoded.fields,
+	}
+
+	// A Snowflake ID could come from either service
+	if kind == IDTypeSnowflake && customEpoch == nil {
+		result.Ambiguous = true
+		if alt, err := decodeID(id, IDTypeDiscord, nil); err == nil {
+			result.Alternatives = append(result.Alternatives, IDReading{
+				Type:   alt.kind,
+				Time:   newZonedTime(alt.t.In(loc), timezone),
+				Fields: alt.fields,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// detectIDType recognises an ID type from its length and alphabet
+func detectIDType(id string) string {
+	switch {
+	case len(id) == 24 && isHex(id):
+		return IDTypeObjectID
+	case len(id) == 26 && strings.Trim(strings.ToUpper(id), crockfordAlphabet) == "":
+		return IDTypeULID
+	case len(id) == 27 && strings.Trim(id, base62Alphabet) == "":
+		return IDTypeKSUID
+	case len(id) <= 20 && strings.Trim(id, "0123456789") == "":
+		return IDTypeSnowflake
+	}
+	if _, err := uuid.Parse(id); err == nil {
+		return IDTypeUUID
+	}
+	return ""
+}
+
+// decodeID decodes an ID of a known type
+func decodeID(id, kind string, customEpoch *time.Time) (decodedID, error) {
+	switch kind {
+	case IDTypeUUID:
+		return decodeUUID(id)
+	case IDTypeULID:
+		return decodeULID(id)
+	case IDTypeKSUID:
+		return decodeKSUID(id)
+	case IDTypeObjectID:
+		return decodeObjectID(id)
+	}
+	return decodeSnowflake(id, kind, customEpoch)
+}
+
+// decodeUUID decodes the timestamp of a version 1, 6 or 7 UUID (RFC 9562)
+func decodeUUID(id string) (decodedID, error) {
+	u, err := uuid.Parse(id)
+	if err != nil {
+		return decodedID{}, NewInvalidIDError(id, err.Error())
+	}
+	if u.Variant() != uuid.RFC4122 {
+		return decodedID{}, NewInvalidIDError(id, fmt.Sprintf("UUID variant %s has no timestamp", u.Variant()))
+	}
+
+	version := int(u.Version())
+	fields := map[string]interface{}{
+		"version": version,
+		"variant": u.Variant().String(),
+	}
+
+	// Versions 1 and 6 count 100 ns intervals since 1582 in the same 60 bits,
+	// in different orders
+	var intervals uint64
+	switch version {
+	case 1:
+		intervals = uint64(binary.BigEndian.Uint16(u[6:8])&0x0FFF)<<48 |
+			uint64(binary.BigEndian.Uint16(u[4:6]))<<32 |
+			uint64(binary.BigEndian.Uint32(u[0:4]))
+	case 6:
+		intervals = uint64(binary.BigEndian.Uint32(u[0:4]))<<28 |
+			uint64(binary.BigEndian.Uint16(u[4:6]))<<12 |
+			uint64(binary.BigEndian.Uint16(u[6:8])&0x0FFF)
+	case 7:
+		ms := int64(binary.BigEndian.Uint64(u[0:8]) >> 16)
+		return decodedID{kind: "uuidv7", t: time.UnixMilli(ms).UTC(), precision: "millisecond", fields: fields}, nil
+	default:
+		return decodedID{}, NewInvalidIDError(id, fmt.Sprintf("UUID version %d has no timestamp", version))
+	}
+
+	node := u.NodeID()
+	fields["clockSequence"] = u.ClockSequence()
+	fields["node"] = formatMAC(node)
+	// The multicast bit marks a random node rather than a MAC address
+	fields["randomNode"] = node[0]&1 == 1
+
+	t := time.Unix(uuidEpoch+int64(intervals/1e7), int64(intervals%1e7)*100).UTC()
+	return decodedID{kind: fmt.Sprintf("uuidv%d", version), t: t, precision: "100ns", fields: fields}, nil
+}
+
+// decodeULID decodes a ULID: 48 bits of Unix milliseconds and 80 random bits
+// in Crockford's base 32
+func decodeULID(id string) (decodedID, error) {
+	if len(id) != 26 {
+		return decodedID{}, NewInvalidIDError(id, "a ULID has 26 characters")
+	}
+	n, ok := decodeBase(strings.ToUpper(id), crockfordAlphabet)
+	if !ok || n.BitLen() > 128 {
+		return decodedID{}, NewInvalidIDError(id, "invalid ULID")
+	}
+
+	b := n.FillBytes(make([]byte, 16))
+	ms := int64(binary.BigEndian.Uint64(append([]byte{0, 0}, b[:6]...)))
+	return decodedID{
+		kind:      IDTypeULID,
+		t:         time.UnixMilli(ms).UTC(),
+		precision: "millisecond",
+		fields:    map[string]interface{}{"randomness": hex.EncodeToString(b[6:])},
+	}, nil
+}
+
+// decodeKSUID decodes a KSUID: 32 bits of seconds since the KSUID epoch and
+// a 128-bit payload in base 62
+func decodeKSUID(id string) (decodedID, error) {
+	if len(id) != 27 {
+		return decodedID{}, NewInvalidIDError(id, "a KSUID has 27 characters")
+	}
+	n, ok := decodeBase(id, base62Alphabet)
+	if !ok || n.BitLen() > 160 {
+		return decodedID{}, NewInvalidIDError(id, "invalid KSUID")
+	}
+
+	b := n.FillBytes(make([]byte, 20))
+	seconds := int64(binary.BigEndian.Uint32(b[:4]))
+	return decodedID{
+		kind:      IDTypeKSUID,
+		t:         time.Unix(ksuidEpoch+seconds, 0).UTC(),
+		precision: "second",
+		fields:    map[string]interface{}{"payload": hex.EncodeToString(b[4:])},
+	}, nil
+}
+
+// decodeObjectID decodes a MongoDB ObjectID: 32 bits of Unix seconds, a
+// 5-byte value random per process and a 3-byte counter
+func decodeObjectID(id string) (decodedID, error) {
+	b, err := hex.DecodeString(id)
+	if err != nil || len(b) != 12 {
+		return decodedID{}, NewInvalidIDError(id, "an ObjectID has 24 hexadecimal digits")
+	}
+
+	return decodedID{
+		kind:      IDTypeObjectID,
+		t:         time.Unix(int64(binary.BigEndian.Uint32(b[:4])), 0).UTC(),
+		precision: "second",
+		fields: map[string]interface{}{
+			"processRandom": hex.EncodeToString(b[4:9]),
+			"counter":       int(b[9])<<16 | int(b[10])<<8 | int(b[11]),
+		},
+	}, nil
+}
+
+// decodeSnowflake decodes a Snowflake ID: 41 bits of milliseconds since the
+// epoch, 10 bits identifying the generator and a 12-bit sequence
+func decodeSnowflake(id, kind string, customEpoch *time.Time) (decodedID, error) {
+	n, err := strconv.ParseUint(id, 10, 63)
+	if err != nil {
+		return decodedID{}, NewInvalidIDError(id, "a Snowflake ID is a positive 64-bit integer")
+	}
+
+	ms := int64(n >> 22)
+	sequence := int(n & 0xFFF)
+	switch {
+	case customEpoch != nil:
+		return decodedID{
+			kind:      IDTypeSnowflake,
+			t:         customEpoch.Add(time.Duration(ms) * time.Millisecond).UTC(),
+			precision: "millisecond",
+			fields:    map[string]interface{}{"machineId": int(n >> 12 & 0x3FF), "sequence": sequence},
+		}, nil
+	case kind == IDTypeDiscord:
+		return decodedID{
+			kind:      IDTypeDiscord,
+			t:         time.UnixMilli(ms + discordEpochMs).UTC(),
+			precision: "millisecond",
+			fields:    map[string]interface{}{"workerId": int(n >> 17 & 0x1F), "processId": int(n >> 12 & 0x1F), "increment": sequence},
+		}, nil
+	}
+	return decodedID{
+		kind:      IDTypeTwitter,
+		t:         time.UnixMilli(ms + twitterEpochMs).UTC(),
+		precision: "millisecond",
+		fields:    map[string]interface{}{"datacenterId": int(n >> 17 & 0x1F), "workerId": int(n >> 12 & 0x1F), "sequence": sequence},
+	}, nil
+}
+
+// parseSnowflakeEpoch reads a custom Snowflake epoch given as Unix
+// milliseconds or as a date
+func (ts *timeService) parseSnowflakeEpoch(epoch string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(strings.TrimSpace(epoch), 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC(), nil
+	}
+
+	t, err := ts.parseInstant(epoch, time.UTC)
+	if err != nil {
+		return time.Time{}, NewTimeServiceError(ErrCodeInvalidID, fmt.Sprintf("invalid epoch '%s': expected Unix milliseconds or a date", epoch), "epoch", err)
+	}
+	return t.UTC(), nil
+}
+
+// decodeBase reads a big-endian number written in an alphabet
+func decodeBase(s, alphabet string) (*big.Int, bool) {
+	n := new(big.Int)
+	base := big.NewInt(int64(len(alphabet)))
+	for _, r := range s {
+		digit := strings.IndexRune(alphabet, r)
+		if digit < 0 {
+			return nil, false
+		}
+		n.Mul(n, base).Add(n, big.NewInt(int64(digit)))
+	}
+	return n, true
+}
+
+// formatMAC formats a node ID as a colon-separated MAC address
+func formatMAC(node []byte) string {
+	parts := make([]string, len(node))
+	for i, b := range node {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// isHex reports whether s consists of hexadecimal digits
+func isHex(s string) bool {
+	return strings.Trim(strings.ToLower(s), "0123456789abcdef") == ""
+}
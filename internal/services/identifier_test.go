@@ -0,0 +1,78 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTimeService_ExtractIDTimestamp(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name        string
+		id, idType  string
+		epoch       string
+		timezone    string
+		kind        string
+		expected    string
+		fields      map[string]interface{}
+		alternative string
+		wantErr     bool
+		errCode     int
+	}{
+		// Examples from RFC 9562, all for 2022-02-22 14:22:22 EST
+		{name: "uuid v1", id: "C232AB00-9414-11EC-B3C8-9F6BDECED846", kind: "uuidv1", expected: "2022-02-22T19:22:22Z", fields: map[string]interface{}{"version": 1, "clockSequence": 0x33C8, "node": "9f:6b:de:ce:d8:46", "randomNode": true}},
+		{name: "uuid v6", id: "1EC9414C-232A-6B00-B3C8-9F6BDECED846", kind: "uuidv6", expected: "2022-02-22T19:22:22Z", fields: map[string]interface{}{"version": 6, "clockSequence": 0x33C8}},
+		{name: "uuid v7", id: "017F22E2-79B0-7CC3-98C4-DC0C0C07398F", kind: "uuidv7", expected: "2022-02-22T19:22:22Z", fields: map[string]interface{}{"version": 7}},
+		{name: "uuid v7 in braces", id: "{017f22e2-79b0-7cc3-98c4-dc0c0c07398f}", kind: "uuidv7", expected: "2022-02-22T19:22:22Z"},
+		{name: "uuid v7 shown in a timezone", id: "017F22E279B07CC398C4DC0C0C07398F", timezone: "America/New_York", kind: "uuidv7", expected: "2022-02-22T14:22:22-05:00"},
+		{name: "ulid", id: "01ARZ3NDEKTSV4RRFFQ69G5FAV", kind: IDTypeULID, expected: "2016-07-30T23:54:10.259Z"},
+		{name: "ksuid", id: "0ujtsYcgvSTl8PAuAdqWYSMnLOv", kind: IDTypeKSUID, expected: "2017-10-10T04:00:47Z", fields: map[string]interface{}{"payload": "b5a1cd34b5f99d1154fb6853345c9735"}},
+		{name: "objectid", id: "507f1f77bcf86cd799439011", kind: IDTypeObjectID, expected: "2012-10-17T21:13:27Z", fields: map[string]interface{}{"counter": 0x439011, "processRandom": "bcf86cd799"}},
+		{name: "discord", id: "175928847299117063", idType: "discord", kind: IDTypeDiscord, expected: "2016-04-30T11:18:25.796Z", fields: map[string]interface{}{"workerId": 1, "processId": 0, "increment": 7}},
+		{name: "detected snowflake", id: "175928847299117063", kind: IDTypeTwitter, expected: "2012-03-03T13:01:20.453Z", alternative: "2016-04-30T11:18:25.796Z"},
+		{name: "custom epoch", id: "4194304", epoch: "2020-01-01", kind: IDTypeSnowflake, expected: "2020-01-01T00:00:00.001Z", fields: map[string]interface{}{"machineId": 0, "sequence": 0}},
+		{name: "custom epoch in milliseconds", id: "4194304", idType: "snowflake", epoch: "1577836800000", kind: IDTypeSnowflake, expected: "2020-01-01T00:00:00.001Z"},
+		{name: "uuid v4", id: "9b2f8c1e-3d4a-4f6b-8e7c-1a2b3c4d5e6f", wantErr: true, errCode: ErrCodeInvalidID},
+		{name: "ulid out of range", id: "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", idType: "ulid", wantErr: true, errCode: ErrCodeInvalidID},
+		{name: "unrecognised", id: "not-an-id", wantErr: true, errCode: ErrCodeInvalidID},
+		{name: "unknown type", id: "1", idType: "guid7", wantErr: true, errCode: ErrCodeInvalidID},
+		{name: "invalid epoch", id: "4194304", epoch: "someday", wantErr: true, errCode: ErrCodeInvalidID},
+		{name: "invalid timezone", id: "507f1f77bcf86cd799439011", timezone: "Mars/Olympus", wantErr: true, errCode: ErrCodeInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.ExtractIDTimestamp(tt.id, tt.idType, tt.epoch, tt.timezone)
+
+			if tt.wantErr {
+				var tsErr *TimeServiceError
+				if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+					t.Errorf("Expected error code %d, got %+v, %v", tt.errCode, result, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Type != tt.kind || result.Time.Time != tt.expected {
+				t.Errorf("Expected %s at %s, got %s at %s", tt.kind, tt.expected, result.Type, result.Time.Time)
+			}
+			for name, value := range tt.fields {
+				if result.Fields[name] != value {
+					t.Errorf("Expected %s %v, got %v", name, value, result.Fields[name])
+				}
+			}
+
+			if tt.alternative == "" {
+				if result.Ambiguous || len(result.Alternatives) > 0 {
+					t.Errorf("Unexpected alternatives %+v", result.Alternatives)
+				}
+			} else if !result.Ambiguous || len(result.Alternatives) != 1 || result.Alternatives[0].Time.Time != tt.alternative {
+				t.Errorf("Expected an alternative at %s, got %+v", tt.alternative, result.Alternatives)
+			}
+		})
+	}
+}
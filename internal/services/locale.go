@@ -0,0 +1,260 @@
+package services
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// Locale styles selecting a locale's default date and time patterns
+const (
+	StyleFull   = "full"
+	StyleLong   = "long"
+	StyleMedium = "medium"
+	StyleShort  = "short"
+)
+
+// nameKind identifies a localized name rendered by a format token
+type nameKind int
+
+const (
+	namesNone nameKind = iota
+	namesMonth
+	namesMonthAbbr
+	namesMonthStandalone
+	namesMonthStandaloneAbbr
+	namesWeekday
+	namesWeekdayAbbr
+	namesDayPeriod
+	namesDayPeriodLower
+)
+
+// localeData holds the names and default patterns of a locale, derived from
+// the CLDR Gregorian calendar data. Patterns use the Java/ICU pattern letters.
+type localeData struct {
+	Tag                         string            `json:"tag"`
+	Name                        string            `json:"name"`
+	Months                      []string          `json:"months"`
+	MonthsAbbreviated           []string          `json:"monthsAbbreviated"`
+	MonthsStandalone            []string          `json:"monthsStandalone,omitempty"`
+	MonthsStandaloneAbbreviated []string          `json:"monthsStandaloneAbbreviated,omitempty"`
+	Weekdays                    []string          `json:"weekdays"`
+	WeekdaysAbbreviated         []string          `json:"weekdaysAbbreviated"`
+	AM                          string            `json:"am"`
+	PM                          string            `json:"pm"`
+	DateFormats                 map[string]string `json:"dateFormats"`
+	TimeFormats                 map[string]string `json:"timeFormats"`
+	DateTimeFormats             map[string]string `json:"dateTimeFormats"`
+	RelativeTime                relativeTimeData  `json:"relativeTime"`
+}
+
+// relativeTimeData holds a locale's relative time phrases. Future and past
+// phrases are keyed by unit and plural category, with {0} for the number.
+type relativeTimeData struct {
+	Future      map[string]map[string]string `json:"future"`
+	Past        map[string]map[string]string `json:"past"`
+	Current     map[string]string            `json:"current"`
+	Yesterday   string                       `json:"yesterday"`
+	Today       string                       `json:"today"`
+	Tomorrow    string                       `json:"tomorrow"`
+	AtTime      string                       `json:"atTime"`
+	LastWeekday []string                     `json:"lastWeekday"`
+	NextWeekday []string                     `json:"nextWeekday"`
+}
+
+// builtinLocales holds the locales embedded in the binary, keyed by tag
+var builtinLocales = mustLoadBuiltinLocales()
+
+// mustLoadBuiltinLocales reads and checks the embedded locale files
+func mustLoadBuiltinLocales() map[string]*localeData {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("failed to read embedded locale data: %v", err))
+	}
+
+	locales := make(map[string]*localeData)
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile("locales/" + entry.Name())
+		if err != nil {
+			panic(fmt.Sprintf("failed to read embedded locale file %s: %v", entry.Name(), err))
+		}
+
+		var locale localeData
+		if err := json.Unmarshal(data, &locale); err != nil {
+			panic(fmt.Sprintf("failed to parse embedded locale file %s: %v", entry.Name(), err))
+		}
+
+		// Standalone month names default to the names used in dates
+		if locale.MonthsStandalone == nil {
+			locale.MonthsStandalone = locale.Months
+		}
+		if locale.MonthsStandaloneAbbreviated == nil {
+			locale.MonthsStandaloneAbbreviated = locale.MonthsAbbreviated
+		}
+
+		if err := locale.validate(); err != nil {
+			panic(fmt.Sprintf("invalid embedded locale file %s: %v", entry.Name(), err))
+		}
+		locales[strings.ToLower(locale.Tag)] = &locale
+	}
+
+	return locales
+}
+
+// validate checks that the locale has every name and pattern
+func (l *localeData) validate() error {
+	for _, months := range [][]string{l.Months, l.MonthsAbbreviated, l.MonthsStandalone, l.MonthsStandaloneAbbreviated} {
+		if len(months) != 12 {
+			return fmt.Errorf("expected 12 month names, got %d", len(months))
+		}
+	}
+	for _, weekdays := range [][]string{l.Weekdays, l.WeekdaysAbbreviated} {
+		if len(weekdays) != 7 {
+			return fmt.Errorf("expected 7 weekday names, got %d", len(weekdays))
+		}
+	}
+	for _, style := range []string{StyleFull, StyleLong, StyleMedium, StyleShort} {
+		if l.DateFormats[style] == "" || l.TimeFormats[style] == "" || l.DateTimeFormats[style] == "" {
+			return fmt.Errorf("missing %s date/time patterns", style)
+		}
+	}
+
+	rt := l.RelativeTime
+	if len(rt.LastWeekday) != 7 || len(rt.NextWeekday) != 7 {
+		return fmt.Errorf("expected 7 last and next weekday phrases")
+	}
+	if rt.Yesterday == "" || rt.Today == "" || rt.Tomorrow == "" || rt.AtTime == "" {
+		return fmt.Errorf("missing relative day phrases")
+	}
+	for _, unit := range relativeUnits {
+		if rt.Current[unit.name] == "" {
+			return fmt.Errorf("missing current %s phrase", unit.name)
+		}
+		for _, n := range []int{0, 1, 2, 5, 22} {
+			if rt.Future[unit.name][l.pluralCategory(n)] == "" || rt.Past[unit.name][l.pluralCategory(n)] == "" {
+				return fmt.Errorf("missing %s phrase for %d", unit.name, n)
+			}
+		}
+	}
+	return nil
+}
+
+// pluralCategory returns the CLDR plural category of a whole number in the
+// locale's language: "one", "few", "many" or "other"
+func (l *localeData) pluralCategory(n int) string {
+	language, _, _ := strings.Cut(strings.ToLower(l.Tag), "-")
+
+	switch language {
+	case "ja", "ko", "zh":
+		return "other"
+	case "fr", "pt":
+		if n <= 1 {
+			return "one"
+		}
+	case "ru", "pl":
+		mod10, mod100 := n%10, n%100
+		switch {
+		case n == 1 || (language == "ru" && mod10 == 1 && mod100 != 11):
+			return "one"
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return "few"
+		}
+		return "many"
+	default:
+		if n == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
+
+// SupportedLocales returns the tags of the built-in locales, sorted
+func SupportedLocales() []string {
+	tags := make([]string, 0, len(builtinLocales))
+	for _, locale := range builtinLocales {
+		tags = append(tags, locale.Tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// lookupLocale finds a locale by BCP 47 tag, e.g. "de", "pt-BR" or "ja_JP",
+// falling back from a regional tag to its language. An empty tag returns nil,
+// which formats in English.
+func lookupLocale(tag string) (*localeData, error) {
+	key := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if key == "" {
+		return nil, nil
+	}
+
+	if locale, ok := builtinLocales[key]; ok {
+		return locale, nil
+	}
+	if language, _, found := strings.Cut(key, "-"); found {
+		if locale, ok := builtinLocales[language]; ok {
+			return locale, nil
+		}
+	}
+
+	return nil, NewInvalidLocaleError(tag, "expected one of "+strings.Join(SupportedLocales(), ", "))
+}
+
+// name returns a localized month or weekday name or AM/PM marker for a time
+func (l *localeData) name(kind nameKind, t time.Time) string {
+	switch kind {
+	case namesMonth:
+		return l.Months[t.Month()-1]
+	case namesMonthAbbr:
+		return l.MonthsAbbreviated[t.Month()-1]
+	case namesMonthStandalone:
+		return l.MonthsStandalone[t.Month()-1]
+	case namesMonthStandaloneAbbr:
+		return l.MonthsStandaloneAbbreviated[t.Month()-1]
+	case namesWeekday:
+		return l.Weekdays[t.Weekday()]
+	case namesWeekdayAbbr:
+		return l.WeekdaysAbbreviated[t.Weekday()]
+	case namesDayPeriod, namesDayPeriodLower:
+		marker := l.AM
+		if t.Hour() >= 12 {
+			marker = l.PM
+		}
+		if kind == namesDayPeriodLower {
+			return strings.ToLower(marker)
+		}
+		return marker
+	}
+	return ""
+}
+
+// stylePattern returns the locale's Java/ICU pattern for a style format:
+// "full", "long", "medium" or "short" for date and time, or the same styles
+// prefixed with "date:" or "time:" for only one of them
+func (l *localeData) stylePattern(format string) (string, bool) {
+	kind, style, found := strings.Cut(strings.ToLower(strings.TrimSpace(format)), ":")
+	if !found {
+		kind, style = "", kind
+	}
+
+	datePattern, ok := l.DateFormats[style]
+	if !ok {
+		return "", false
+	}
+	timePattern := l.TimeFormats[style]
+
+	switch kind {
+	case "date":
+		return datePattern, true
+	case "time":
+		return timePattern, true
+	case "":
+		return strings.NewReplacer("{0}", timePattern, "{1}", datePattern).Replace(l.DateTimeFormats[style]), true
+	}
+	return "", false
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeService_FormatTimeLocale(t *testing.T) {
+	ts := NewTimeService()
+	// Tuesday 5 March 2024, 14:07:09 in Berlin
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	testTime := time.Date(2024, 3, 5, 14, 7, 9, 0, loc)
+
+	tests := []struct {
+		name     string
+		format   string
+		dialect  string
+		locale   string
+		expected string
+		wantErr  bool
+		errCode  int
+	}{
+		{name: "german default dialect", format: "dddd, D. MMMM YYYY", locale: "de", expected: "Dienstag, 5. März 2024"},
+		{name: "german abbreviations", format: "ddd D. MMM", locale: "de-DE", expected: "Di. 5. März"},
+		{name: "brazilian strftime", format: "%A, %d de %B de %Y", locale: "pt-BR", expected: "terça-feira, 05 de março de 2024"},
+		{name: "japanese java", format: "y年M月d日(E) a h:mm", dialect: "java", locale: "ja", expected: "2024年3月5日(火) 午後 2:07"},
+		{name: "underscore tag", format: "MMMM", locale: "fr_FR", expected: "mars"},
+		{name: "moment lowercase marker", format: "h:mm a", dialect: "moment", locale: "es", expected: "2:07 p. m."},
+		{name: "russian genitive month", format: "d MMMM", dialect: "java", locale: "ru", expected: "5 марта"},
+		{name: "russian standalone month", format: "LLLL", dialect: "java", locale: "ru", expected: "март"},
+		{name: "english without locale", format: "dddd MMMM A", expected: "Tuesday March PM"},
+		{name: "german full style", format: "full", locale: "de", expected: "Dienstag, 5. März 2024 um 14:07:09 Europe/Berlin"},
+		{name: "german medium style", format: "medium", locale: "de", expected: "05.03.2024, 14:07:09"},
+		{name: "brazilian long date", format: "date:long", locale: "pt-BR", expected: "5 de março de 2024"},
+		{name: "japanese short style", format: "short", locale: "ja", expected: "2024/03/05 14:07"},
+		{name: "english style without locale", format: "medium", expected: "Mar 5, 2024, 2:07:09 PM"},
+		{name: "english short time", format: "time:short", locale: "en-US", expected: "2:07 PM"},
+		{name: "korean medium time", format: "time:medium", locale: "ko", expected: "오후 2:07:09"},
+		{name: "english ordinal", format: "MMMM Do", dialect: "moment", locale: "en-GB", expected: "March 5th"},
+		{name: "ordinal in another language", format: "D MMMM Do", dialect: "moment", locale: "fr", wantErr: true, errCode: ErrCodeInvalidFormat},
+		{name: "quarter ordinal in another language", format: "QQQQ", dialect: "java", locale: "de", wantErr: true, errCode: ErrCodeInvalidFormat},
+		{name: "unknown locale", format: "MMMM", locale: "xx", wantErr: true, errCode: ErrCodeInvalidLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.FormatTime(testTime, tt.format, tt.dialect, tt.locale)
+
+			if tt.wantErr {
+				var tsErr *TimeServiceError
+				if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+					t.Errorf("Expected error code %d, got %q, %v", tt.errCode, result, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestBuiltinLocales(t *testing.T) {
+	testTime := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	for _, tag := range SupportedLocales() {
+		t.Run(tag, func(t *testing.T) {
+			locale, err := lookupLocale(tag)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			// Every style pattern must compile with the Java pattern letters
+			for _, style := range []string{"full", "long", "medium", "short", "date:full", "time:short"} {
+				pattern, ok := locale.stylePattern(style)
+				if !ok {
+					t.Fatalf("Missing %s pattern", style)
+				}
+				compiled, err := compileFormat(pattern, DialectJava)
+				if err != nil {
+					t.Fatalf("Pattern %q for %s does not compile: %v", pattern, style, err)
+				}
+				if compiled.FormatLocale(testTime, locale) == "" {
+					t.Errorf("Pattern %q for %s rendered nothing", pattern, style)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/zodimo/go-time-mcp/internal/astronomy"
+)
+
+// Names of the intermediate phases of the moon; the principal phases use
+// astronomy.Phase names
+const (
+	WaxingCrescent = "Waxing Crescent"
+	WaxingGibbous  = "Waxing Gibbous"
+	WaningGibbous  = "Waning Gibbous"
+	WaningCrescent = "Waning Crescent"
+)
+
+// Coordinates is a geographic location in decimal degrees, north and east
+// positive
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// MoonPhase describes the moon at an instant
+type MoonPhase struct {
+	Time         ZonedTime    `json:"time"`
+	Phase        string       `json:"phase"`
+	Illumination float64      `json:"illumination"`
+	Elongation   float64      `json:"elongation"`
+	AgeDays      float64      `json:"ageDays"`
+	Waxing       bool         `json:"waxing"`
+	NextNewMoon  ZonedTime    `json:"nextNewMoon"`
+	NextFullMoon ZonedTime    `json:"nextFullMoon"`
+	RiseSet      *MoonRiseSet `json:"riseSet,omitempty"`
+}
+
+// MoonRiseSet is the moonrise and moonset of a local day at a location. The
+// moon rises about 50 minutes later each day, so once a month a day has no
+// moonrise (state noRise) or no moonset (noSet); at high latitudes the moon
+// can also stay up (alwaysAbove) or down (alwaysBelow) all day.
+type MoonRiseSet struct {
+	Latitude  float64    `json:"latitude"`
+	Longitude float64    `json:"longitude"`
+	Date      string     `json:"date"`
+	Moonrise  *ZonedTime `json:"moonrise"`
+	Moonset   *ZonedTime `json:"moonset"`
+	State     string     `json:"state"`
+}
+
+// MoonPhase computes the illuminated fraction, phase name and age of the moon
+// and the next new and full moons. A date without a time of day is read as
+// local noon. The phase is named after a principal phase (New Moon, First
+// Quarter, Full Moon, Last Quarter) when that phase falls on the local date,
+// and after the crescent or gibbous in between otherwise. When a location is
+// given, the moonrise and moonset of the local date are added.
+func (ts *timeService) MoonPhase(date, timezone string, location *Coordinates) (*MoonPhase, error) {
+	if location != nil {
+		if err := validateCoordinates(location.Latitude, location.Longitude); err != nil {
+			return nil, err
+		}
+	}
+
+	loc, err := ts.loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	day, err := ts.parseCivilDate(date, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	instant := localTime(day.Year(), day.Month(), day.Day(), 12, 0, 0, loc)
+	if !isDateOnly(date) {
+		if instant, err = ts.parseInstant(date, loc); err != nil {
+			return nil, err
+		}
+	}
+
+	start := localTime(day.Year(), day.Month(), day.Day(), 0, 0, 0, loc)
+	end := localTime(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, loc)
+	zoned := func(t time.Time) *ZonedTime {
+		z := newZonedTime(t.In(loc), timezone)
+		return &z
+	}
+
+	fraction, elongation := astronomy.MoonIllumination(instant)
+	result := &MoonPhase{
+		Time:         *zoned(instant),
+		Phase:        moonPhaseName(elongation, start, end),
+		Illumination: math.Round(fraction*1000) / 1000,
+		Elongation:   math.Round(elongation*10) / 10,
+		AgeDays:      math.Round(astronomy.MoonAge(instant)*100) / 100,
+		Waxing:       elongation < 180,
+		NextNewMoon:  *zoned(astronomy.NextPhase(instant, astronomy.NewMoon)),
+		NextFullMoon: *zoned(astronomy.NextPhase(instant, astronomy.FullMoon)),
+	}
+
+	if location != nil {
+		c := astronomy.MoonRiseSet(start, end, location.Latitude, location.Longitude)
+		result.RiseSet = &MoonRiseSet{
+			Latitude:  location.Latitude,
+			Longitude: location.Longitude,
+			Date:      day.Format("2006-01-02"),
+			State:     c.State,
+		}
+		if !c.Rise.IsZero() {
+			result.RiseSet.Moonrise = zoned(c.Rise)
+		}
+		if !c.Set.IsZero() {
+			result.RiseSet.Moonset = zoned(c.Set)
+		}
+	}
+
+	return result, nil
+}
+
+// moonPhaseName names the phase of the moon on the local day from start to
+// end, given its elongation at the requested instant
+func moonPhaseName(elongation float64, start, end time.Time) string {
+	for _, phase := range []astronomy.Phase{astronomy.NewMoon, astronomy.FirstQuarter, astronomy.FullMoon, astronomy.LastQuarter} {
+		if at := astronomy.NextPhase(start.Add(-time.Nanosecond), phase); at.Before(end) {
+			return phase.String()
+		}
+	}
+
+	switch {
+	case elongation < 90:
+		return WaxingCrescent
+	case elongation < 180:
+		return WaxingGibbous
+	case elongation < 270:
+		return WaningGibbous
+	}
+	return WaningCrescent
+}
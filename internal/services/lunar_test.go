@@ -0,0 +1,84 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zodimo/go-time-mcp/internal/astronomy"
+)
+
+func TestTimeService_MoonPhase(t *testing.T) {
+	ts := NewTimeService()
+	newYork := &Coordinates{Latitude: 40.7128, Longitude: -74.0060}
+	london := &Coordinates{Latitude: 51.5074, Longitude: -0.1278}
+
+	tests := []struct {
+		name           string
+		date, timezone string
+		location       *Coordinates
+		phase          string
+		waxing         bool
+		nextNewMoon    string // UTC to the minute
+		state          string
+		moonrise       string // Local moonrise to the minute
+		wantErr        bool
+		errCode        int
+	}{
+		{name: "full moon", date: "2024-01-25", phase: "Full Moon", waxing: true, nextNewMoon: "2024-02-09T22:59"},
+		{name: "waning after full moon", date: "2024-01-26T06:00:00Z", phase: WaningGibbous, nextNewMoon: "2024-02-09T22:59"},
+		{name: "waxing gibbous", date: "2024-01-21", phase: WaxingGibbous, waxing: true, nextNewMoon: "2024-02-09T22:59"},
+		{name: "waning crescent", date: "2024-01-08", phase: WaningCrescent, nextNewMoon: "2024-01-11T11:57"},
+		{name: "principal phase on the local date", date: "2024-01-26", timezone: "Pacific/Auckland", phase: "Full Moon"},
+		{name: "day before in another timezone", date: "2024-01-25", timezone: "Pacific/Auckland", phase: WaxingGibbous, waxing: true},
+		{name: "instant after new moon", date: "2024-01-11T18:00:00Z", phase: "New Moon", waxing: true, nextNewMoon: "2024-02-09T22:59"},
+		{name: "moonrise and moonset", date: "2024-06-21", timezone: "America/New_York", location: newYork, phase: "Full Moon", waxing: true, state: astronomy.CrossingNormal, moonrise: "2024-06-21T20:49"},
+		{name: "no moonset", date: "2024-03-14", timezone: "Europe/London", location: london, phase: WaxingCrescent, waxing: true, state: astronomy.NoSet},
+		{name: "invalid coordinates", date: "2024-01-25", location: &Coordinates{Latitude: -95}, wantErr: true, errCode: ErrCodeInvalidCoordinates},
+		{name: "invalid date", date: "someday", wantErr: true, errCode: ErrCodeInvalidTime},
+		{name: "invalid timezone", date: "2024-01-25", timezone: "Mars/Olympus", wantErr: true, errCode: ErrCodeInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.MoonPhase(tt.date, tt.timezone, tt.location)
+
+			if tt.wantErr {
+				var tsErr *TimeServiceError
+				if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+					t.Errorf("Expected error code %d, got %+v, %v", tt.errCode, result, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Phase != tt.phase || result.Waxing != tt.waxing {
+				t.Errorf("Expected %s (waxing %v), got %s (waxing %v)", tt.phase, tt.waxing, result.Phase, result.Waxing)
+			}
+			if result.Illumination < 0 || result.Illumination > 1 || result.AgeDays < 0 || result.AgeDays > 29.6 {
+				t.Errorf("Unexpected illumination %v and age %v", result.Illumination, result.AgeDays)
+			}
+			if tt.nextNewMoon != "" && result.NextNewMoon.Time[:16] != tt.nextNewMoon {
+				t.Errorf("Expected next new moon at %s, got %s", tt.nextNewMoon, result.NextNewMoon.Time)
+			}
+
+			if (result.RiseSet != nil) != (tt.location != nil) {
+				t.Fatalf("Unexpected rise and set %+v", result.RiseSet)
+			}
+			if tt.location == nil {
+				return
+			}
+			if result.RiseSet.State != tt.state {
+				t.Errorf("Expected state %s, got %s", tt.state, result.RiseSet.State)
+			}
+			if tt.moonrise != "" && (result.RiseSet.Moonrise == nil || result.RiseSet.Moonrise.Time[:16] != tt.moonrise) {
+				t.Errorf("Expected moonrise at %s, got %+v", tt.moonrise, result.RiseSet.Moonrise)
+			}
+			if tt.state == astronomy.NoSet && result.RiseSet.Moonset != nil {
+				t.Errorf("Expected no moonset, got %+v", result.RiseSet.Moonset)
+			}
+		})
+	}
+}
@@ -0,0 +1,401 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits on meeting slot searches
+const (
+	maxMeetingParticipants = 20
+	maxMeetingRangeDays    = 31
+	defaultMeetingSlots    = 10
+	maxMeetingSlots        = 50
+	defaultMeetingStep     = 30
+	defaultWorkingHours    = "09:00-17:00"
+)
+
+// MeetingParticipant describes when one attendee is available
+type MeetingParticipant struct {
+	Name         string // Label used in the result, defaults to the timezone
+	Timezone     string // Timezone the working hours are in
+	WorkingHours string // Local window such as "09:00-17:00" or "22:00-06:00", default 09:00-17:00
+	Calendars    string // Comma-separated holiday calendars, weekends only when empty
+	Weekend      string // Weekend override as a country code or weekday list
+}
+
+// MeetingOptions controls a meeting slot search
+type MeetingOptions struct {
+	Participants []MeetingParticipant
+	Duration     string // Meeting length, e.g. "45m" or "PT1H"
+	From         string // Start of the search range, default now
+	To           string // End of the search range, default seven days after From
+	Timezone     string // Timezone of the range and of the slot times, default UTC
+	Step         int    // Minutes between candidate start times, default 30
+	Limit        int    // Slots to return, default 10
+}
+
+// MeetingPlan lists the best meeting slots found in a range
+type MeetingPlan struct {
+	Duration   string        `json:"duration"`
+	From       ZonedTime     `json:"from"`
+	To         ZonedTime     `json:"to"`
+	Slots      []MeetingSlot `json:"slots"`
+	Count      int           `json:"count"`
+	Candidates int           `json:"candidates"`
+}
+
+// MeetingSlot is a time that falls within every participant's working hours
+type MeetingSlot struct {
+	Start        ZonedTime         `json:"start"`
+	End          ZonedTime         `json:"end"`
+	Score        float64           `json:"score"`
+	Participants []MeetingAttendee `json:"participants"`
+}
+
+// MeetingAttendee is a slot in one participant's local time. Comfort is 1 in
+// the middle of their working hours and 0 at either edge.
+type MeetingAttendee struct {
+	Name    string    `json:"name"`
+	Start   ZonedTime `json:"start"`
+	End     ZonedTime `json:"end"`
+	Comfort float64   `json:"comfort"`
+}
+
+// interval is a half-open span of instants
+type interval struct {
+	start, end time.Time
+}
+
+// meetingAvailability holds a participant's working intervals within a search
+type meetingAvailability struct {
+	name      string
+	timezone  string
+	loc       *time.Location
+	intervals []interval
+}
+
+// FindMeetingSlots finds times within every participant's working hours on
+// their business days. Slots are ranked by how close they fall to the middle
+// of each participant's day, favouring the participant worst off, with
+// earlier slots first among equals.
+func (ts *timeService) FindMeetingSlots(opts MeetingOptions) (*MeetingPlan, error) {
+	switch {
+	case len(opts.Participants) == 0:
+		return nil, NewTimeServiceError(ErrCodeTimeOperation, "at least one participant is required", "participants", nil)
+	case len(opts.Participants) > maxMeetingParticipants:
+		return nil, NewTimeServiceError(ErrCodeTimeOperation, fmt.Sprintf("too many participants: at most %d are supported", maxMeetingParticipants), "participants", nil)
+	}
+
+	d, err := parseCalendarDuration(opts.Duration)
+	if err != nil {
+		return nil, err
+	}
+	length := d.clock + time.Duration(d.days)*24*time.Hour
+	if d.months != 0 || length <= 0 || length > 24*time.Hour {
+		return nil, NewInvalidDurationError(opts.Duration, "meeting length must be positive and at most 24 hours")
+	}
+
+	step := opts.Step
+	if step == 0 {
+		step = defaultMeetingStep
+	}
+	if step < 1 || step > 24*60 {
+		return nil, NewTimeServiceError(ErrCodeTimeOperation, fmt.Sprintf("invalid step %d: must be between 1 and 1440 minutes", opts.Step), "step", nil)
+	}
+
+	limit := opts.Limit
+	if limit == 0 {
+		limit = defaultMeetingSlots
+	}
+	if limit < 1 || limit > maxMeetingSlots {
+		return nil, NewTimeServiceError(ErrCodeTimeOperation, fmt.Sprintf("invalid limit %d: must be between 1 and %d", opts.Limit, maxMeetingSlots), "limit", nil)
+	}
+
+	loc, err := ts.loadLocation(opts.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	from := time.Now().In(loc)
+	if opts.From != "" {
+		if from, err = ts.parseInstant(opts.From, loc); err != nil {
+			return nil, err
+		}
+	}
+	to := from.AddDate(0, 0, 7)
+	if opts.To != "" {
+		if to, err = ts.parseInstant(opts.To, loc); err != nil {
+			return nil, err
+		}
+		if isDateOnly(opts.To) {
+			// A date includes the whole day
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+	switch {
+	case !to.After(from):
+		return nil, NewTimeServiceError(ErrCodeTimeOperation, "the end of the range must be after its start", "to", nil)
+	case to.Sub(from) > maxMeetingRangeDays*24*time.Hour:
+		return nil, NewTimeServiceError(ErrCodeTimeOperation, fmt.Sprintf("the range cannot exceed %d days", maxMeetingRangeDays), "to", nil)
+	}
+
+	// Each participant's working intervals, intersected into common ones
+	participants := make([]meetingAvailability, len(opts.Participants))
+	var common []interval
+	for i, p := range opts.Participants {
+		a, err := ts.meetingAvailability(p, from, to)
+		if err != nil {
+			return nil, err
+		}
+		participants[i] = a
+
+		if i == 0 {
+			common = a.intervals
+		} else {
+			common = intersectIntervals(common, a.intervals)
+		}
+	}
+
+	// Candidate starts on the step grid within each common interval
+	type candidate struct {
+		start   time.Time
+		score   float64
+		comfort []float64
+	}
+	var candidates []candidate
+	stepSeconds := int64(step) * 60
+	for _, iv := range common {
+		first := maxTime(iv.start, from)
+		last := minTime(iv.end, to).Add(-length)
+
+		unix := first.Unix()
+		if rem := ((unix % stepSeconds) + stepSeconds) % stepSeconds; rem != 0 || first.Nanosecond() != 0 {
+			unix += stepSeconds - rem
+		}
+		for start := time.Unix(unix, 0); !start.After(last); start = start.Add(time.Duration(step) * time.Minute) {
+			c := candidate{start: start, comfort: make([]float64, len(participants))}
+			lowest, total := 1.0, 0.0
+			for j, a := range participants {
+				c.comfort[j] = a.comfort(start, length)
+				lowest = math.Min(lowest, c.comfort[j])
+				total += c.comfort[j]
+			}
+			c.score = roundScore((lowest + total/float64(len(participants))) / 2)
+			candidates = append(candidates, c)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].start.Before(candidates[j].start)
+	})
+
+	plan := &MeetingPlan{
+		Duration:   length.String(),
+		From:       newZonedTime(from.In(loc), opts.Timezone),
+		To:         newZonedTime(to.In(loc), opts.Timezone),
+		Slots:      make([]MeetingSlot, 0, min(limit, len(candidates))),
+		Candidates: len(candidates),
+	}
+	for _, c := range candidates[:min(limit, len(candidates))] {
+		end := c.start.Add(length)
+		slot := MeetingSlot{
+			Start:        newZonedTime(c.start.In(loc), opts.Timezone),
+			End:          newZonedTime(end.In(loc), opts.Timezone),
+			Score:        c.score,
+			Participants: make([]MeetingAttendee, len(participants)),
+		}
+		for j, a := range participants {
+			slot.Participants[j] = MeetingAttendee{
+				Name:    a.name,
+				Start:   newZonedTime(c.start.In(a.loc), a.timezone),
+				End:     newZonedTime(end.In(a.loc), a.timezone),
+				Comfort: roundScore(c.comfort[j]),
+			}
+		}
+		plan.Slots = append(plan.Slots, slot)
+	}
+	plan.Count = len(plan.Slots)
+
+	return plan, nil
+}
+
+// meetingAvailability returns the working intervals of a participant that
+// overlap a range. A window that ends before it starts runs past midnight
+// and belongs to the business day it starts on.
+func (ts *timeService) meetingAvailability(p MeetingParticipant, from, to time.Time) (meetingAvailability, error) {
+	loc, err := ts.loadLocation(p.Timezone)
+	if err != nil {
+		return meetingAvailability{}, err
+	}
+
+	bd, err := ts.resolveBusinessDays(p.Calendars, p.Weekend)
+	if err != nil {
+		return meetingAvailability{}, err
+	}
+
+	hours := p.WorkingHours
+	if hours == "" {
+		hours = defaultWorkingHours
+	}
+	opens, closes, err := parseWorkingHours(hours)
+	if err != nil {
+		return meetingAvailability{}, err
+	}
+	if closes <= opens {
+		closes += 24 * 60
+	}
+
+	a := meetingAvailability{name: p.Name, timezone: p.Timezone, loc: loc}
+	if a.name == "" {
+		a.name = p.Timezone
+	}
+	if a.name == "" {
+		a.name = "UTC"
+	}
+
+	first, last := from.In(loc), to.In(loc)
+	for day := civilDate(first.Year(), first.Month(), first.Day()-1); !day.After(civilDate(last.Year(), last.Month(), last.Day())); day = day.AddDate(0, 0, 1) {
+		if !bd.isBusinessDay(day) {
+			continue
+		}
+
+		y, m, d := day.Date()
+		iv := interval{
+			start: localTime(y, m, d, opens/60, opens%60, 0, loc),
+			end:   localTime(y, m, d+closes/(24*60), closes%(24*60)/60, closes%60, 0, loc),
+		}
+		if !iv.end.After(from) || !iv.start.Before(to) {
+			continue
+		}
+
+		// Back-to-back windows, such as around-the-clock hours, form one interval
+		if n := len(a.intervals); n > 0 && !a.intervals[n-1].end.Before(iv.start) {
+			a.intervals[n-1].end = iv.end
+			continue
+		}
+		a.intervals = append(a.intervals, iv)
+	}
+
+	return a, nil
+}
+
+// comfort scores a meeting by its place within the participant's working
+// interval: 1 when centred, falling to 0 when it touches either edge
+func (a meetingAvailability) comfort(start time.Time, length time.Duration) float64 {
+	for _, iv := range a.intervals {
+		if start.Before(iv.start) || start.Add(length).After(iv.end) {
+			continue
+		}
+		room := iv.end.Sub(iv.start) - length
+		if room <= 0 {
+			return 1
+		}
+		position := float64(start.Sub(iv.start)) / float64(room)
+		return 1 - math.Abs(2*position-1)
+	}
+	return 0
+}
+
+// parseWorkingHours parses a window such as "09:00-17:30" or "9-17" into
+// minutes after midnight. The end may be "24:00".
+func parseWorkingHours(hours string) (int, int, error) {
+	invalid := func() (int, int, error) {
+		return 0, 0, NewTimeServiceError(
+			ErrCodeTimeOperation,
+			fmt.Sprintf("invalid working hours '%s': expected a window such as '09:00-17:00'", hours),
+			"workingHours",
+			nil,
+		)
+	}
+
+	opens, closes, found := strings.Cut(strings.ReplaceAll(hours, " ", ""), "-")
+	if !found {
+		return invalid()
+	}
+	start, ok := parseClockMinutes(opens)
+	if !ok || start >= 24*60 {
+		return invalid()
+	}
+	end, ok := parseClockMinutes(closes)
+	if !ok || end == start {
+		return invalid()
+	}
+	return start, end % (24 * 60), nil
+}
+
+// parseClockMinutes parses "9", "09:30" or "24:00" into minutes after midnight
+func parseClockMinutes(clock string) (int, bool) {
+	h, m, _ := strings.Cut(clock, ":")
+	hour, err := strconv.Atoi(h)
+	if err != nil || hour < 0 || hour > 24 {
+		return 0, false
+	}
+	minute := 0
+	if m != "" {
+		if minute, err = strconv.Atoi(m); err != nil || len(m) != 2 || minute > 59 {
+			return 0, false
+		}
+	}
+	if hour == 24 && minute != 0 {
+		return 0, false
+	}
+	return hour*60 + minute, true
+}
+
+// intersectIntervals returns the spans covered by both sorted interval lists
+func intersectIntervals(a, b []interval) []interval {
+	var result []interval
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := maxTime(a[i].start, b[j].start), minTime(a[i].end, b[j].end)
+		if start.Before(end) {
+			result = append(result, interval{start: start, end: end})
+		}
+		if a[i].end.Before(b[j].end) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// isDateOnly reports whether a range bound was given as a calendar date
+// such as "2024-06-07" or "20240607", with no time of day
+func isDateOnly(input string) bool {
+	input = strings.TrimSpace(input)
+	for _, layout := range []string{"2006-01-02", "20060102"} {
+		if _, err := time.Parse(layout, input); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// roundScore rounds a score to two decimal places
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
+
+// maxTime returns the later of two times
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// minTime returns the earlier of two times
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTimeService_FindMeetingSlots(t *testing.T) {
+	ts := NewTimeService()
+
+	newYork := MeetingParticipant{Name: "Ana", Timezone: "America/New_York"}
+	london := MeetingParticipant{Name: "Ben", Timezone: "Europe/London"}
+
+	tests := []struct {
+		name       string
+		opts       MeetingOptions
+		expected   []string
+		local      []string // Local start of each participant in the first slot
+		candidates int
+		wantErr    bool
+		errCode    int
+	}{
+		{
+			name:       "overlap ranked towards the middle of both days",
+			opts:       MeetingOptions{Participants: []MeetingParticipant{newYork, london}, Duration: "30m", From: "2024-06-03", To: "2024-06-03", Limit: 3},
+			expected:   []string{"2024-06-03T14:00:00Z", "2024-06-03T14:30:00Z", "2024-06-03T13:30:00Z"},
+			local:      []string{"2024-06-03T10:00:00-04:00", "2024-06-03T15:00:00+01:00"},
+			candidates: 6,
+		},
+		{
+			name:       "unix seconds end is an instant",
+			opts:       MeetingOptions{Participants: []MeetingParticipant{newYork, london}, Duration: "30m", From: "2024-06-03", To: "1717423200", Limit: 3},
+			expected:   []string{"2024-06-03T13:30:00Z", "2024-06-03T13:00:00Z"},
+			candidates: 2,
+		},
+		{
+			name:       "holiday removes a day",
+			opts:       MeetingOptions{Participants: []MeetingParticipant{{Timezone: "America/New_York", Calendars: "us"}, london}, Duration: "PT1H", From: "2024-07-04", To: "2024-07-05", Limit: 1},
+			expected:   []string{"2024-07-05T14:00:00Z"},
+			candidates: 5,
+		},
+		{
+			name: "window past midnight",
+			opts: MeetingOptions{
+				Participants: []MeetingParticipant{
+					{Timezone: "Asia/Tokyo", WorkingHours: "08:00-12:00"},
+					{Timezone: "America/Los_Angeles", WorkingHours: "15:00-01:00"},
+				},
+				Duration: "1h", From: "2024-06-04T00:00:00Z", To: "2024-06-05T00:00:00Z", Step: 60, Timezone: "UTC", Limit: 1,
+			},
+			expected:   []string{"2024-06-04T01:00:00Z"},
+			local:      []string{"2024-06-04T10:00:00+09:00", "2024-06-03T18:00:00-07:00"},
+			candidates: 4,
+		},
+		{
+			name:       "no overlap",
+			opts:       MeetingOptions{Participants: []MeetingParticipant{{Timezone: "Asia/Tokyo"}, {Timezone: "America/Los_Angeles"}}, Duration: "30m", From: "2024-06-03", To: "2024-06-07"},
+			expected:   []string{},
+			candidates: 0,
+		},
+		{name: "no participants", opts: MeetingOptions{Duration: "30m"}, wantErr: true, errCode: ErrCodeTimeOperation},
+		{name: "invalid working hours", opts: MeetingOptions{Participants: []MeetingParticipant{{WorkingHours: "9am to 5pm"}}, Duration: "30m"}, wantErr: true, errCode: ErrCodeTimeOperation},
+		{name: "invalid duration", opts: MeetingOptions{Participants: []MeetingParticipant{newYork}, Duration: "P1M"}, wantErr: true, errCode: ErrCodeInvalidDuration},
+		{name: "unknown calendar", opts: MeetingOptions{Participants: []MeetingParticipant{{Calendars: "mars"}}, Duration: "30m"}, wantErr: true, errCode: ErrCodeInvalidCalendar},
+		{name: "range too long", opts: MeetingOptions{Participants: []MeetingParticipant{newYork}, Duration: "30m", From: "2024-01-01", To: "2024-03-01"}, wantErr: true, errCode: ErrCodeTimeOperation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.FindMeetingSlots(tt.opts)
+
+			if tt.wantErr {
+				var tsErr *TimeServiceError
+				if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+					t.Errorf("Expected error code %d, got %+v, %v", tt.errCode, result, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Candidates != tt.candidates {
+				t.Errorf("Expected %d candidates, got %d", tt.candidates, result.Candidates)
+			}
+			if len(result.Slots) != len(tt.expected) {
+				t.Fatalf("Expected %d slots, got %+v", len(tt.expected), result.Slots)
+			}
+			for i, expected := range tt.expected {
+				if result.Slots[i].Start.Time != expected {
+					t.Errorf("Slot %d: expected %s, got %s", i, expected, result.Slots[i].Start.Time)
+				}
+			}
+			for i, expected := range tt.local {
+				if got := result.Slots[0].Participants[i].Start.Time; got != expected {
+					t.Errorf("Participant %d: expected local start %s, got %s", i, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseWorkingHours(t *testing.T) {
+	tests := []struct {
+		input      string
+		start, end int
+		wantErr    bool
+	}{
+		{input: "09:00-17:30", start: 540, end: 1050},
+		{input: "9 - 17", start: 540, end: 1020},
+		{input: "22:00-06:00", start: 1320, end: 360},
+		{input: "00:00-24:00", start: 0, end: 0},
+		{input: "09:00-09:00", wantErr: true},
+		{input: "25:00-26:00", wantErr: true},
+		{input: "9:5-17", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		start, end, err := parseWorkingHours(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: unexpected error %v", tt.input, err)
+			continue
+		}
+		if !tt.wantErr && (start != tt.start || end != tt.end) {
+			t.Errorf("%q: expected %d-%d, got %d-%d", tt.input, tt.start, tt.end, start, end)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeInterpretation is one possible reading of a timestamp string
+type TimeInterpretation struct {
+	RFC3339     string `json:"rfc3339"`
+	Unix        int64  `json:"unix"`
+	Layout      string `json:"layout"`
+	Description string `json:"description"`
+}
+
+// ParsedTime is the result of parsing a timestamp string.
+// Result is only set when the input has exactly one interpretation; ambiguous
+// inputs list every interpretation in Candidates instead.
+type ParsedTime struct {
+	Input      string               `json:"input"`
+	Ambiguous  bool                 `json:"ambiguous"`
+	Result     *TimeInterpretation  `json:"result,omitempty"`
+	Candidates []TimeInterpretation `json:"candidates,omitempty"`
+}
+
+// detectionLayout is a layout tried during automatic format detection
+type detectionLayout struct {
+	layout      string
+	description string
+}
+
+// detectionLayouts lists the layouts tried, in order, when no format is given
+var detectionLayouts = []detectionLayout{
+	{time.RFC3339Nano, "RFC3339"},
+	{"2006-01-02T15:04:05", "ISO 8601 extended (local time)"},
+	{"2006-01-02T15:04", "ISO 8601 extended (local time, minutes)"},
+	{"2006-01-02 15:04:05Z07:00", "ISO 8601 extended (space separated)"},
+	{"2006-01-02 15:04:05", "ISO 8601 extended (space separated, local time)"},
+	{"2006-01-02 15:04", "ISO 8601 extended (space separated, minutes)"},
+	{"2006-01-02", "ISO 8601 calendar date"},
+	{"20060102T150405Z0700", "ISO 8601 basic"},
+	{"20060102T150405Z", "ISO 8601 basic (UTC)"},
+	{"20060102T150405", "ISO 8601 basic (local time)"},
+	{"20060102", "ISO 8601 basic calendar date"},
+	{time.RFC1123, "RFC1123"},
+	{time.RFC1123Z, "RFC1123 with numeric zone"},
+	{time.RFC850, "RFC850"},
+	{time.RFC822, "RFC822"},
+	{time.RFC822Z, "RFC822 with numeric zone"},
+	{time.ANSIC, "ANSI C"},
+	{time.UnixDate, "Unix date"},
+	{time.RubyDate, "Ruby date"},
+	{"02/Jan/2006:15:04:05 -0700", "Common Log Format"},
+	{"2006/01/02 15:04:05", "Go log timestamp"},
+	{"2006-01-02 15:04:05,000", "log4j/Python logging timestamp"},
+	{"01/02/2006 15:04:05", "US date (month/day/year) with time"},
+	{"01/02/2006 15:04", "US date (month/day/year) with time"},
+	{"01/02/2006", "US date (month/day/year)"},
+	{"02/01/2006 15:04:05", "European date (day/month/year) with time"},
+	{"02/01/2006 15:04", "European date (day/month/year) with time"},
+	{"02/01/2006", "European date (day/month/year)"},
+	{"02.01.2006 15:04:05", "European dotted date with time"},
+	{"02.01.2006", "European dotted date"},
+}
+
+// ParseTime parses a timestamp string into a canonical instant.
+// With a format the input must match it exactly; otherwise common layouts and
+// Unix epoch values are detected automatically. Inputs without an offset are
+// interpreted in the given timezone.
+func (ts *timeService) ParseTime(input, format, dialect, timezone string) (*ParsedTime, error) {
+	loc, err := ts.loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var detected []detectedTime
+	if format != "" {
+		parsed, err := ts.parseTimeInput(input, format, dialect, loc)
+		if err != nil {
+			return nil, err
+		}
+		detected = append(detected, detectedTime{parsed, format, "user format"})
+	} else {
+		trimmed := strings.TrimSpace(input)
+		if trimmed == "" {
+			return nil, NewInvalidTimeError(input, "time value cannot be empty", nil)
+		}
+		if detected, err = ts.detectTime(trimmed, loc); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(detected) == 0 {
+		return nil, NewInvalidTimeError(input, "no known format matched", nil)
+	}
+
+	candidates := make([]TimeInterpretation, len(detected))
+	for i, d := range detected {
+		candidates[i] = newTimeInterpretation(d.time, timezone, loc, d.layout, d.description)
+	}
+
+	result := &ParsedTime{Input: input}
+	if len(candidates) == 1 {
+		result.Result = &candidates[0]
+		return result, nil
+	}
+
+	result.Ambiguous = true
+	result.Candidates = candidates
+	return result, nil
+}
+
+// parseInstant resolves a single unambiguous instant from input, accepting "now",
+// "today" (midnight in loc) and any automatically detected layout
+func (ts *timeService) parseInstant(input string, loc *time.Location) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return time.Time{}, NewInvalidTimeError(input, "time value cannot be empty", nil)
+	}
+
+	switch {
+	case strings.EqualFold(input, "now"):
+		return time.Now().In(loc), nil
+	case strings.EqualFold(input, "today"):
+		y, m, d := time.Now().In(loc).Date()
+		return localTime(y, m, d, 0, 0, 0, loc), nil
+	}
+
+	detected, err := ts.detectTime(input, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch len(detected) {
+	case 0:
+		return time.Time{}, NewInvalidTimeError(input, "no known format matched", nil)
+	case 1:
+		return detected[0].time, nil
+	default:
+		interpretations := make([]string, len(detected))
+		for i, d := range detected {
+			interpretations[i] = fmt.Sprintf("%s (%s)", d.time.Format(time.RFC3339), d.description)
+		}
+		return time.Time{}, NewInvalidTimeError(
+			input,
+			fmt.Sprintf("ambiguous, could be %s", strings.Join(interpretations, " or ")),
+			nil,
+		)
+	}
+}
+
+// detectedTime is an instant matched by one of the detection layouts
+type detectedTime struct {
+	time        time.Time
+	layout      string
+	description string
+}
+
+// detectTime returns every distinct interpretation of input across the
+// detection layouts. A zone abbreviation that is not one of loc's is looked up
+// in the service's abbreviation table, so an unknown or ambiguous abbreviation
+// is an error rather than UTC.
+func (ts *timeService) detectTime(input string, loc *time.Location) ([]detectedTime, error) {
+	var detected []detectedTime
+	seen := make(map[int64]bool)
+
+	add := func(t time.Time, layout, description string) {
+		key := t.UnixNano()
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		detected = append(detected, detectedTime{t, layout, description})
+	}
+
+	for _, dl := range detectionLayouts {
+		parsed, err := time.ParseInLocation(dl.layout, input, loc)
+		if err != nil {
+			continue
+		}
+		if strings.Contains(dl.layout, "MST") {
+			if parsed, err = ts.resolveParsedAbbreviation(parsed, loc); err != nil {
+				return nil, err
+			}
+		}
+		add(parsed, dl.layout, dl.description)
+	}
+
+	if t, layout, description, ok := parseUnixEpoch(input); ok {
+		add(t, layout, description)
+	}
+
+	return detected, nil
+}
+
+// resolveParsedAbbreviation reads the wall-clock time of t in the zone its
+// abbreviation names. time.Parse only knows UTC and the abbreviations of loc;
+// any other it records with a zero offset.
+func (ts *timeService) resolveParsedAbbreviation(t time.Time, loc *time.Location) (time.Time, error) {
+	abbreviation, offset := t.Zone()
+	if offset != 0 || t.Location() == loc || t.Location() == time.UTC {
+		return t, nil
+	}
+
+	zone, err := ts.zones.Resolve(abbreviation)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), zone), nil
+}
+
+// parseUnixEpoch interprets an integer string as a Unix epoch, choosing the
+// unit by magnitude. Eight digits that form a valid date are an ISO 8601
+// basic date rather than seconds in 1970.
+func parseUnixEpoch(input string) (time.Time, string, string, bool) {
+	digits := input
+	if len(digits) > 0 && (digits[0] == '-' || digits[0] == '+') {
+		digits = digits[1:]
+	}
+	if digits == "" {
+		return time.Time{}, "", "", false
+	}
+	if len(input) == 8 {
+		if _, err := time.Parse("20060102", input); err == nil {
+			return time.Time{}, "", "", false
+		}
+	}
+
+	value, err := strconv.ParseInt(input, 10, 64)
+	if err != nil {
+		return time.Time{}, "", "", false
+	}
+
+	switch n := len(digits); {
+	case n <= 11:
+		return time.Unix(value, 0).UTC(), "unix-seconds", "Unix seconds", true
+	case n <= 14:
+		return time.UnixMilli(value).UTC(), "unix-milliseconds", "Unix milliseconds", true
+	case n <= 17:
+		return time.UnixMicro(value).UTC(), "unix-microseconds", "Unix microseconds", true
+	default:
+		return time.Unix(0, value).UTC(), "unix-nanoseconds", "Unix nanoseconds", true
+	}
+}
+
+// newTimeInterpretation renders t in the requested timezone, or its own offset when none is given
+func newTimeInterpretation(t time.Time, timezone string, loc *time.Location, layout, description string) TimeInterpretation {
+	if timezone != "" {
+		t = t.In(loc)
+	}
+
+	return TimeInterpretation{
+		RFC3339:     t.Format(time.RFC3339Nano),
+		Unix:        t.Unix(),
+		Layout:      layout,
+		Description: description,
+	}
+}
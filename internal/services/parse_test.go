@@ -0,0 +1,218 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeService_ParseTime(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name           string
+		input          string
+		format         string
+		timezone       string
+		expected       string
+		expectedLayout string
+		ambiguous      int
+		wantErr        bool
+	}{
+		{
+			name:           "RFC3339",
+			input:          "2024-01-15T14:30:45Z",
+			expected:       "2024-01-15T14:30:45Z",
+			expectedLayout: "2006-01-02T15:04:05.999999999Z07:00",
+		},
+		{
+			name:           "RFC1123",
+			input:          "Mon, 15 Jan 2024 14:30:45 GMT",
+			expected:       "2024-01-15T14:30:45Z",
+			expectedLayout: "Mon, 02 Jan 2006 15:04:05 MST",
+		},
+		{
+			name:           "RFC1123 with a zone abbreviation",
+			input:          "Mon, 15 Jan 2024 14:30:45 PST",
+			expected:       "2024-01-15T14:30:45-08:00",
+			expectedLayout: "Mon, 02 Jan 2006 15:04:05 MST",
+		},
+		{
+			name:           "Unix date with a zone abbreviation",
+			input:          "Mon Jan 15 14:30:45 EST 2024",
+			timezone:       "Europe/Berlin",
+			expected:       "2024-01-15T20:30:45+01:00",
+			expectedLayout: "Mon Jan _2 15:04:05 MST 2006",
+		},
+		{
+			name:           "Abbreviation of the timezone",
+			input:          "Mon Jan 15 14:30:45 EST 2024",
+			timezone:       "America/New_York",
+			expected:       "2024-01-15T14:30:45-05:00",
+			expectedLayout: "Mon Jan _2 15:04:05 MST 2006",
+		},
+		{
+			name:    "Ambiguous zone abbreviation",
+			input:   "Mon, 15 Jan 2024 14:30:45 IST",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown zone abbreviation",
+			input:   "Mon, 15 Jan 2024 14:30:45 XYZ",
+			wantErr: true,
+		},
+		{
+			name:           "Surrounding whitespace",
+			input:          "  2024-01-02  ",
+			expected:       "2024-01-02T00:00:00Z",
+			expectedLayout: "2006-01-02",
+		},
+		{
+			name:           "ISO 8601 basic",
+			input:          "20240115T143045Z",
+			expected:       "2024-01-15T14:30:45Z",
+			expectedLayout: "20060102T150405Z0700",
+		},
+		{
+			name:           "ISO 8601 extended in timezone",
+			input:          "2024-01-15T14:30:45",
+			timezone:       "Europe/Berlin",
+			expected:       "2024-01-15T14:30:45+01:00",
+			expectedLayout: "2006-01-02T15:04:05",
+		},
+		{
+			name:           "ISO 8601 basic date is not Unix seconds",
+			input:          "20240115",
+			expected:       "2024-01-15T00:00:00Z",
+			expectedLayout: "20060102",
+		},
+		{
+			name:           "Eight digits that are not a date",
+			input:          "20241350",
+			expected:       "1970-08-23T06:35:50Z",
+			expectedLayout: "unix-seconds",
+		},
+		{
+			name:           "Unix seconds",
+			input:          "1705329045",
+			expected:       "2024-01-15T14:30:45Z",
+			expectedLayout: "unix-seconds",
+		},
+		{
+			name:           "Unix milliseconds",
+			input:          "1705329045123",
+			expected:       "2024-01-15T14:30:45.123Z",
+			expectedLayout: "unix-milliseconds",
+		},
+		{
+			name:           "Unix nanoseconds",
+			input:          "1705329045123456789",
+			expected:       "2024-01-15T14:30:45.123456789Z",
+			expectedLayout: "unix-nanoseconds",
+		},
+		{
+			name:           "Common Log Format",
+			input:          "15/Jan/2024:14:30:45 +0000",
+			expected:       "2024-01-15T14:30:45Z",
+			expectedLayout: "02/Jan/2006:15:04:05 -0700",
+		},
+		{
+			name:           "Unambiguous European date",
+			input:          "15/01/2024",
+			expected:       "2024-01-15T00:00:00Z",
+			expectedLayout: "02/01/2006",
+		},
+		{
+			name:           "Explicit format",
+			input:          "15.01.2024",
+			format:         "DD.MM.YYYY",
+			expected:       "2024-01-15T00:00:00Z",
+			expectedLayout: "DD.MM.YYYY",
+		},
+		{
+			name:      "Ambiguous day and month",
+			input:     "01/02/2024",
+			ambiguous: 2,
+		},
+		{
+			name:    "Unrecognised input",
+			input:   "sometime next week",
+			wantErr: true,
+		},
+		{
+			name:    "Input not matching explicit format",
+			input:   "2024-01-15",
+			format:  "DD/MM/YYYY",
+			wantErr: true,
+		},
+		{
+			name:     "Invalid timezone",
+			input:    "2024-01-15",
+			timezone: "Invalid/Timezone",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.ParseTime(tt.input, tt.format, "", tt.timezone)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for input %s, but got none", tt.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error for input %s: %v", tt.input, err)
+				return
+			}
+
+			if tt.ambiguous > 0 {
+				if !result.Ambiguous || result.Result != nil {
+					t.Errorf("Expected ambiguous result for input %s", tt.input)
+				}
+				if len(result.Candidates) != tt.ambiguous {
+					t.Errorf("Expected %d candidates, got %d", tt.ambiguous, len(result.Candidates))
+				}
+				return
+			}
+
+			if result.Ambiguous || result.Result == nil {
+				t.Fatalf("Expected a single interpretation for input %s, got %+v", tt.input, result.Candidates)
+			}
+
+			if result.Result.RFC3339 != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result.Result.RFC3339)
+			}
+
+			if result.Result.Layout != tt.expectedLayout {
+				t.Errorf("Expected layout %s, got %s", tt.expectedLayout, result.Result.Layout)
+			}
+		})
+	}
+}
+
+func TestTimeService_ParseTimeCustomAbbreviations(t *testing.T) {
+	resolver, err := NewZoneResolver(map[string]string{"IST": "Asia/Kolkata"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ts := NewTimeService(WithZoneResolver(resolver)).(*timeService)
+
+	result, err := ts.ParseTime("Mon, 15 Jan 2024 14:30:45 IST", "", "", "UTC")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := result.Result.RFC3339; got != "2024-01-15T09:00:45Z" {
+		t.Errorf("Expected 2024-01-15T09:00:45Z, got %s", got)
+	}
+
+	instant, err := ts.parseInstant("Mon Jan 15 14:30:45 IST 2024", time.UTC)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := instant.UTC().Format(time.RFC3339); got != "2024-01-15T09:00:45Z" {
+		t.Errorf("Expected 2024-01-15T09:00:45Z, got %s", got)
+	}
+}
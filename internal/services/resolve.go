@@ -0,0 +1,324 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// offsetPattern matches fixed UTC offsets such as "+05:30", "-0700", "+5",
+// "UTC+5", "UTC-03:30" and "GMT+8"
+var offsetPattern = regexp.MustCompile(`^(?i:UTC|GMT|UT)?\s*([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// maxOffsetSeconds is the largest UTC offset accepted (real zones range from -12:00 to +14:00)
+const maxOffsetSeconds = 14 * 3600
+
+// hintPattern matches a timezone qualified with a region or country hint, e.g. "IST (IN)"
+var hintPattern = regexp.MustCompile(`^(.+?)\s*\(([^()]*)\)$`)
+
+// abbreviationData maps common timezone abbreviations to the zones they may
+// refer to, each with the fixed offset the abbreviation denotes there
+//
+//go:embed zoneinfo/abbreviations.json
+var abbreviationData []byte
+
+// abbreviationCandidate is one zone an abbreviation may refer to
+type abbreviationCandidate struct {
+	Zone    string `json:"zone"`
+	Offset  string `json:"offset"`
+	Country string `json:"country"`
+	Name    string `json:"name"`
+
+	loc *time.Location
+}
+
+// String describes the candidate, including the hint that selects it
+func (c abbreviationCandidate) String() string {
+	var parts []string
+	for _, part := range []string{c.Offset, c.Zone} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	desc := strings.Join(parts, ", ")
+	if c.Name != "" {
+		desc = fmt.Sprintf("%s (%s)", c.Name, desc)
+	}
+	if c.Country != "" {
+		desc = fmt.Sprintf("%s: %s", c.Country, desc)
+	}
+	return desc
+}
+
+// matchesHint reports whether a region or country hint selects the candidate.
+// A hint may be a country code ("IN"), country name ("India"), IANA region
+// ("Asia") or IANA zone ("Asia/Kolkata").
+func (c abbreviationCandidate) matchesHint(hint string) bool {
+	switch {
+	case c.Country != "" && strings.EqualFold(c.Country, hint):
+		return true
+	case c.Country != "" && strings.EqualFold(getZoneCatalog().countryNames[c.Country], hint):
+		return true
+	case strings.EqualFold(c.Zone, hint):
+		return true
+	case c.Zone != "" && strings.HasPrefix(strings.ToLower(c.Zone), strings.ToLower(hint)+"/"):
+		return true
+	}
+	return false
+}
+
+// sameOffset reports whether two candidates resolve to the same fixed offset or zone
+func (c abbreviationCandidate) sameOffset(other abbreviationCandidate) bool {
+	if c.Offset != "" || other.Offset != "" {
+		return c.Offset == other.Offset
+	}
+	return c.Zone == other.Zone
+}
+
+// defaultZoneResolver resolves zones using only the default abbreviation table
+var defaultZoneResolver = func() *ZoneResolver {
+	r, err := NewZoneResolver(nil)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default abbreviation table: %v", err))
+	}
+	return r
+}()
+
+// ZoneResolver turns the timezone names accepted by the tools into locations.
+//
+// Names are resolved in this order:
+//   - Abbreviations from the abbreviation table, e.g. "PST" is a fixed -08:00
+//   - "UTC", "GMT", "UT" and "Z" resolve to UTC
+//   - Fixed offsets: "+05:30", "-0700", "+5", "UTC+5", "UTC-03:30", "GMT+8"
+//   - IANA names such as "America/New_York", matched case-insensitively
+//
+// Abbreviations win over IANA names with the same spelling, so "CET" is a
+// fixed +01:00 rather than the IANA zone that also observes summer time.
+// Abbreviations used in several places with different offsets, such as "IST"
+// (India, Israel or Ireland), need a region or country hint: "IST (IN)".
+//
+// "GMT+8" follows the everyday convention and means UTC+08:00. The IANA
+// "Etc/GMT+8" zone uses the inverted POSIX sign convention and means UTC-08:00;
+// it is resolved as an IANA name and keeps that meaning.
+type ZoneResolver struct {
+	abbreviations map[string][]abbreviationCandidate
+}
+
+// NewZoneResolver creates a resolver using the embedded abbreviation table,
+// with entries replaced or added by the given abbreviations. Each abbreviation
+// maps to a fixed offset ("-08:00") or an IANA name ("America/Los_Angeles").
+func NewZoneResolver(abbreviations map[string]string) (*ZoneResolver, error) {
+	var table map[string][]abbreviationCandidate
+	if err := json.Unmarshal(abbreviationData, &table); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded abbreviation table: %w", err)
+	}
+
+	for abbr, zone := range abbreviations {
+		key := strings.ToUpper(strings.TrimSpace(abbr))
+		if key == "" {
+			return nil, NewInvalidTimezoneError(abbr, fmt.Errorf("abbreviation cannot be empty"))
+		}
+
+		zone = strings.TrimSpace(zone)
+		if offsetPattern.MatchString(zone) {
+			table[key] = []abbreviationCandidate{{Offset: zone}}
+		} else {
+			table[key] = []abbreviationCandidate{{Zone: zone}}
+		}
+	}
+
+	r := &ZoneResolver{abbreviations: make(map[string][]abbreviationCandidate, len(table))}
+	for key, candidates := range table {
+		for i, c := range candidates {
+			spec := c.Offset
+			if spec == "" {
+				spec = c.Zone
+			}
+
+			loc, err := resolveZoneName(spec)
+			if err != nil {
+				return nil, NewInvalidTimezoneError(spec, fmt.Errorf("abbreviation %s: %w", key, err))
+			}
+
+			// Name fixed offsets after the abbreviation so times format as "PST"
+			if c.Offset != "" {
+				_, offset := time.Time{}.In(loc).Zone()
+				loc = time.FixedZone(key, offset)
+			}
+			candidates[i].loc = loc
+		}
+
+		r.abbreviations[key] = candidates
+	}
+
+	return r, nil
+}
+
+// LoadTimezoneAbbreviationsFile loads an abbreviation table from a JSON or YAML
+// file mapping abbreviations to offsets or IANA names
+func LoadTimezoneAbbreviationsFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, NewTimeServiceError(ErrCodeInvalidTimezone, fmt.Sprintf("failed to read abbreviations file '%s'", path), "timezone", err)
+	}
+
+	var abbreviations map[string]string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &abbreviations)
+	default:
+		err = json.Unmarshal(data, &abbreviations)
+	}
+	if err != nil {
+		return nil, NewTimeServiceError(ErrCodeInvalidTimezone, fmt.Sprintf("failed to parse abbreviations file '%s'", path), "timezone", err)
+	}
+
+	return abbreviations, nil
+}
+
+// Resolve returns the location for a timezone name; an empty name is UTC.
+// The name may carry a region or country hint in parentheses, e.g. "IST (IN)",
+// which only matters for ambiguous abbreviations.
+func (r *ZoneResolver) Resolve(timezone string) (*time.Location, error) {
+	name := strings.TrimSpace(timezone)
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	var hint string
+	if m := hintPattern.FindStringSubmatch(name); m != nil {
+		name, hint = m[1], strings.TrimSpace(m[2])
+	}
+
+	if candidates, ok := r.abbreviations[strings.ToUpper(name)]; ok {
+		return resolveAbbreviation(strings.ToUpper(name), hint, candidates)
+	}
+
+	loc, err := resolveZoneName(name)
+	if err != nil {
+		tzErr := NewInvalidTimezoneError(timezone, err)
+		if suggestions := suggestTimezones(name); len(suggestions) > 0 {
+			tzErr.Message += fmt.Sprintf(" (did you mean %s?)", strings.Join(suggestions, ", "))
+		}
+		return nil, tzErr
+	}
+
+	return loc, nil
+}
+
+// resolveAbbreviation picks the candidate an abbreviation refers to. A hint is
+// only needed, and only applied, when the candidates disagree on the offset.
+func resolveAbbreviation(abbr, hint string, candidates []abbreviationCandidate) (*time.Location, error) {
+	if unambiguous(candidates) {
+		return candidates[0].loc, nil
+	}
+
+	descriptions := make([]string, len(candidates))
+	for i, c := range candidates {
+		descriptions[i] = c.String()
+	}
+
+	if hint == "" {
+		return nil, NewAmbiguousTimezoneError(abbr, "pass a region or country hint such as '"+candidates[0].Country+"'", descriptions)
+	}
+
+	var matches []abbreviationCandidate
+	for _, c := range candidates {
+		if c.matchesHint(hint) {
+			matches = append(matches, c)
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		return nil, NewAmbiguousTimezoneError(abbr, fmt.Sprintf("no candidate matches region '%s'", hint), descriptions)
+	case !unambiguous(matches):
+		return nil, NewAmbiguousTimezoneError(abbr, fmt.Sprintf("region '%s' matches several candidates", hint), descriptions)
+	}
+
+	return matches[0].loc, nil
+}
+
+// unambiguous reports whether all candidates share the same offset
+func unambiguous(candidates []abbreviationCandidate) bool {
+	for _, c := range candidates[1:] {
+		if !c.sameOffset(candidates[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// QualifyTimezone adds a region or country hint to a timezone name, in the
+// "IST (IN)" form understood by the resolver
+func QualifyTimezone(timezone, region string) string {
+	timezone = strings.TrimSpace(timezone)
+	region = strings.TrimSpace(region)
+	if timezone == "" || region == "" || hintPattern.MatchString(timezone) {
+		return timezone
+	}
+	return fmt.Sprintf("%s (%s)", timezone, region)
+}
+
+// resolveZoneName resolves a fixed offset or IANA name, without abbreviations
+func resolveZoneName(name string) (*time.Location, error) {
+	switch strings.ToUpper(name) {
+	case "UTC", "GMT", "UT", "Z":
+		return time.UTC, nil
+	}
+
+	if m := offsetPattern.FindStringSubmatch(name); m != nil {
+		return parseFixedOffset(m)
+	}
+
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc, nil
+	}
+
+	// Fall back to a case-insensitive match against the zone catalog
+	for _, entry := range getZoneCatalog().entries {
+		if strings.EqualFold(entry.name, name) {
+			return entry.loc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown time zone %s", name)
+}
+
+// parseFixedOffset builds a fixed zone from an offsetPattern match
+func parseFixedOffset(m []string) (*time.Location, error) {
+	hours, _ := strconv.Atoi(m[2])
+	minutes := 0
+	if m[3] != "" {
+		minutes, _ = strconv.Atoi(m[3])
+	}
+
+	if minutes >= 60 {
+		return nil, fmt.Errorf("offset minutes must be below 60")
+	}
+
+	seconds := hours*3600 + minutes*60
+	if seconds > maxOffsetSeconds {
+		return nil, fmt.Errorf("offset must be between -14:00 and +14:00")
+	}
+
+	if seconds == 0 {
+		return time.UTC, nil
+	}
+
+	sign := m[1]
+	if sign == "-" {
+		seconds = -seconds
+	}
+
+	return time.FixedZone(fmt.Sprintf("UTC%s%02d:%02d", sign, hours, minutes), seconds), nil
+}
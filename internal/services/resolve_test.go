@@ -0,0 +1,220 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestZoneResolver_Resolve(t *testing.T) {
+	r, err := NewZoneResolver(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A winter instant, so IANA zones report their standard offsets
+	instant := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		timezone       string
+		expectedOffset int
+		expectedAbbr   string
+		wantErr        bool
+	}{
+		{timezone: "", expectedOffset: 0, expectedAbbr: "UTC"},
+		{timezone: "Z", expectedOffset: 0, expectedAbbr: "UTC"},
+		{timezone: "UTC", expectedOffset: 0, expectedAbbr: "UTC"},
+		{timezone: "America/New_York", expectedOffset: -5 * 3600, expectedAbbr: "EST"},
+		{timezone: "america/new_york", expectedOffset: -5 * 3600, expectedAbbr: "EST"},
+		{timezone: "+05:30", expectedOffset: 5*3600 + 1800, expectedAbbr: "UTC+05:30"},
+		{timezone: "-0700", expectedOffset: -7 * 3600, expectedAbbr: "UTC-07:00"},
+		{timezone: "+5", expectedOffset: 5 * 3600, expectedAbbr: "UTC+05:00"},
+		{timezone: "UTC-03:30", expectedOffset: -3*3600 - 1800, expectedAbbr: "UTC-03:30"},
+		{timezone: "utc+0545", expectedOffset: 5*3600 + 2700, expectedAbbr: "UTC+05:45"},
+		{timezone: "GMT+8", expectedOffset: 8 * 3600, expectedAbbr: "UTC+08:00"},
+		{timezone: "+00:00", expectedOffset: 0, expectedAbbr: "UTC"},
+		{timezone: "Etc/GMT+8", expectedOffset: -8 * 3600, expectedAbbr: "-08"},
+		{timezone: "PST", expectedOffset: -8 * 3600, expectedAbbr: "PST"},
+		{timezone: "pdt", expectedOffset: -7 * 3600, expectedAbbr: "PDT"},
+		{timezone: "CEST", expectedOffset: 2 * 3600, expectedAbbr: "CEST"},
+		{timezone: "+15:00", wantErr: true},
+		{timezone: "+05:60", wantErr: true},
+		{timezone: "UTC+", wantErr: true},
+		{timezone: "XYZ", wantErr: true},
+		{timezone: "Invalid/Timezone", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			loc, err := r.Resolve(tt.timezone)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q, but got none", tt.timezone)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			abbr, offset := instant.In(loc).Zone()
+			if offset != tt.expectedOffset {
+				t.Errorf("Expected offset %d, got %d", tt.expectedOffset, offset)
+			}
+			if abbr != tt.expectedAbbr {
+				t.Errorf("Expected abbreviation %s, got %s", tt.expectedAbbr, abbr)
+			}
+		})
+	}
+}
+
+func TestZoneResolver_CustomAbbreviations(t *testing.T) {
+	r, err := NewZoneResolver(map[string]string{
+		"ist": "Asia/Jerusalem",
+		"NPT": "+05:45",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loc, err := r.Resolve("IST")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if loc.String() != "Asia/Jerusalem" {
+		t.Errorf("Expected IST override to Asia/Jerusalem, got %s", loc)
+	}
+
+	loc, err = r.Resolve("NPT")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if abbr, offset := time.Now().In(loc).Zone(); abbr != "NPT" || offset != 5*3600+2700 {
+		t.Errorf("Expected NPT at +05:45, got %s %d", abbr, offset)
+	}
+
+	if _, err := NewZoneResolver(map[string]string{"BAD": "Nowhere/City"}); err == nil {
+		t.Errorf("Expected error for abbreviation with unknown zone, but got none")
+	}
+}
+
+func TestLoadTimezoneAbbreviationsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "abbreviations.yaml")
+	if err := os.WriteFile(path, []byte("NPT: \"+05:45\"\nSAST: Africa/Johannesburg\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	abbreviations, err := LoadTimezoneAbbreviationsFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if abbreviations["NPT"] != "+05:45" || abbreviations["SAST"] != "Africa/Johannesburg" {
+		t.Errorf("Unexpected abbreviations %v", abbreviations)
+	}
+
+	if _, err := LoadTimezoneAbbreviationsFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("Expected error for missing file, but got none")
+	}
+}
+
+func TestTimeService_FixedOffsetsWorkEndToEnd(t *testing.T) {
+	ts := NewTimeService()
+
+	for _, timezone := range []string{"+05:30", "-0800", "PST", "GMT+2", "Z"} {
+		if err := ts.ValidateTimezone(timezone); err != nil {
+			t.Fatalf("Unexpected validation error for %s: %v", timezone, err)
+		}
+		if _, err := ts.GetCurrentTime(timezone); err != nil {
+			t.Errorf("Validated timezone %s failed in GetCurrentTime: %v", timezone, err)
+		}
+	}
+
+	result, err := ts.ConvertTime("2024-01-15T12:00:00Z", "", "", "UTC", "+05:30")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.To.Time != "2024-01-15T17:30:00+05:30" {
+		t.Errorf("Expected 2024-01-15T17:30:00+05:30, got %s", result.To.Time)
+	}
+}
+
+func TestZoneResolver_AmbiguousAbbreviations(t *testing.T) {
+	r, err := NewZoneResolver(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		timezone       string
+		expectedOffset int
+		wantErr        bool
+	}{
+		{timezone: "IST", wantErr: true},
+		{timezone: "IST (IN)", expectedOffset: 5*3600 + 1800},
+		{timezone: "ist (Israel)", expectedOffset: 2 * 3600},
+		{timezone: "IST (Europe)", expectedOffset: 3600},
+		{timezone: "CST (Asia/Shanghai)", expectedOffset: 8 * 3600},
+		{timezone: "CST (America)", wantErr: true},
+		{timezone: "CST (JP)", wantErr: true},
+		{timezone: "PST (PH)", expectedOffset: -8 * 3600},
+		{timezone: "America/New_York (IN)", expectedOffset: -5 * 3600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			loc, err := r.Resolve(tt.timezone)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q, but got none", tt.timezone)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			_, offset := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC).In(loc).Zone()
+			if offset != tt.expectedOffset {
+				t.Errorf("Expected offset %d, got %d", tt.expectedOffset, offset)
+			}
+		})
+	}
+
+	_, err = r.Resolve("IST")
+	var tsErr *TimeServiceError
+	if !errors.As(err, &tsErr) || tsErr.Code != ErrCodeAmbiguousZone {
+		t.Fatalf("Expected ambiguous timezone error, got %v", err)
+	}
+	for _, candidate := range []string{"Asia/Kolkata", "Asia/Jerusalem", "Europe/Dublin"} {
+		if !strings.Contains(err.Error(), candidate) {
+			t.Errorf("Expected candidate %s in error %q", candidate, err)
+		}
+	}
+}
+
+func TestQualifyTimezone(t *testing.T) {
+	tests := []struct {
+		timezone string
+		region   string
+		expected string
+	}{
+		{"IST", "IN", "IST (IN)"},
+		{"IST", "", "IST"},
+		{"", "IN", ""},
+		{"IST (IL)", "IN", "IST (IL)"},
+	}
+
+	for _, tt := range tests {
+		if got := QualifyTimezone(tt.timezone, tt.region); got != tt.expected {
+			t.Errorf("QualifyTimezone(%q, %q) = %q, expected %q", tt.timezone, tt.region, got, tt.expected)
+		}
+	}
+}
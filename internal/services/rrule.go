@@ -0,0 +1,858 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxOccurrences is the default cap on the occurrences returned by one
+// recurrence expansion
+const DefaultMaxOccurrences = 1000
+
+// Recurrence frequencies, from coarsest to finest
+const (
+	freqYearly = iota
+	freqMonthly
+	freqWeekly
+	freqDaily
+	freqHourly
+	freqMinutely
+	freqSecondly
+)
+
+var recurFrequencies = []string{"YEARLY", "MONTHLY", "WEEKLY", "DAILY", "HOURLY", "MINUTELY", "SECONDLY"}
+
+var recurWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// Limits that stop the expansion of rules that rarely or never match
+const (
+	recurSearchYears = 400
+	recurMaxPeriods  = 5000000
+)
+
+// RecurrenceOptions controls how a recurrence rule is expanded
+type RecurrenceOptions struct {
+	Start    string   // DTSTART, unless given in the rule text
+	Timezone string   // Timezone of a DTSTART without an offset or TZID, default UTC
+	From     string   // Start of the window, default DTSTART
+	To       string   // End of the window, default unbounded
+	ExDates  []string // Excluded occurrences (EXDATE)
+	RDates   []string // Additional occurrences (RDATE)
+	Limit    int      // Occurrences to return, default and at most the server maximum
+}
+
+// RecurrenceExpansion lists the occurrences of a recurrence rule within a window
+type RecurrenceExpansion struct {
+	Rule        string      `json:"rule"`
+	Start       ZonedTime   `json:"start"`
+	AllDay      bool        `json:"allDay"`
+	Occurrences []ZonedTime `json:"occurrences"`
+	Count       int         `json:"count"`
+	Limit       int         `json:"limit"`
+	Truncated   bool        `json:"truncated"`
+}
+
+// recurWeekday is a BYDAY item such as "MO", "2TU" or "-1FR"
+type recurWeekday struct {
+	weekday time.Weekday
+	n       int
+}
+
+// recurRule is a parsed RRULE
+type recurRule struct {
+	freq       int
+	interval   int
+	count      int
+	until      string
+	byMonth    []int
+	byWeekNo   []int
+	byYearDay  []int
+	byMonthDay []int
+	byDay      []recurWeekday
+	byHour     []int
+	byMinute   []int
+	bySecond   []int
+	bySetPos   []int
+	wkst       time.Weekday
+}
+
+// recurDate is a DTSTART, UNTIL, EXDATE or RDATE value: an instant, or a
+// date when allDay is set
+type recurDate struct {
+	t      time.Time
+	allDay bool
+}
+
+// ExpandRecurrence expands an RFC 5545 recurrence rule into its occurrences
+// within a window. The rule may be a bare RRULE value ("FREQ=WEEKLY;BYDAY=MO")
+// or iCalendar lines with DTSTART, RRULE, EXDATE and RDATE properties.
+// Occurrences are computed on the wall clock of the DTSTART timezone: a time
+// skipped by a daylight saving gap moves forward by the length of the gap,
+// and a repeated time uses its first occurrence.
+func (ts *timeService) ExpandRecurrence(rule string, opts RecurrenceOptions) (*RecurrenceExpansion, error) {
+	loc, err := ts.loadLocation(opts.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := opts.Limit
+	switch {
+	case limit < 0:
+		return nil, NewTimeServiceError(ErrCodeTimeOperation, fmt.Sprintf("invalid limit %d: must be positive", limit), "limit", nil)
+	case limit == 0 || limit > ts.maxOccurrences:
+		limit = ts.maxOccurrences
+	}
+
+	// Split iCalendar lines into the rule and its dates
+	var ruleText, startText, startTZID string
+	exdates, rdates := opts.ExDates, opts.RDates
+	exTZIDs := make([]string, len(exdates))
+	rTZIDs := make([]string, len(rdates))
+	for _, line := range unfoldRecurrenceLines(rule) {
+		name, params, value := splitRecurrenceLine(line)
+		switch name {
+		case "RRULE", "":
+			if ruleText != "" {
+				return nil, NewInvalidRecurrenceError(rule, "only one RRULE is supported")
+			}
+			ruleText = value
+		case "DTSTART":
+			startText, startTZID = value, params["TZID"]
+		case "EXDATE", "RDATE":
+			for _, v := range strings.Split(value, ",") {
+				if name == "EXDATE" {
+					exdates, exTZIDs = append(exdates, v), append(exTZIDs, params["TZID"])
+				} else {
+					rdates, rTZIDs = append(rdates, v), append(rTZIDs, params["TZID"])
+				}
+			}
+		default:
+			return nil, NewInvalidRecurrenceError(rule, fmt.Sprintf("unsupported property '%s'", name))
+		}
+	}
+	if ruleText == "" {
+		return nil, NewInvalidRecurrenceError(rule, "missing RRULE")
+	}
+
+	if startText == "" {
+		startText = opts.Start
+	}
+	if startText == "" {
+		return nil, NewInvalidRecurrenceError(rule, "missing DTSTART: give it in the rule or as the start")
+	}
+	timezone := opts.Timezone
+	if startTZID != "" {
+		if loc, err = ts.loadLocation(startTZID); err != nil {
+			return nil, err
+		}
+		timezone = startTZID
+	}
+	start, err := ts.parseRecurrenceDate(startText, loc)
+	if err != nil {
+		return nil, err
+	}
+	start.t = start.t.In(loc)
+
+	r, err := parseRecurrenceRule(ruleText)
+	if err != nil {
+		return nil, NewInvalidRecurrenceError(ruleText, err.Error())
+	}
+
+	var until *time.Time
+	if r.until != "" {
+		u, err := ts.parseRecurrenceDate(r.until, loc)
+		if err != nil {
+			return nil, err
+		}
+		if u.allDay {
+			// A date UNTIL includes the whole day
+			u.t = localTime(u.t.Year(), u.t.Month(), u.t.Day()+1, 0, 0, 0, loc).Add(-time.Nanosecond)
+		}
+		until = &u.t
+	}
+
+	from, to := start.t, time.Time{}
+	if opts.From != "" {
+		if from, err = ts.parseInstant(opts.From, loc); err != nil {
+			return nil, err
+		}
+	}
+	if opts.To != "" {
+		if to, err = ts.parseInstant(opts.To, loc); err != nil {
+			return nil, err
+		}
+		if to.Before(from) {
+			return nil, NewTimeServiceError(ErrCodeTimeOperation, "the end of the window is before its start", "to", nil)
+		}
+	}
+
+	parseDates := func(values, tzids []string) ([]recurDate, error) {
+		dates := make([]recurDate, 0, len(values))
+		for i, v := range values {
+			dateLoc := loc
+			if i < len(tzids) && tzids[i] != "" {
+				if dateLoc, err = ts.loadLocation(tzids[i]); err != nil {
+					return nil, err
+				}
+			}
+			d, err := ts.parseRecurrenceDate(strings.TrimSpace(v), dateLoc)
+			if err != nil {
+				return nil, err
+			}
+			if d.allDay {
+				d.t = localTime(d.t.Year(), d.t.Month(), d.t.Day(), start.t.Hour(), start.t.Minute(), start.t.Second(), loc)
+			}
+			dates = append(dates, d)
+		}
+		return dates, nil
+	}
+	excluded, err := parseDates(exdates, exTZIDs)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := parseDates(rdates, rTZIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	isExcluded := func(t time.Time) bool {
+		for _, ex := range excluded {
+			if ex.t.Equal(t) || (ex.allDay && sameLocalDate(ex.t, t)) {
+				return true
+			}
+		}
+		return false
+	}
+	inWindow := func(t time.Time) bool {
+		return !t.Before(from) && (to.IsZero() || !t.After(to))
+	}
+
+	// Rule occurrences, stopping once one more than the limit is in the window
+	var occurrences []time.Time
+	r.expand(start.t, from, until, func(t time.Time) bool {
+		if !to.IsZero() && t.After(to) {
+			return false
+		}
+		if inWindow(t) && !isExcluded(t) {
+			occurrences = append(occurrences, t)
+		}
+		return len(occurrences) <= limit
+	})
+
+	for _, d := range extra {
+		if inWindow(d.t) && !isExcluded(d.t) {
+			occurrences = append(occurrences, d.t)
+		}
+	}
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+
+	result := &RecurrenceExpansion{
+		Rule:        r.String(),
+		Start:       newZonedTime(start.t, timezone),
+		AllDay:      start.allDay,
+		Occurrences: make([]ZonedTime, 0, min(len(occurrences), limit)),
+		Limit:       limit,
+	}
+	for i, t := range occurrences {
+		if i > 0 && t.Equal(occurrences[i-1]) {
+			continue
+		}
+		if len(result.Occurrences) == limit {
+			result.Truncated = true
+			break
+		}
+		result.Occurrences = append(result.Occurrences, newZonedTime(t.In(loc), timezone))
+	}
+	result.Count = len(result.Occurrences)
+
+	return result, nil
+}
+
+// unfoldRecurrenceLines splits iCalendar text into unfolded, non-empty lines.
+// Escaped "\n" sequences count as line breaks, as rules are often pasted from
+// JSON.
+func unfoldRecurrenceLines(text string) []string {
+	text = strings.NewReplacer("\r\n", "\n", `\n`, "\n").Replace(text)
+	text = strings.NewReplacer("\n ", "", "\n\t", "").Replace(text)
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// splitRecurrenceLine splits "NAME;PARAM=VALUE:value" into its parts. A bare
+// rule such as "FREQ=DAILY" has an empty name.
+func splitRecurrenceLine(line string) (string, map[string]string, string) {
+	head, value, found := strings.Cut(line, ":")
+	if !found || strings.Contains(head, "=") && !strings.Contains(head, ";") {
+		return "", nil, line
+	}
+
+	parts := strings.Split(head, ";")
+	params := make(map[string]string)
+	for _, param := range parts[1:] {
+		key, v, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(v, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, value
+}
+
+// parseRecurrenceDate parses an iCalendar DATE ("20240315") or ISO date
+// ("2024-03-15"), local DATE-TIME ("20240315T090000"), UTC DATE-TIME
+// ("20240315T090000Z") or any timestamp accepted elsewhere, such as RFC3339
+func (ts *timeService) parseRecurrenceDate(value string, loc *time.Location) (recurDate, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"20060102", "2006-01-02"} {
+		if len(value) != len(layout) {
+			continue
+		}
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return recurDate{t: t, allDay: true}, nil
+		}
+	}
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return recurDate{t: t}, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", value, time.UTC); err == nil {
+		return recurDate{t: localTime(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), loc)}, nil
+	}
+
+	t, err := ts.parseInstant(value, loc)
+	if err != nil {
+		return recurDate{}, err
+	}
+	return recurDate{t: t}, nil
+}
+
+// sameLocalDate reports whether two times fall on the same date in a's location
+func sameLocalDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.In(a.Location()).Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// parseRecurrenceRule parses an RRULE value such as "FREQ=MONTHLY;BYDAY=-1FR"
+func parseRecurrenceRule(text string) (*recurRule, error) {
+	r := &recurRule{freq: -1, interval: 1, wkst: time.Monday}
+
+	for _, part := range strings.Split(strings.ToUpper(strings.TrimSpace(text)), ";") {
+		if part == "" {
+			continue
+		}
+		key, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("expected NAME=VALUE, got '%s'", part)
+		}
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.freq = indexOf(recurFrequencies, value)
+			if r.freq < 0 {
+				return nil, fmt.Errorf("invalid FREQ '%s': expected one of %s", value, strings.Join(recurFrequencies, ", "))
+			}
+		case "INTERVAL":
+			if r.interval, err = strconv.Atoi(value); err != nil || r.interval < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL '%s': expected a positive integer", value)
+			}
+		case "COUNT":
+			if r.count, err = strconv.Atoi(value); err != nil || r.count < 1 {
+				return nil, fmt.Errorf("invalid COUNT '%s': expected a positive integer", value)
+			}
+		case "UNTIL":
+			r.until = value
+		case "WKST":
+			weekday, ok := recurWeekdays[value]
+			if !ok {
+				return nil, fmt.Errorf("invalid WKST '%s': expected MO, TU, WE, TH, FR, SA or SU", value)
+			}
+			r.wkst = weekday
+		case "BYDAY":
+			for _, item := range strings.Split(value, ",") {
+				if len(item) < 2 {
+					return nil, fmt.Errorf("invalid BYDAY '%s'", item)
+				}
+				weekday, ok := recurWeekdays[item[len(item)-2:]]
+				n := 0
+				if prefix := item[:len(item)-2]; prefix != "" {
+					n, err = strconv.Atoi(strings.TrimPrefix(prefix, "+"))
+					if err != nil || n == 0 || n < -53 || n > 53 {
+						ok = false
+					}
+				}
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY '%s': expected e.g. MO, 2TU or -1FR", item)
+				}
+				r.byDay = append(r.byDay, recurWeekday{weekday: weekday, n: n})
+			}
+		case "BYMONTH":
+			r.byMonth, err = parseRecurrenceInts(key, value, 1, 12, false)
+		case "BYWEEKNO":
+			r.byWeekNo, err = parseRecurrenceInts(key, value, 1, 53, true)
+		case "BYYEARDAY":
+			r.byYearDay, err = parseRecurrenceInts(key, value, 1, 366, true)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseRecurrenceInts(key, value, 1, 31, true)
+		case "BYHOUR":
+			r.byHour, err = parseRecurrenceInts(key, value, 0, 23, false)
+		case "BYMINUTE":
+			r.byMinute, err = parseRecurrenceInts(key, value, 0, 59, false)
+		case "BYSECOND":
+			r.bySecond, err = parseRecurrenceInts(key, value, 0, 59, false)
+		case "BYSETPOS":
+			r.bySetPos, err = parseRecurrenceInts(key, value, 1, 366, true)
+		default:
+			return nil, fmt.Errorf("unsupported rule part '%s'", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case r.freq < 0:
+		return nil, fmt.Errorf("missing FREQ")
+	case r.count > 0 && r.until != "":
+		return nil, fmt.Errorf("COUNT and UNTIL cannot both be given")
+	case r.byWeekNo != nil && r.freq != freqYearly:
+		return nil, fmt.Errorf("BYWEEKNO is only allowed with FREQ=YEARLY")
+	case r.byYearDay != nil && (r.freq == freqMonthly || r.freq == freqWeekly || r.freq == freqDaily):
+		return nil, fmt.Errorf("BYYEARDAY is not allowed with FREQ=%s", recurFrequencies[r.freq])
+	case r.byMonthDay != nil && r.freq == freqWeekly:
+		return nil, fmt.Errorf("BYMONTHDAY is not allowed with FREQ=WEEKLY")
+	}
+	for _, day := range r.byDay {
+		if day.n != 0 && r.freq != freqMonthly && r.freq != freqYearly {
+			return nil, fmt.Errorf("numbered BYDAY values such as 2MO need FREQ=MONTHLY or FREQ=YEARLY")
+		}
+	}
+
+	return r, nil
+}
+
+// parseRecurrenceInts parses a list of integers from lo to hi, or from -hi
+// to -lo as well when negative is set
+func parseRecurrenceInts(key, value string, lo, hi int, negative bool) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimPrefix(item, "+"))
+		valid := err == nil && ((n >= lo && n <= hi) || (negative && n <= -lo && n >= -hi))
+		if !valid {
+			if negative {
+				return nil, fmt.Errorf("invalid %s '%s': expected %d to %d or -%d to -%d", key, item, lo, hi, hi, lo)
+			}
+			return nil, fmt.Errorf("invalid %s '%s': expected %d to %d", key, item, lo, hi)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+// String returns the rule in canonical RRULE form
+func (r *recurRule) String() string {
+	parts := []string{"FREQ=" + recurFrequencies[r.freq]}
+	if r.interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.interval))
+	}
+	if r.count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.count))
+	}
+	if r.until != "" {
+		parts = append(parts, "UNTIL="+r.until)
+	}
+
+	ints := func(key string, values []int) {
+		if values == nil {
+			return
+		}
+		items := make([]string, len(values))
+		for i, v := range values {
+			items[i] = strconv.Itoa(v)
+		}
+		parts = append(parts, key+"="+strings.Join(items, ","))
+	}
+	ints("BYMONTH", r.byMonth)
+	ints("BYWEEKNO", r.byWeekNo)
+	ints("BYYEARDAY", r.byYearDay)
+	ints("BYMONTHDAY", r.byMonthDay)
+	if r.byDay != nil {
+		items := make([]string, len(r.byDay))
+		for i, day := range r.byDay {
+			items[i] = strings.ToUpper(day.weekday.String()[:2])
+			if day.n != 0 {
+				items[i] = strconv.Itoa(day.n) + items[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(items, ","))
+	}
+	ints("BYHOUR", r.byHour)
+	ints("BYMINUTE", r.byMinute)
+	ints("BYSECOND", r.bySecond)
+	ints("BYSETPOS", r.bySetPos)
+	if r.wkst != time.Monday {
+		parts = append(parts, "WKST="+strings.ToUpper(r.wkst.String()[:2]))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// expand calls emit with each occurrence from start onwards, in order, until
+// emit returns false or the rule ends. DTSTART is always the first occurrence
+// and counts towards COUNT. When the rule has no COUNT, periods ending before
+// from are skipped.
+func (r *recurRule) expand(start, from time.Time, until *time.Time, emit func(time.Time) bool) {
+	loc := start.Location()
+	// Civil date-times are handled as UTC times holding the local wall clock
+	startWall := time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, time.UTC)
+
+	// Fill in the parts implied by DTSTART
+	byMonth, byMonthDay, byDay := r.byMonth, r.byMonthDay, r.byDay
+	if r.byDay == nil && r.byMonthDay == nil && r.byYearDay == nil && r.byWeekNo == nil {
+		switch r.freq {
+		case freqYearly:
+			if byMonth == nil {
+				byMonth = []int{int(start.Month())}
+			}
+			byMonthDay = []int{start.Day()}
+		case freqMonthly:
+			byMonthDay = []int{start.Day()}
+		case freqWeekly:
+			byDay = []recurWeekday{{weekday: start.Weekday()}}
+		}
+	}
+	if r.freq == freqYearly && r.byWeekNo != nil && r.byDay == nil && r.byYearDay == nil && r.byMonthDay == nil {
+		byDay = []recurWeekday{{weekday: start.Weekday()}}
+	}
+	timeDefault := func(values []int, freq, value int) []int {
+		if values == nil && r.freq < freq {
+			return []int{value}
+		}
+		return values
+	}
+	byHour := timeDefault(r.byHour, freqHourly, start.Hour())
+	byMinute := timeDefault(r.byMinute, freqMinutely, start.Minute())
+	bySecond := timeDefault(r.bySecond, freqSecondly, start.Second())
+
+	emitted := 0
+	done := func(t time.Time) bool {
+		if until != nil && t.After(*until) {
+			return true
+		}
+		emitted++
+		return !emit(t) || (r.count > 0 && emitted >= r.count)
+	}
+	if done(start) {
+		return
+	}
+
+	// The first period containing DTSTART, and the step between periods
+	cursor := startWall
+	switch r.freq {
+	case freqYearly:
+		cursor = civilDate(start.Year(), time.January, 1)
+	case freqMonthly:
+		cursor = civilDate(start.Year(), start.Month(), 1)
+	case freqWeekly:
+		cursor = civilDate(start.Year(), start.Month(), start.Day()-(int(start.Weekday())-int(r.wkst)+7)%7)
+	case freqDaily:
+		cursor = civilDate(start.Year(), start.Month(), start.Day())
+	case freqHourly:
+		cursor = startWall.Truncate(time.Hour)
+	case freqMinutely:
+		cursor = startWall.Truncate(time.Minute)
+	}
+	next := func(t time.Time, periods int) time.Time {
+		n := periods * r.interval
+		switch r.freq {
+		case freqYearly:
+			return t.AddDate(n, 0, 0)
+		case freqMonthly:
+			return t.AddDate(0, n, 0)
+		case freqWeekly:
+			return t.AddDate(0, 0, 7*n)
+		case freqDaily:
+			return t.AddDate(0, 0, n)
+		case freqHourly:
+			return t.Add(time.Duration(n) * time.Hour)
+		case freqMinutely:
+			return t.Add(time.Duration(n) * time.Minute)
+		}
+		return t.Add(time.Duration(n) * time.Second)
+	}
+
+	// Without COUNT, jump to shortly before the window
+	if r.count == 0 && from.After(start) {
+		local := from.In(loc)
+		fromWall := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), 0, time.UTC)
+		var units int
+		switch r.freq {
+		case freqYearly:
+			units = fromWall.Year() - cursor.Year()
+		case freqMonthly:
+			units = (fromWall.Year()-cursor.Year())*12 + int(fromWall.Month()) - int(cursor.Month())
+		case freqWeekly:
+			units = int(fromWall.Sub(cursor).Hours() / 24 / 7)
+		case freqDaily:
+			units = int(fromWall.Sub(cursor).Hours() / 24)
+		case freqHourly:
+			units = int(fromWall.Sub(cursor).Hours())
+		case freqMinutely:
+			units = int(fromWall.Sub(cursor).Minutes())
+		default:
+			units = int(fromWall.Sub(cursor).Seconds())
+		}
+		if skip := units/r.interval - 1; skip > 0 {
+			cursor = next(cursor, skip)
+		}
+	}
+
+	lastMatch := max(startWall.Year(), from.In(loc).Year())
+	for periods := 0; periods < recurMaxPeriods && cursor.Year()-lastMatch <= recurSearchYears; periods++ {
+		// Step over whole days that cannot match rather than each hour in them
+		if r.freq >= freqHourly {
+			day := civilDate(cursor.Year(), cursor.Month(), cursor.Day())
+			if !r.matchesDay(day, byMonth, byMonthDay, byDay, false) {
+				step := next(cursor, 1).Sub(cursor)
+				cursor = cursor.Add((day.AddDate(0, 0, 1).Sub(cursor) + step - 1) / step * step)
+				continue
+			}
+		}
+
+		candidates := r.periodCandidates(cursor, byMonth, byMonthDay, byDay, byHour, byMinute, bySecond)
+		cursor = next(cursor, 1)
+		if len(candidates) == 0 {
+			continue
+		}
+		lastMatch = candidates[0].Year()
+
+		for _, wall := range candidates {
+			if !wall.After(startWall) {
+				continue
+			}
+			if done(localTime(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), loc)) {
+				return
+			}
+		}
+	}
+}
+
+// periodCandidates returns the wall-clock times of a period that match the
+// rule, in order, after BYSETPOS
+func (r *recurRule) periodCandidates(cursor time.Time, byMonth, byMonthDay []int, byDay []recurWeekday, byHour, byMinute, bySecond []int) []time.Time {
+	var days []time.Time
+	switch r.freq {
+	case freqYearly:
+		for d := civilDate(cursor.Year(), time.January, 1); d.Year() == cursor.Year(); d = d.AddDate(0, 0, 1) {
+			days = append(days, d)
+		}
+	case freqMonthly:
+		for d := cursor; d.Month() == cursor.Month(); d = d.AddDate(0, 0, 1) {
+			days = append(days, d)
+		}
+	case freqWeekly:
+		for i := 0; i < 7; i++ {
+			days = append(days, cursor.AddDate(0, 0, i))
+		}
+	default:
+		days = []time.Time{civilDate(cursor.Year(), cursor.Month(), cursor.Day())}
+	}
+
+	// Ordinal weekdays count within the month for MONTHLY, or YEARLY with
+	// BYMONTH, and within the year otherwise
+	monthFrame := r.freq == freqMonthly || (r.freq == freqYearly && byMonth != nil)
+
+	// The period fixes the units at least as coarse as the frequency
+	hours, minutes, seconds := byHour, byMinute, bySecond
+	if r.freq >= freqHourly {
+		hours = filterRecurrenceUnit(byHour, cursor.Hour())
+	}
+	if r.freq >= freqMinutely {
+		minutes = filterRecurrenceUnit(byMinute, cursor.Minute())
+	}
+	if r.freq >= freqSecondly {
+		seconds = filterRecurrenceUnit(bySecond, cursor.Second())
+	}
+	hours, minutes, seconds = sortedInts(hours, 0, 23), sortedInts(minutes, 0, 59), sortedInts(seconds, 0, 59)
+
+	var candidates []time.Time
+	for _, day := range days {
+		if !r.matchesDay(day, byMonth, byMonthDay, byDay, monthFrame) {
+			continue
+		}
+		for _, h := range hours {
+			for _, m := range minutes {
+				for _, s := range seconds {
+					candidates = append(candidates, time.Date(day.Year(), day.Month(), day.Day(), h, m, s, 0, time.UTC))
+				}
+			}
+		}
+	}
+
+	if r.bySetPos == nil || len(candidates) == 0 {
+		return candidates
+	}
+
+	var selected []time.Time
+	for _, pos := range r.bySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(candidates) + pos
+		}
+		if i >= 0 && i < len(candidates) {
+			selected = append(selected, candidates[i])
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Before(selected[j]) })
+	return selected
+}
+
+// filterRecurrenceUnit returns the period's own value of a unit when the BY
+// list allows it
+func filterRecurrenceUnit(values []int, value int) []int {
+	if values == nil {
+		return []int{value}
+	}
+	for _, v := range values {
+		if v == value {
+			return []int{value}
+		}
+	}
+	return nil
+}
+
+// sortedInts returns the distinct values in ascending order
+func sortedInts(values []int, lo, hi int) []int {
+	seen := make([]bool, hi-lo+1)
+	for _, v := range values {
+		seen[v-lo] = true
+	}
+	var sorted []int
+	for i, ok := range seen {
+		if ok {
+			sorted = append(sorted, lo+i)
+		}
+	}
+	return sorted
+}
+
+// matchesDay reports whether a day passes the BYMONTH, BYWEEKNO, BYYEARDAY,
+// BYMONTHDAY and BYDAY parts
+func (r *recurRule) matchesDay(day time.Time, byMonth, byMonthDay []int, byDay []recurWeekday, monthFrame bool) bool {
+	year, month, mday := day.Date()
+
+	if byMonth != nil && !containsInt(byMonth, int(month)) {
+		return false
+	}
+
+	if r.byWeekNo != nil {
+		week, weeks := recurWeekNumber(day, r.wkst)
+		matched := false
+		for _, n := range r.byWeekNo {
+			if n == week || (n < 0 && weeks+n+1 == week) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if r.byYearDay != nil {
+		yday, ydays := day.YearDay(), daysInYear(year)
+		if !containsInt(r.byYearDay, yday) && !containsInt(r.byYearDay, yday-ydays-1) {
+			return false
+		}
+	}
+
+	if byMonthDay != nil {
+		mdays := daysIn(year, month)
+		if !containsInt(byMonthDay, mday) && !containsInt(byMonthDay, mday-mdays-1) {
+			return false
+		}
+	}
+
+	if byDay != nil {
+		matched := false
+		for _, d := range byDay {
+			if d.weekday != day.Weekday() {
+				continue
+			}
+			if d.n == 0 {
+				matched = true
+				break
+			}
+
+			// Position of this weekday in the month or year, from either end
+			var index, total int
+			if monthFrame {
+				index, total = (mday-1)/7+1, (daysIn(year, month)-mday)/7+(mday-1)/7+1
+			} else {
+				yday := day.YearDay()
+				index, total = (yday-1)/7+1, (daysInYear(year)-yday)/7+(yday-1)/7+1
+			}
+			if d.n == index || (d.n < 0 && total+d.n+1 == index) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// recurWeekNumber returns the RFC 5545 week number of a day and the number of
+// weeks in its year: weeks start on wkst, and week 1 is the first week with
+// at least four days in the year
+func recurWeekNumber(day time.Time, wkst time.Weekday) (int, int) {
+	firstWeekStart := func(year int) time.Time {
+		jan1 := civilDate(year, time.January, 1)
+		offset := (int(jan1.Weekday()) - int(wkst) + 7) % 7
+		start := jan1.AddDate(0, 0, -offset)
+		if offset > 3 {
+			start = start.AddDate(0, 0, 7)
+		}
+		return start
+	}
+
+	year := day.Year()
+	start := firstWeekStart(year)
+	if day.Before(start) {
+		year--
+		start = firstWeekStart(year)
+	} else if nextStart := firstWeekStart(year + 1); !day.Before(nextStart) {
+		year++
+		start = nextStart
+	}
+
+	weeks := int(firstWeekStart(year+1).Sub(start).Hours()/24) / 7
+	return int(day.Sub(start).Hours()/24)/7 + 1, weeks
+}
+
+// daysInYear returns 365 or 366
+func daysInYear(year int) int {
+	return civilDate(year, time.December, 31).YearDay()
+}
+
+// containsInt reports whether values contains v
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
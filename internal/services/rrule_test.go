@@ -0,0 +1,200 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeService_ExpandRecurrence(t *testing.T) {
+	ts := NewTimeService(WithMaxOccurrences(50))
+
+	tests := []struct {
+		name      string
+		rule      string
+		opts      RecurrenceOptions
+		expected  []string
+		allDay    bool
+		truncated bool
+		wantErr   bool
+		errCode   int
+	}{
+		{
+			name:     "daily across a daylight saving change",
+			rule:     "DTSTART;TZID=America/New_York:19971025T090000\nRRULE:FREQ=DAILY;COUNT=3",
+			expected: []string{"1997-10-25T09:00:00-04:00", "1997-10-26T09:00:00-05:00", "1997-10-27T09:00:00-05:00"},
+		},
+		{
+			name:     "first friday of the month",
+			rule:     "FREQ=MONTHLY;COUNT=5;BYDAY=1FR",
+			opts:     RecurrenceOptions{Start: "19970905T090000", Timezone: "America/New_York"},
+			expected: []string{"1997-09-05T09:00:00-04:00", "1997-10-03T09:00:00-04:00", "1997-11-07T09:00:00-05:00", "1997-12-05T09:00:00-05:00", "1998-01-02T09:00:00-05:00"},
+		},
+		{
+			name:     "last work day of the month",
+			rule:     "RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=4",
+			opts:     RecurrenceOptions{Start: "1997-09-30T09:00:00Z"},
+			expected: []string{"1997-09-30T09:00:00Z", "1997-10-31T09:00:00Z", "1997-11-28T09:00:00Z", "1997-12-31T09:00:00Z"},
+		},
+		{
+			name:     "every other week with monday start",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			opts:     RecurrenceOptions{Start: "19970805T090000Z"},
+			expected: []string{"1997-08-05T09:00:00Z", "1997-08-10T09:00:00Z", "1997-08-19T09:00:00Z", "1997-08-24T09:00:00Z"},
+		},
+		{
+			name:     "every other week with sunday start",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			opts:     RecurrenceOptions{Start: "19970805T090000Z"},
+			expected: []string{"1997-08-05T09:00:00Z", "1997-08-17T09:00:00Z", "1997-08-19T09:00:00Z", "1997-08-31T09:00:00Z"},
+		},
+		{
+			name:     "until is inclusive",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;UNTIL=19970919T090000Z;WKST=SU;BYDAY=MO,WE,FR",
+			opts:     RecurrenceOptions{Start: "19970901T090000Z"},
+			expected: []string{"1997-09-01T09:00:00Z", "1997-09-03T09:00:00Z", "1997-09-05T09:00:00Z", "1997-09-15T09:00:00Z", "1997-09-17T09:00:00Z", "1997-09-19T09:00:00Z"},
+		},
+		{
+			name:     "monday of week 20",
+			rule:     "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO;COUNT=3",
+			opts:     RecurrenceOptions{Start: "19970512T090000Z"},
+			expected: []string{"1997-05-12T09:00:00Z", "1998-05-11T09:00:00Z", "1999-05-17T09:00:00Z"},
+		},
+		{
+			name:     "20th monday of the year",
+			rule:     "FREQ=YEARLY;BYDAY=20MO;COUNT=3",
+			opts:     RecurrenceOptions{Start: "19970519T090000Z"},
+			expected: []string{"1997-05-19T09:00:00Z", "1998-05-18T09:00:00Z", "1999-05-17T09:00:00Z"},
+		},
+		{
+			name:     "friday the 13th excluding the start",
+			rule:     "DTSTART:19970902T090000Z\nEXDATE:19970902T090000Z\nRRULE:FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13;COUNT=5",
+			expected: []string{"1998-02-13T09:00:00Z", "1998-03-13T09:00:00Z", "1998-11-13T09:00:00Z", "1999-08-13T09:00:00Z"},
+		},
+		{
+			name:     "third to last day of the month",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-3;COUNT=3",
+			opts:     RecurrenceOptions{Start: "19971229T090000Z"},
+			expected: []string{"1997-12-29T09:00:00Z", "1998-01-29T09:00:00Z", "1998-02-26T09:00:00Z"},
+		},
+		{
+			name:     "31st skips short months",
+			rule:     "FREQ=MONTHLY;COUNT=3",
+			opts:     RecurrenceOptions{Start: "2024-01-31T10:00:00Z"},
+			expected: []string{"2024-01-31T10:00:00Z", "2024-03-31T10:00:00Z", "2024-05-31T10:00:00Z"},
+		},
+		{
+			name:     "hourly with by hour",
+			rule:     "FREQ=HOURLY;INTERVAL=3;BYHOUR=9,12,15;COUNT=4",
+			opts:     RecurrenceOptions{Start: "2024-06-03T09:00:00Z"},
+			expected: []string{"2024-06-03T09:00:00Z", "2024-06-03T12:00:00Z", "2024-06-03T15:00:00Z", "2024-06-04T09:00:00Z"},
+		},
+		{
+			name:     "time in a daylight saving gap",
+			rule:     "FREQ=DAILY;COUNT=3",
+			opts:     RecurrenceOptions{Start: "20240309T023000", Timezone: "America/New_York"},
+			expected: []string{"2024-03-09T02:30:00-05:00", "2024-03-10T03:30:00-04:00", "2024-03-11T02:30:00-04:00"},
+		},
+		{
+			name:     "window with exdate and rdate",
+			rule:     "FREQ=WEEKLY;BYDAY=MO",
+			opts:     RecurrenceOptions{Start: "2024-01-01T09:00:00Z", From: "2030-06-01T00:00:00Z", To: "2030-06-30T00:00:00Z", ExDates: []string{"20300610"}, RDates: []string{"2030-06-12T15:00:00Z"}},
+			expected: []string{"2030-06-03T09:00:00Z", "2030-06-12T15:00:00Z", "2030-06-17T09:00:00Z", "2030-06-24T09:00:00Z"},
+		},
+		{
+			name:      "limit truncates",
+			rule:      "FREQ=DAILY",
+			opts:      RecurrenceOptions{Start: "2024-01-01", Limit: 2},
+			expected:  []string{"2024-01-01T00:00:00Z", "2024-01-02T00:00:00Z"},
+			allDay:    true,
+			truncated: true,
+		},
+		{
+			name:     "iso date start is all day",
+			rule:     "FREQ=DAILY;COUNT=2",
+			opts:     RecurrenceOptions{Start: "2024-01-05", Timezone: "Europe/Paris"},
+			expected: []string{"2024-01-05T00:00:00+01:00", "2024-01-06T00:00:00+01:00"},
+			allDay:   true,
+		},
+		{
+			name:     "iso date exdate excludes the whole day",
+			rule:     "FREQ=DAILY;COUNT=3",
+			opts:     RecurrenceOptions{Start: "2024-01-01T09:00:00Z", ExDates: []string{"2024-01-02"}},
+			expected: []string{"2024-01-01T09:00:00Z", "2024-01-03T09:00:00Z"},
+		},
+		{
+			name:     "iso date rdate takes the start time of day",
+			rule:     "FREQ=DAILY;COUNT=2",
+			opts:     RecurrenceOptions{Start: "2024-01-01T09:00:00Z", RDates: []string{"2024-01-10"}},
+			expected: []string{"2024-01-01T09:00:00Z", "2024-01-02T09:00:00Z", "2024-01-10T09:00:00Z"},
+		},
+		{
+			name:     "never matches",
+			rule:     "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			opts:     RecurrenceOptions{Start: "2024-01-01T00:00:00Z"},
+			expected: []string{"2024-01-01T00:00:00Z"},
+		},
+		{name: "missing freq", rule: "COUNT=3", opts: RecurrenceOptions{Start: "2024-01-01"}, wantErr: true, errCode: ErrCodeInvalidRecurrence},
+		{name: "missing start", rule: "FREQ=DAILY", wantErr: true, errCode: ErrCodeInvalidRecurrence},
+		{name: "count and until", rule: "FREQ=DAILY;COUNT=3;UNTIL=20240301", opts: RecurrenceOptions{Start: "2024-01-01"}, wantErr: true, errCode: ErrCodeInvalidRecurrence},
+		{name: "numbered weekday with weekly", rule: "FREQ=WEEKLY;BYDAY=2MO", opts: RecurrenceOptions{Start: "2024-01-01"}, wantErr: true, errCode: ErrCodeInvalidRecurrence},
+		{name: "month day out of range", rule: "FREQ=MONTHLY;BYMONTHDAY=32", opts: RecurrenceOptions{Start: "2024-01-01"}, wantErr: true, errCode: ErrCodeInvalidRecurrence},
+		{name: "window backwards", rule: "FREQ=DAILY", opts: RecurrenceOptions{Start: "2024-01-01", From: "2024-02-01", To: "2024-01-15"}, wantErr: true, errCode: ErrCodeTimeOperation},
+		{name: "invalid timezone", rule: "FREQ=DAILY", opts: RecurrenceOptions{Start: "2024-01-01", Timezone: "Mars/Base"}, wantErr: true, errCode: ErrCodeInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.ExpandRecurrence(tt.rule, tt.opts)
+
+			if tt.wantErr {
+				var tsErr *TimeServiceError
+				if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+					t.Errorf("Expected error code %d, got %+v, %v", tt.errCode, result, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(result.Occurrences) != len(tt.expected) {
+				t.Fatalf("Expected %d occurrences, got %+v", len(tt.expected), result.Occurrences)
+			}
+			for i, expected := range tt.expected {
+				if result.Occurrences[i].Time != expected {
+					t.Errorf("Occurrence %d: expected %s, got %s", i, expected, result.Occurrences[i].Time)
+				}
+			}
+			if result.AllDay != tt.allDay {
+				t.Errorf("Expected all day %v, got %v", tt.allDay, result.AllDay)
+			}
+			if result.Truncated != tt.truncated {
+				t.Errorf("Expected truncated %v, got %v", tt.truncated, result.Truncated)
+			}
+			if result.Count != len(result.Occurrences) {
+				t.Errorf("Count %d does not match %d occurrences", result.Count, len(result.Occurrences))
+			}
+		})
+	}
+}
+
+func TestRecurWeekNumber(t *testing.T) {
+	tests := []struct {
+		year, month, day int
+		week, weeks      int
+	}{
+		{2024, 1, 1, 1, 52},
+		{2020, 12, 31, 53, 53},
+		{2021, 1, 3, 53, 53},  // Belongs to the last week of 2020
+		{2024, 12, 30, 1, 52}, // Belongs to the first week of 2025
+	}
+
+	for _, tt := range tests {
+		week, weeks := recurWeekNumber(civilDate(tt.year, time.Month(tt.month), tt.day), time.Monday)
+		if week != tt.week || weeks != tt.weeks {
+			t.Errorf("%d-%02d-%02d: expected week %d of %d, got %d of %d", tt.year, tt.month, tt.day, tt.week, tt.weeks, week, weeks)
+		}
+	}
+}
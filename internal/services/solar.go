@@ -0,0 +1,122 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/zodimo/go-time-mcp/internal/astronomy"
+)
+
+// Daylight conditions of a solar day
+const (
+	DaylightNormal = "normal"
+	PolarDay       = "polarDay"
+	PolarNight     = "polarNight"
+)
+
+// Twilight states: the sun crosses the twilight altitude, never sinks below
+// it so the twilight lasts all night, or never rises above it
+const (
+	TwilightNormal   = "normal"
+	TwilightAllNight = "allNight"
+	TwilightNone     = "none"
+)
+
+// SolarTimes lists the daylight and twilight times of a date at a location
+type SolarTimes struct {
+	Latitude             float64    `json:"latitude"`
+	Longitude            float64    `json:"longitude"`
+	Date                 string     `json:"date"`
+	Daylight             string     `json:"daylight"`
+	Sunrise              *ZonedTime `json:"sunrise"`
+	Sunset               *ZonedTime `json:"sunset"`
+	SolarNoon            ZonedTime  `json:"solarNoon"`
+	NoonElevation        float64    `json:"noonElevation"`
+	DayLength            string     `json:"dayLength"`
+	DayLengthSeconds     int64      `json:"dayLengthSeconds"`
+	CivilTwilight        Twilight   `json:"civilTwilight"`
+	NauticalTwilight     Twilight   `json:"nauticalTwilight"`
+	AstronomicalTwilight Twilight   `json:"astronomicalTwilight"`
+}
+
+// Twilight is the start of the morning twilight (dawn) and the end of the
+// evening twilight (dusk) for one depression of the sun. Both are null unless
+// the state is normal.
+type Twilight struct {
+	Dawn  *ZonedTime `json:"dawn"`
+	Dusk  *ZonedTime `json:"dusk"`
+	State string     `json:"state"`
+}
+
+// SolarTimes computes sunrise, sunset, solar noon and the civil, nautical and
+// astronomical twilights of a date at a location. The date is read in the
+// timezone, which is also used for the results. Above the polar circles the
+// daylight is reported as polarDay or polarNight, with no sunrise or sunset,
+// rather than as times that never happen.
+func (ts *timeService) SolarTimes(latitude, longitude float64, date, timezone string) (*SolarTimes, error) {
+	if err := validateCoordinates(latitude, longitude); err != nil {
+		return nil, err
+	}
+
+	loc, err := ts.loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	day, err := ts.parseCivilDate(date, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	solar := astronomy.Solar(localTime(day.Year(), day.Month(), day.Day(), 12, 0, 0, loc), latitude, longitude)
+	zoned := func(t time.Time) *ZonedTime {
+		z := newZonedTime(t.In(loc), timezone)
+		return &z
+	}
+
+	result := &SolarTimes{
+		Latitude:             latitude,
+		Longitude:            longitude,
+		Date:                 day.Format("2006-01-02"),
+		SolarNoon:            *zoned(solar.Noon),
+		NoonElevation:        math.Round(solar.NoonAltitude*100) / 100,
+		DayLength:            solar.DayLength().String(),
+		DayLengthSeconds:     int64(solar.DayLength().Seconds()),
+		CivilTwilight:        newTwilight(solar.CivilTwilight, zoned),
+		NauticalTwilight:     newTwilight(solar.NauticalTwilight, zoned),
+		AstronomicalTwilight: newTwilight(solar.AstronomicalTwilight, zoned),
+	}
+	switch solar.Sunrise.State {
+	case astronomy.AlwaysAbove:
+		result.Daylight = PolarDay
+	case astronomy.AlwaysBelow:
+		result.Daylight = PolarNight
+	default:
+		result.Daylight = DaylightNormal
+		result.Sunrise, result.Sunset = zoned(solar.Sunrise.Rise), zoned(solar.Sunrise.Set)
+	}
+
+	return result, nil
+}
+
+// newTwilight converts a twilight crossing into its dawn and dusk
+func newTwilight(c astronomy.Crossing, zoned func(time.Time) *ZonedTime) Twilight {
+	switch c.State {
+	case astronomy.AlwaysAbove:
+		return Twilight{State: TwilightAllNight}
+	case astronomy.AlwaysBelow:
+		return Twilight{State: TwilightNone}
+	}
+	return Twilight{Dawn: zoned(c.Rise), Dusk: zoned(c.Set), State: TwilightNormal}
+}
+
+// validateCoordinates checks a latitude and longitude in degrees
+func validateCoordinates(latitude, longitude float64) error {
+	switch {
+	case math.IsNaN(latitude) || latitude < -90 || latitude > 90:
+		return NewInvalidCoordinatesError(latitude, longitude, "latitude must be between -90 and 90")
+	case math.IsNaN(longitude) || longitude < -180 || longitude > 180:
+		return NewInvalidCoordinatesError(latitude, longitude, "longitude must be between -180 and 180")
+	}
+	return nil
+}
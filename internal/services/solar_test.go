@@ -0,0 +1,61 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTimeService_SolarTimes(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name                string
+		latitude, longitude float64
+		date, timezone      string
+		daylight            string
+		sunrise             string // Local sunrise to the minute
+		civil               string
+		wantErr             bool
+		errCode             int
+	}{
+		{name: "new york in summer", latitude: 40.7128, longitude: -74.0060, date: "2024-06-21", timezone: "America/New_York", daylight: DaylightNormal, sunrise: "2024-06-21T05:25", civil: TwilightNormal},
+		{name: "date read in the timezone", latitude: -33.8688, longitude: 151.2093, date: "2024-06-21T20:00:00Z", timezone: "Australia/Sydney", daylight: DaylightNormal, sunrise: "2024-06-22T07:00", civil: TwilightNormal},
+		{name: "white night", latitude: 64.5401, longitude: 40.5433, date: "2024-06-21", timezone: "Europe/Moscow", daylight: DaylightNormal, civil: TwilightAllNight},
+		{name: "polar day", latitude: 78.2232, longitude: 15.6267, date: "2024-06-21", timezone: "Arctic/Longyearbyen", daylight: PolarDay, civil: TwilightAllNight},
+		{name: "polar night", latitude: 78.2232, longitude: 15.6267, date: "2024-12-21", timezone: "Arctic/Longyearbyen", daylight: PolarNight, civil: TwilightNone},
+		{name: "latitude out of range", latitude: 91, date: "2024-06-21", wantErr: true, errCode: ErrCodeInvalidCoordinates},
+		{name: "longitude out of range", longitude: -181, date: "2024-06-21", wantErr: true, errCode: ErrCodeInvalidCoordinates},
+		{name: "invalid date", date: "someday", wantErr: true, errCode: ErrCodeInvalidTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.SolarTimes(tt.latitude, tt.longitude, tt.date, tt.timezone)
+
+			if tt.wantErr {
+				var tsErr *TimeServiceError
+				if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+					t.Errorf("Expected error code %d, got %+v, %v", tt.errCode, result, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Daylight != tt.daylight || result.CivilTwilight.State != tt.civil {
+				t.Fatalf("Expected daylight %s and civil twilight %s, got %s and %s", tt.daylight, tt.civil, result.Daylight, result.CivilTwilight.State)
+			}
+			if (result.Sunrise == nil) != (tt.daylight != DaylightNormal) || (result.Sunset == nil) != (result.Sunrise == nil) {
+				t.Errorf("Unexpected sunrise %+v and sunset %+v for %s", result.Sunrise, result.Sunset, tt.daylight)
+			}
+			if tt.sunrise != "" && result.Sunrise.Time[:16] != tt.sunrise {
+				t.Errorf("Expected sunrise at %s, got %s", tt.sunrise, result.Sunrise.Time)
+			}
+			if (result.CivilTwilight.Dawn == nil) != (tt.civil != TwilightNormal) {
+				t.Errorf("Unexpected civil dawn %+v for %s", result.CivilTwilight.Dawn, tt.civil)
+			}
+		})
+	}
+}
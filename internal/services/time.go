@@ -1,215 +1,159 @@
 package services
 
-import (
-	"fmt"
-	"strconv"
-	"strings"
-	"time"
-)
+import "time"
 
 // TimeService provides time-related operations
 type TimeService interface {
 	GetCurrentTime(timezone string) (time.Time, error)
-	GetUnixTimestamp() int64
-	FormatTime(t time.Time, format string) (string, error)
+	GetUnixTimestamp(unit string, decimal bool) (*UnixTimestamp, error)
+	WorldClock(zones []string, format, dialect, locale string) (*WorldClock, error)
+	FormatTime(t time.Time, format, dialect, locale string) (string, error)
 	ValidateTimezone(timezone string) error
-	ValidateFormat(format string) error
+	ValidateFormat(format, dialect string) error
+	ConvertTime(t, format, dialect, fromZone, toZone string) (*TimeConversion, error)
+	ParseTime(input, format, dialect, timezone string) (*ParsedTime, error)
+	Diff(a, b, timezone string) (*TimeDifference, error)
+	Add(t, duration, timezone string) (*TimeAddition, error)
+	Humanize(t, reference, locale string, opts HumanizeOptions) (*RelativeTime, error)
+	ResolveDateExpression(expression, reference, timezone string) (*DateExpression, error)
+	CronRuns(expression, reference string, opts CronOptions) (*CronSchedule, error)
+	ExpandRecurrence(rule string, opts RecurrenceOptions) (*RecurrenceExpansion, error)
+	IsBusinessDay(date, calendars, weekend, timezone string) (*BusinessDayInfo, error)
+	AddBusinessDays(date string, days int, calendars, weekend, timezone string) (*BusinessDayAddition, error)
+	CountBusinessDays(from, to, calendars, weekend, timezone string) (*BusinessDayCount, error)
+	FindMeetingSlots(opts MeetingOptions) (*MeetingPlan, error)
+	SolarTimes(latitude, longitude float64, date, timezone string) (*SolarTimes, error)
+	MoonPhase(date, timezone string, location *Coordinates) (*MoonPhase, error)
+	ConvertTimestamp(value, scale string) (*TimestampConversion, error)
+	ExtractIDTimestamp(id, idType, epoch, timezone string) (*IDTimestamp, error)
+	ConvertCalendar(date, from, to, timezone string) (*CalendarConversion, error)
+	CalendarDate(t time.Time, calendar string) (*CalendarDate, error)
+	DateInfo(date, timezone, weekStart string) (*DateInfo, error)
+	ListTimezones(region, country string, includeLinks bool) (*TimezoneList, error)
+	SearchTimezones(query string, limit int) (*TimezoneList, error)
+	ZoneInfo(timezone, from, to string) (*ZoneDetails, error)
 }
 
 // timeService implements TimeService interface
-type timeService struct{}
-
-// NewTimeService creates a new time service instance
-func NewTimeService() TimeService {
-	return &timeService{}
+type timeService struct {
+	calendars      map[string]*BusinessCalendar
+	zones          *ZoneResolver
+	maxOccurrences int
 }
 
-// GetCurrentTime returns the current time in the specified timezone
-func (ts *timeService) GetCurrentTime(timezone string) (time.Time, error) {
-	if timezone == "" {
-		return time.Now().UTC(), nil
-	}
+// Option configures optional time service behaviour
+type Option func(*timeService)
 
-	// Validate timezone first
-	if err := ts.ValidateTimezone(timezone); err != nil {
-		return time.Time{}, err
+// WithBusinessCalendar registers an additional business calendar, replacing
+// any built-in calendar with the same ID
+func WithBusinessCalendar(cal *BusinessCalendar) Option {
+	return func(ts *timeService) {
+		ts.calendars[cal.id] = cal
 	}
+}
 
-	// Load the timezone
-	loc, err := time.LoadLocation(timezone)
-	if err != nil {
-		return time.Time{}, NewTimeServiceError(
-			ErrCodeInvalidTimezone,
-			fmt.Sprintf("failed to load timezone '%s'", timezone),
-			"timezone",
-			err,
-		)
+// WithZoneResolver replaces the resolver used to turn timezone names into
+// locations, e.g. one with a custom abbreviation table
+func WithZoneResolver(r *ZoneResolver) Option {
+	return func(ts *timeService) {
+		ts.zones = r
 	}
-
-	return time.Now().In(loc), nil
 }
 
-// GetUnixTimestamp returns the current Unix timestamp
-func (ts *timeService) GetUnixTimestamp() int64 {
-	return time.Now().Unix()
+// WithMaxOccurrences caps the occurrences returned by one recurrence expansion
+func WithMaxOccurrences(n int) Option {
+	return func(ts *timeService) {
+		ts.maxOccurrences = n
+	}
 }
 
-// FormatTime formats a time according to the specified format string
-func (ts *timeService) FormatTime(t time.Time, format string) (string, error) {
-	if format == "" {
-		// Default to RFC3339 format
-		return t.Format(time.RFC3339), nil
+// NewTimeService creates a new time service instance
+func NewTimeService(opts ...Option) TimeService {
+	ts := &timeService{
+		calendars:      make(map[string]*BusinessCalendar, len(builtinCalendars)),
+		zones:          defaultZoneResolver,
+		maxOccurrences: DefaultMaxOccurrences,
 	}
-
-	// Validate format first
-	if err := ts.ValidateFormat(format); err != nil {
-		return "", err
+	for id, cal := range builtinCalendars {
+		ts.calendars[id] = cal
 	}
 
-	// Convert common format patterns to Go time format
-	goFormat := convertToGoTimeFormat(format)
+	for _, opt := range opts {
+		opt(ts)
+	}
 
-	return t.Format(goFormat), nil
+	return ts
 }
 
-// ValidateTimezone checks if a timezone string is valid
-func (ts *timeService) ValidateTimezone(timezone string) error {
+// GetCurrentTime returns the current time in the specified timezone
+func (ts *timeService) GetCurrentTime(timezone string) (time.Time, error) {
 	if timezone == "" {
-		return nil // Empty timezone is valid (defaults to UTC)
-	}
-
-	// Check for common timezone formats
-	if isValidTimezoneFormat(timezone) {
-		return nil
+		return time.Now().UTC(), nil
 	}
 
-	// Try to load the timezone to validate it
-	_, err := time.LoadLocation(timezone)
+	loc, err := ts.loadLocation(timezone)
 	if err != nil {
-		return NewInvalidTimezoneError(timezone, err)
+		return time.Time{}, err
 	}
 
-	return nil
+	return time.Now().In(loc), nil
+}
+
+// loadLocation resolves a timezone name, defaulting to UTC when empty
+func (ts *timeService) loadLocation(timezone string) (*time.Location, error) {
+	return ts.zones.Resolve(timezone)
 }
 
-// ValidateFormat checks if a format string is valid and safe
-func (ts *timeService) ValidateFormat(format string) error {
+// FormatTime formats a time according to a format string in the given dialect
+// (default, go, strftime, java/icu or moment), with month and weekday names and
+// AM/PM markers in the given locale. The formats "full", "long", "medium" and
+// "short", optionally prefixed with "date:" or "time:", select the locale's
+// default patterns.
+func (ts *timeService) FormatTime(t time.Time, format, dialect, locale string) (string, error) {
 	if format == "" {
-		return nil // Empty format is valid (uses default)
+		// Default to RFC3339 format
+		return t.Format(time.RFC3339), nil
 	}
 
-	// Check for potentially dangerous format strings
-	if containsDangerousPatterns(format) {
-		return NewInvalidFormatError(format, "format contains potentially dangerous patterns")
+	data, err := lookupLocale(locale)
+	if err != nil {
+		return "", err
 	}
 
-	// Test the format with a known time to ensure it's valid
-	testTime := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
-	goFormat := convertToGoTimeFormat(format)
+	styles := data
+	if styles == nil {
+		styles = builtinLocales["en"]
+	}
+	if pattern, ok := styles.stylePattern(format); ok {
+		format, dialect = pattern, DialectJava
+	}
 
-	_, err := time.Parse(goFormat, testTime.Format(goFormat))
+	compiled, err := compileFormat(format, dialect)
 	if err != nil {
-		return NewInvalidFormatError(format, "invalid time format")
+		return "", err
+	}
+	if err := compiled.checkLocale(data); err != nil {
+		return "", err
 	}
 
-	return nil
-}
-
-// isValidTimezoneFormat checks for common timezone formats
-func isValidTimezoneFormat(timezone string) bool {
-	// Check for UTC variants
-	if timezone == "UTC" || timezone == "GMT" {
-		return true
-	}
-
-	// Check for offset format (+/-HH:MM or +/-HHMM)
-	if len(timezone) >= 3 && (timezone[0] == '+' || timezone[0] == '-') {
-		offset := timezone[1:]
-		// Try to parse as offset
-		if _, err := time.Parse("-07:00", timezone); err == nil {
-			return true
-		}
-		if _, err := time.Parse("-0700", timezone); err == nil {
-			return true
-		}
-		if _, err := strconv.Atoi(offset); err == nil && len(offset) <= 4 {
-			return true
-		}
-	}
-
-	// Check for common abbreviations (this is a basic check)
-	commonTimezones := map[string]bool{
-		"EST": true, "CST": true, "MST": true, "PST": true,
-		"EDT": true, "CDT": true, "MDT": true, "PDT": true,
-		"CET": true, "EET": true, "WET": true,
-		"JST": true, "KST": true, "IST": true,
-	}
-
-	return commonTimezones[timezone]
-}
-
-// convertToGoTimeFormat converts common time format patterns to Go time format
-func convertToGoTimeFormat(format string) string {
-	// This is a simplified converter for common patterns
-	// In a production system, you might want a more comprehensive converter
-
-	// Common replacements
-	replacements := map[string]string{
-		"YYYY": "2006",
-		"yyyy": "2006",
-		"YY":   "06",
-		"yy":   "06",
-		"MM":   "01",
-		"DD":   "02",
-		"dd":   "02",
-		"HH":   "15",
-		"hh":   "03",
-		"mm":   "04",
-		"ss":   "05",
-		"SSS":  "000",
-	}
-
-	result := format
-	for pattern, replacement := range replacements {
-		result = replaceAll(result, pattern, replacement)
-	}
-
-	return result
+	return compiled.FormatLocale(t, data), nil
 }
 
-// replaceAll is a simple string replacement function
-func replaceAll(s, old, new string) string {
-	// Use Go's standard strings.ReplaceAll for reliable replacement
-	return strings.ReplaceAll(s, old, new)
+// ValidateTimezone checks if a timezone string can be resolved to a location
+func (ts *timeService) ValidateTimezone(timezone string) error {
+	_, err := ts.loadLocation(timezone)
+	return err
 }
 
-// containsDangerousPatterns checks for potentially dangerous format patterns
-func containsDangerousPatterns(format string) bool {
-	// Check for patterns that might be used for injection attacks
-	dangerousPatterns := []string{
-		"../", "./", "\\", "%", "$", "`", "$(", "${",
+// ValidateFormat checks if a format string is valid in the given dialect
+func (ts *timeService) ValidateFormat(format, dialect string) error {
+	if format == "" {
+		return nil // Empty format is valid (uses default)
 	}
-
-	for _, pattern := range dangerousPatterns {
-		if contains(format, pattern) {
-			return true
-		}
+	if _, ok := builtinLocales["en"].stylePattern(format); ok {
+		return nil
 	}
 
-	return false
-}
-
-// contains checks if a string contains a substring
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && indexOfSubstring(s, substr) >= 0
-}
-
-// indexOfSubstring finds the index of a substring in a string
-func indexOfSubstring(s, substr string) int {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return i
-		}
-	}
-	return -1
+	_, err := compileFormat(format, dialect)
+	return err
 }
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -8,12 +9,70 @@ import (
 func TestTimeService_GetUnixTimestamp(t *testing.T) {
 	ts := NewTimeService()
 
-	timestamp := ts.GetUnixTimestamp()
+	timestamp, err := ts.GetUnixTimestamp("", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
 
 	// Verify timestamp is reasonable (within last minute)
 	now := time.Now().Unix()
-	if timestamp < now-60 || timestamp > now+60 {
-		t.Errorf("Timestamp %d is not within reasonable range of %d", timestamp, now)
+	if timestamp.Timestamp < now-60 || timestamp.Timestamp > now+60 {
+		t.Errorf("Timestamp %d is not within reasonable range of %d", timestamp.Timestamp, now)
+	}
+	if timestamp.Unit != "s" || timestamp.Decimal != "" {
+		t.Errorf("Expected whole seconds, got %+v", timestamp)
+	}
+
+	// The timestamp and its RFC3339 rendering come from one reading
+	reading, err := time.Parse(time.RFC3339Nano, timestamp.RFC3339Nano)
+	if err != nil || reading.Unix() != timestamp.Timestamp {
+		t.Errorf("RFC3339 time %s does not match timestamp %d", timestamp.RFC3339Nano, timestamp.Timestamp)
+	}
+}
+
+func TestUnixTimestampAt(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 123456789, time.UTC)
+
+	tests := []struct {
+		unit      string
+		decimal   bool
+		timestamp int64
+		name      string
+		expected  string
+		wantErr   bool
+	}{
+		{unit: "", timestamp: 1704067200, name: "s"},
+		{unit: "s", decimal: true, timestamp: 1704067200, name: "s", expected: "1704067200.123456789"},
+		{unit: "ms", timestamp: 1704067200123, name: "ms"},
+		{unit: "ms", decimal: true, timestamp: 1704067200123, name: "ms", expected: "1704067200123.456789"},
+		{unit: "us", decimal: true, timestamp: 1704067200123456, name: "us", expected: "1704067200123456.789"},
+		{unit: "µs", timestamp: 1704067200123456, name: "us"},
+		{unit: "NS", decimal: true, timestamp: 1704067200123456789, name: "ns", expected: "1704067200123456789"},
+		{unit: "milliseconds", timestamp: 1704067200123, name: "ms"},
+		{unit: "minutes", wantErr: true},
+		{unit: "ntp", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		result, err := unixTimestampAt(now, tt.unit, tt.decimal)
+
+		if tt.wantErr {
+			var tsErr *TimeServiceError
+			if !errors.As(err, &tsErr) || tsErr.Code != ErrCodeTimeOperation {
+				t.Errorf("%q: expected an invalid unit error, got %+v, %v", tt.unit, result, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.unit, err)
+		}
+		if result.Timestamp != tt.timestamp || result.Unit != tt.name || result.Decimal != tt.expected {
+			t.Errorf("%q: expected %d %s (%q), got %+v", tt.unit, tt.timestamp, tt.name, tt.expected, result)
+		}
+		if result.RFC3339Nano != "2024-01-01T00:00:00.123456789Z" {
+			t.Errorf("%q: unexpected RFC3339 time %s", tt.unit, result.RFC3339Nano)
+		}
 	}
 }
 
@@ -105,7 +164,7 @@ func TestTimeService_FormatTime(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ts.FormatTime(testTime, tt.format)
+			result, err := ts.FormatTime(testTime, tt.format, "", "")
 
 			if tt.wantErr {
 				if err == nil {
@@ -218,7 +277,7 @@ func TestTimeService_ValidateFormat(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ts.ValidateFormat(tt.format)
+			err := ts.ValidateFormat(tt.format, "")
 
 			if tt.wantErr && err == nil {
 				t.Errorf("Expected error for format %s, but got none", tt.format)
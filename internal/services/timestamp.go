@@ -0,0 +1,629 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// leapSecondsData is the leap second table in the leap-seconds.list format
+// published by the IERS and shipped with the IANA time zone database
+//
+//go:embed zoneinfo/leap-seconds.list
+var leapSecondsData []byte
+
+// Timestamp scales accepted by ConvertTimestamp
+const (
+	ScaleAuto              = "auto"
+	ScaleUTC               = "utc"
+	ScaleUnix              = "unix"
+	ScaleUnixMillis        = "unixMillis"
+	ScaleUnixMicros        = "unixMicros"
+	ScaleUnixNanos         = "unixNanos"
+	ScaleNTP               = "ntp"
+	ScaleGPS               = "gps"
+	ScaleTAI               = "tai"
+	ScaleTAI64             = "tai64"
+	ScaleExcel             = "excel"
+	ScaleFiletime          = "filetime"
+	ScaleDotNetTicks       = "dotnetTicks"
+	ScaleMacAbsolute       = "macAbsolute"
+	ScaleJulianDay         = "julianDay"
+	ScaleModifiedJulianDay = "modifiedJulianDay"
+)
+
+// scaleAliases maps lowercase scale names and their common aliases to scales
+var scaleAliases = map[string]string{
+	"auto": ScaleAuto, "": ScaleAuto,
+	"utc": ScaleUTC, "rfc3339": ScaleUTC, "iso": ScaleUTC, "iso8601": ScaleUTC,
+	"unix": ScaleUnix, "s": ScaleUnix, "seconds": ScaleUnix, "epoch": ScaleUnix,
+	"unixmillis": ScaleUnixMillis, "ms": ScaleUnixMillis, "millis": ScaleUnixMillis, "milliseconds": ScaleUnixMillis,
+	"unixmicros": ScaleUnixMicros, "us": ScaleUnixMicros, "µs": ScaleUnixMicros, "micros": ScaleUnixMicros, "microseconds": ScaleUnixMicros,
+	"unixnanos": ScaleUnixNanos, "ns": ScaleUnixNanos, "nanos": ScaleUnixNanos, "nanoseconds": ScaleUnixNanos,
+	"ntp": ScaleNTP,
+	"gps": ScaleGPS,
+	"tai": ScaleTAI, "ptp": ScaleTAI,
+	"tai64": ScaleTAI64, "tai64n": ScaleTAI64,
+	"excel": ScaleExcel, "oadate": ScaleExcel,
+	"filetime": ScaleFiletime, "windows": ScaleFiletime,
+	"dotnetticks": ScaleDotNetTicks, "ticks": ScaleDotNetTicks, "dotnet": ScaleDotNetTicks,
+	"macabsolute": ScaleMacAbsolute, "mac": ScaleMacAbsolute, "cocoa": ScaleMacAbsolute, "coredata": ScaleMacAbsolute,
+	"julianday": ScaleJulianDay, "jd": ScaleJulianDay,
+	"modifiedjulianday": ScaleModifiedJulianDay, "mjd": ScaleModifiedJulianDay,
+}
+
+// linearScale is a count of fixed units from an epoch on the UTC time line,
+// ignoring leap seconds as Unix time does
+type linearScale struct {
+	epoch int64 // Unix seconds of the epoch
+	unit  int64 // Nanoseconds per unit
+}
+
+// Epochs of the linear scales, in Unix seconds
+const (
+	ntpEpoch          = -2208988800  // 1900-01-01
+	excelEpoch        = -2209161600  // 1899-12-30, so that 1900-03-01 is serial 61
+	excelBugEpoch     = -2209075200  // 1899-12-31, for serials before the phantom 1900-02-29
+	filetimeEpoch     = -11644473600 // 1601-01-01
+	dotNetEpoch       = -62135596800 // 0001-01-01
+	macEpoch          = 978307200    // 2001-01-01
+	julianEpoch       = -210866760000
+	mjdEpoch          = -3506716800 // 1858-11-17
+	gpsTAIEpoch       = 315964819   // 1980-01-06T00:00:00 UTC on the TAI time line
+	secondsPerGPSWeek = 7 * 24 * 3600
+	nanosPerDay       = int64(24 * time.Hour)
+)
+
+// linearScales lists the scales that are a plain count from an epoch
+var linearScales = map[string]linearScale{
+	ScaleUnix:              {0, int64(time.Second)},
+	ScaleUnixMillis:        {0, int64(time.Millisecond)},
+	ScaleUnixMicros:        {0, int64(time.Microsecond)},
+	ScaleUnixNanos:         {0, 1},
+	ScaleNTP:               {ntpEpoch, int64(time.Second)},
+	ScaleExcel:             {excelEpoch, nanosPerDay},
+	ScaleFiletime:          {filetimeEpoch, 100},
+	ScaleDotNetTicks:       {dotNetEpoch, 100},
+	ScaleMacAbsolute:       {macEpoch, int64(time.Second)},
+	ScaleJulianDay:         {julianEpoch, nanosPerDay},
+	ScaleModifiedJulianDay: {mjdEpoch, nanosPerDay},
+}
+
+// Range of instants that can be converted, the years 1 to 9999
+const (
+	minTimestamp = dotNetEpoch
+	maxTimestamp = 253402300799
+)
+
+// unixUnits maps the Unix scales to the unit names used by GetUnixTimestamp
+var unixUnits = map[string]string{
+	ScaleUnix:       "s",
+	ScaleUnixMillis: "ms",
+	ScaleUnixMicros: "us",
+	ScaleUnixNanos:  "ns",
+}
+
+// UnixTimestamp is one reading of the system clock as a Unix timestamp
+type UnixTimestamp struct {
+	Timestamp   int64  `json:"timestamp"`
+	Unit        string `json:"unit"`
+	Decimal     string `json:"decimal,omitempty"`
+	RFC3339Nano string `json:"rfc3339Nano"`
+}
+
+// TimestampConversion is one instant expressed in every supported scale.
+// Values are exact decimal strings, since many exceed the integers a JSON
+// number holds exactly.
+type TimestampConversion struct {
+	Input             string       `json:"input"`
+	Scale             string       `json:"scale"`
+	UTC               string       `json:"utc"`
+	LeapSecond        bool         `json:"leapSecond,omitempty"`
+	Unix              string       `json:"unix"`
+	UnixMillis        string       `json:"unixMillis"`
+	UnixMicros        string       `json:"unixMicros"`
+	UnixNanos         string       `json:"unixNanos"`
+	NTP               NTPTimestamp `json:"ntp"`
+	GPS               *GPSTime     `json:"gps,omitempty"`
+	TAI               TAITime      `json:"tai"`
+	Excel             string       `json:"excel,omitempty"`
+	Filetime          string       `json:"filetime,omitempty"`
+	DotNetTicks       string       `json:"dotnetTicks"`
+	MacAbsolute       string       `json:"macAbsolute"`
+	JulianDay         string       `json:"julianDay"`
+	ModifiedJulianDay string       `json:"modifiedJulianDay"`
+	LeapSecondsExpire string       `json:"leapSecondsExpire"`
+}
+
+// NTPTimestamp is an instant as NTP seconds since 1900, with the era and the
+// 64-bit wire format (32 bits of seconds within the era, 32 of fraction)
+type NTPTimestamp struct {
+	Seconds string `json:"seconds"`
+	Era     int64  `json:"era"`
+	Hex     string `json:"hex"`
+}
+
+// GPSTime is an instant as GPS seconds since 1980-01-06, which do not count
+// leap seconds, and as a GPS week and seconds into the week
+type GPSTime struct {
+	Seconds       string `json:"seconds"`
+	Week          int64  `json:"week"`
+	SecondsOfWeek string `json:"secondsOfWeek"`
+	OffsetSeconds int    `json:"offsetSeconds"` // GPS - UTC
+}
+
+// TAITime is an instant in International Atomic Time, as a date and time, as
+// seconds since 1970-01-01 TAI (the PTP timescale) and as a TAI64N label
+type TAITime struct {
+	Time          string `json:"time"`
+	Seconds       string `json:"seconds"`
+	TAI64N        string `json:"tai64n"`
+	OffsetSeconds int    `json:"offsetSeconds"` // TAI - UTC
+}
+
+// timestampInstant is an instant on both the UTC and TAI time lines. During a
+// leap second the UTC time repeats 23:59:59 and leap is set.
+type timestampInstant struct {
+	utc  time.Time
+	tai  time.Time
+	leap bool
+}
+
+// ConvertTimestamp reads a value in one timestamp scale and expresses it in
+// all of them. The auto scale reads date strings as UTC and numbers as Unix
+// seconds, milliseconds, microseconds or nanoseconds by magnitude. TAI and
+// GPS time are related to UTC by the embedded leap second table; before 1972
+// TAI is taken as UTC + 10 seconds.
+func (ts *timeService) ConvertTimestamp(value, scale string) (*TimestampConversion, error) {
+	value = strings.TrimSpace(value)
+	canonical, ok := scaleAliases[strings.ToLower(strings.TrimSpace(scale))]
+	if !ok {
+		return nil, NewTimeServiceError(ErrCodeInvalidTimestamp, fmt.Sprintf("unknown timestamp scale '%s'", scale), "scale", nil)
+	}
+	if value == "" {
+		return nil, NewInvalidTimestampError(value, canonical, "value cannot be empty")
+	}
+	if canonical == ScaleAuto {
+		canonical = detectTimestampScale(value)
+	}
+
+	instant, err := ts.readTimestamp(value, canonical)
+	if err != nil {
+		return nil, err
+	}
+	if sec := instant.utc.Unix(); sec < minTimestamp || sec > maxTimestamp {
+		return nil, NewInvalidTimestampError(value, canonical, "instant is outside the years 1 to 9999")
+	}
+
+	return newTimestampConversion(value, canonical, instant), nil
+}
+
+// GetUnixTimestamp returns the current Unix timestamp in seconds (s),
+// milliseconds (ms), microseconds (us) or nanoseconds (ns), truncated to
+// whole units. With decimal set, the exact reading is also given as a decimal
+// number of units, e.g. "1704067200.123456789" seconds. The RFC3339 rendering
+// comes from the same clock reading.
+func (ts *timeService) GetUnixTimestamp(unit string, decimal bool) (*UnixTimestamp, error) {
+	return unixTimestampAt(time.Now(), unit, decimal)
+}
+
+// unixTimestampAt expresses an instant as a Unix timestamp
+func unixTimestampAt(now time.Time, unit string, decimal bool) (*UnixTimestamp, error) {
+	scale := ScaleUnix
+	if unit != "" {
+		scale = scaleAliases[strings.ToLower(strings.TrimSpace(unit))]
+	}
+	if _, ok := unixUnits[scale]; !ok {
+		return nil, NewTimeServiceError(ErrCodeTimeOperation, fmt.Sprintf("unsupported unit '%s': expected s, ms, us or ns", unit), "unit", nil)
+	}
+
+	result := &UnixTimestamp{
+		Unit:        unixUnits[scale],
+		RFC3339Nano: now.UTC().Format(time.RFC3339Nano),
+	}
+	switch scale {
+	case ScaleUnix:
+		result.Timestamp = now.Unix()
+	case ScaleUnixMillis:
+		result.Timestamp = now.UnixMilli()
+	case ScaleUnixMicros:
+		result.Timestamp = now.UnixMicro()
+	case ScaleUnixNanos:
+		result.Timestamp = now.UnixNano()
+	}
+	if decimal {
+		result.Decimal = timeToCount(now, 0, linearScales[scale].unit)
+	}
+	return result, nil
+}
+
+// detectTimestampScale picks the scale of a value given without one
+func detectTimestampScale(value string) string {
+	if strings.HasPrefix(value, "@") {
+		return ScaleTAI64
+	}
+
+	n, err := parseTimestampNumber(value)
+	if err != nil {
+		return ScaleUTC
+	}
+
+	magnitude := new(big.Rat).Abs(n)
+	switch {
+	case magnitude.Cmp(big.NewRat(1e11, 1)) < 0:
+		return ScaleUnix
+	case magnitude.Cmp(big.NewRat(1e14, 1)) < 0:
+		return ScaleUnixMillis
+	case magnitude.Cmp(big.NewRat(1e17, 1)) < 0:
+		return ScaleUnixMicros
+	}
+	return ScaleUnixNanos
+}
+
+// readTimestamp reads a value in a scale
+func (ts *timeService) readTimestamp(value, scale string) (timestampInstant, error) {
+	switch scale {
+	case ScaleUTC:
+		return ts.readUTCTimestamp(value)
+
+	case ScaleTAI64:
+		return readTAI64(value)
+
+	case ScaleTAI:
+		if n, err := parseTimestampNumber(value); err == nil {
+			return taiInstant(countToTime(n, 0, int64(time.Second))), nil
+		}
+		t, err := ts.parseInstant(strings.TrimSuffix(strings.TrimSpace(value), " TAI"), time.UTC)
+		if err != nil {
+			return timestampInstant{}, err
+		}
+		return taiInstant(t.UTC()), nil
+
+	case ScaleGPS:
+		return readGPS(value)
+
+	case ScaleNTP:
+		if digits, ok := strings.CutPrefix(strings.ToLower(value), "0x"); ok && len(strings.ReplaceAll(digits, "_", "")) > 8 {
+			return readNTP64(value, digits)
+		}
+	}
+
+	n, err := parseTimestampNumber(value)
+	if err != nil {
+		return timestampInstant{}, NewInvalidTimestampError(value, scale, "not a number")
+	}
+
+	s := linearScales[scale]
+	if scale == ScaleExcel {
+		if n.Sign() < 0 {
+			return timestampInstant{}, NewInvalidTimestampError(value, scale, "Excel serial dates cannot be negative")
+		}
+		switch {
+		case n.Cmp(big.NewRat(60, 1)) < 0:
+			// Excel counts a 29 February 1900 that never happened
+			s.epoch = excelBugEpoch
+		case n.Cmp(big.NewRat(61, 1)) < 0:
+			return timestampInstant{}, NewInvalidTimestampError(value, scale, "Excel serial 60 is 29 February 1900, a day that never happened")
+		}
+	}
+	return utcInstant(countToTime(n, s.epoch, s.unit)), nil
+}
+
+// leapSecondPattern matches the seconds of a time written as 60
+var leapSecondPattern = regexp.MustCompile(`(\d:\d\d:)60(\D|$)`)
+
+// readUTCTimestamp reads a UTC date and time, which may be 23:59:60 during an
+// inserted leap second
+func (ts *timeService) readUTCTimestamp(value string) (timestampInstant, error) {
+	leap := leapSecondPattern.MatchString(value)
+	text := value
+	if leap {
+		text = leapSecondPattern.ReplaceAllString(value, "${1}59${2}")
+	}
+
+	t, err := ts.parseInstant(text, time.UTC)
+	if err != nil {
+		return timestampInstant{}, err
+	}
+	instant := utcInstant(t.UTC())
+	if !leap {
+		return instant, nil
+	}
+
+	if !leapTable.insertedAt(instant.utc.Truncate(time.Second).Add(time.Second)) {
+		return timestampInstant{}, NewInvalidTimestampError(value, ScaleUTC, "no leap second was inserted at this time")
+	}
+	instant.tai = instant.tai.Add(time.Second)
+	instant.leap = true
+	return instant, nil
+}
+
+// readNTP64 reads the 64-bit NTP wire format. As in RFC 4330, seconds with
+// the top bit clear are in era 1, from 2036 to 2104.
+func readNTP64(value, digits string) (timestampInstant, error) {
+	v, ok := new(big.Int).SetString(strings.ReplaceAll(digits, "_", ""), 16)
+	if !ok || v.BitLen() > 64 {
+		return timestampInstant{}, NewInvalidTimestampError(value, ScaleNTP, "expected a 64-bit hexadecimal NTP timestamp")
+	}
+
+	raw := v.Uint64()
+	seconds, fraction := int64(raw>>32), raw&0xFFFFFFFF
+	if seconds < 1<<31 {
+		seconds += 1 << 32
+	}
+	nanos := int64(fraction * uint64(time.Second) >> 32)
+	return utcInstant(time.Unix(seconds+ntpEpoch, nanos).UTC()), nil
+}
+
+// readGPS reads GPS seconds, or a GPS week and seconds of the week separated
+// by a colon or comma
+func readGPS(value string) (timestampInstant, error) {
+	var seconds *big.Rat
+	if week, sow, ok := strings.Cut(strings.ReplaceAll(value, ",", ":"), ":"); ok {
+		w, err := strconv.ParseInt(strings.TrimSpace(week), 10, 64)
+		if err != nil || w < 0 {
+			return timestampInstant{}, NewInvalidTimestampError(value, ScaleGPS, "invalid GPS week")
+		}
+		s, err := parseTimestampNumber(strings.TrimSpace(sow))
+		if err != nil || s.Sign() < 0 || s.Cmp(big.NewRat(secondsPerGPSWeek, 1)) >= 0 {
+			return timestampInstant{}, NewInvalidTimestampError(value, ScaleGPS, fmt.Sprintf("seconds of the week must be from 0 to %d", secondsPerGPSWeek))
+		}
+		seconds = s.Add(s, big.NewRat(w*secondsPerGPSWeek, 1))
+	} else {
+		s, err := parseTimestampNumber(value)
+		if err != nil {
+			return timestampInstant{}, NewInvalidTimestampError(value, ScaleGPS, "not a number or week:seconds")
+		}
+		seconds = s
+	}
+
+	return taiInstant(countToTime(seconds, gpsTAIEpoch, int64(time.Second))), nil
+}
+
+// readTAI64 reads a TAI64, TAI64N or TAI64NA label such as
+// "@4000000065920a250c8a5a80"; attoseconds are dropped
+func readTAI64(value string) (timestampInstant, error) {
+	digits := strings.TrimPrefix(value, "@")
+	if len(digits) != 16 && len(digits) != 24 && len(digits) != 32 {
+		return timestampInstant{}, NewInvalidTimestampError(value, ScaleTAI64, "expected '@' and 16, 24 or 32 hexadecimal digits")
+	}
+
+	label, err := strconv.ParseUint(digits[:16], 16, 64)
+	if err != nil || label < 1<<62 || label >= 1<<63 {
+		return timestampInstant{}, NewInvalidTimestampError(value, ScaleTAI64, "invalid TAI64 label")
+	}
+	var nanos uint64
+	if len(digits) > 16 {
+		if nanos, err = strconv.ParseUint(digits[16:24], 16, 32); err != nil || nanos >= uint64(time.Second) {
+			return timestampInstant{}, NewInvalidTimestampError(value, ScaleTAI64, "invalid nanoseconds")
+		}
+	}
+
+	return taiInstant(time.Unix(int64(label-1<<62), int64(nanos)).UTC()), nil
+}
+
+// parseTimestampNumber reads a decimal number, possibly with a fraction or
+// exponent, or a hexadecimal integer with a 0x prefix. Underscores may
+// separate digits.
+func parseTimestampNumber(value string) (*big.Rat, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), "_", "")
+	if digits, ok := strings.CutPrefix(strings.ToLower(value), "0x"); ok {
+		n, ok := new(big.Int).SetString(digits, 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid hexadecimal number '%s'", value)
+		}
+		return new(big.Rat).SetInt(n), nil
+	}
+
+	// Bound the exponent so a value like 1e999999999 cannot exhaust memory
+	if i := strings.IndexAny(value, "eE"); i >= 0 {
+		if exp, err := strconv.Atoi(value[i+1:]); err != nil || exp < -30 || exp > 30 {
+			return nil, fmt.Errorf("invalid exponent in '%s'", value)
+		}
+	}
+
+	n, ok := new(big.Rat).SetString(value)
+	if !ok || strings.Contains(value, "/") {
+		return nil, fmt.Errorf("invalid number '%s'", value)
+	}
+	return n, nil
+}
+
+// countToTime converts a count of units since an epoch to an instant,
+// truncating to whole nanoseconds
+func countToTime(count *big.Rat, epoch, unit int64) time.Time {
+	nanos := new(big.Rat).Mul(count, big.NewRat(unit, 1))
+	whole := new(big.Int).Div(nanos.Num(), nanos.Denom())
+
+	seconds, rem := new(big.Int).DivMod(whole, big.NewInt(int64(time.Second)), new(big.Int))
+	seconds.Add(seconds, big.NewInt(epoch))
+	if !seconds.IsInt64() {
+		// Far outside the supported range; rejected by the caller
+		return time.Unix(maxTimestamp+1, 0).UTC()
+	}
+	return time.Unix(seconds.Int64(), rem.Int64()).UTC()
+}
+
+// timeToCount converts an instant to a count of units since an epoch
+func timeToCount(t time.Time, epoch, unit int64) string {
+	nanos := new(big.Int).Mul(big.NewInt(t.Unix()-epoch), big.NewInt(int64(time.Second)))
+	nanos.Add(nanos, big.NewInt(int64(t.Nanosecond())))
+
+	// Enough decimals to show a single nanosecond, up to 11 for day counts
+	decimals := min(len(strconv.FormatInt(unit, 10))-1, 11)
+	s := new(big.Rat).SetFrac(nanos, big.NewInt(unit)).FloatString(decimals)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
+
+// newTimestampConversion expresses an instant in every scale
+func newTimestampConversion(input, scale string, instant timestampInstant) *TimestampConversion {
+	t, tai := instant.utc, instant.tai
+	count := func(scale string) string {
+		s := linearScales[scale]
+		return timeToCount(t, s.epoch, s.unit)
+	}
+
+	utc := t.Format(time.RFC3339Nano)
+	if instant.leap {
+		utc = strings.Replace(utc, "T23:59:59", "T23:59:60", 1)
+	}
+	offset := int(tai.Sub(t) / time.Second)
+
+	ntpSeconds := t.Unix() - ntpEpoch
+	era := ntpSeconds >> 32
+	fraction := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+
+	result := &TimestampConversion{
+		Input:      input,
+		Scale:      scale,
+		UTC:        utc,
+		LeapSecond: instant.leap,
+		Unix:       count(ScaleUnix),
+		UnixMillis: count(ScaleUnixMillis),
+		UnixMicros: count(ScaleUnixMicros),
+		UnixNanos:  count(ScaleUnixNanos),
+		NTP: NTPTimestamp{
+			Seconds: count(ScaleNTP),
+			Era:     era,
+			Hex:     fmt.Sprintf("0x%08X%08X", ntpSeconds-era<<32, fraction),
+		},
+		TAI: TAITime{
+			Time:          tai.Format("2006-01-02T15:04:05.999999999") + " TAI",
+			Seconds:       timeToCount(tai, 0, int64(time.Second)),
+			TAI64N:        fmt.Sprintf("@%016x%08x", uint64(tai.Unix())+1<<62, tai.Nanosecond()),
+			OffsetSeconds: offset,
+		},
+		DotNetTicks:       count(ScaleDotNetTicks),
+		MacAbsolute:       count(ScaleMacAbsolute),
+		JulianDay:         count(ScaleJulianDay),
+		ModifiedJulianDay: count(ScaleModifiedJulianDay),
+		LeapSecondsExpire: leapTable.expires.Format("2006-01-02"),
+	}
+
+	if tai.Unix() >= gpsTAIEpoch {
+		week := (tai.Unix() - gpsTAIEpoch) / secondsPerGPSWeek
+		result.GPS = &GPSTime{
+			Seconds:       timeToCount(tai, gpsTAIEpoch, int64(time.Second)),
+			Week:          week,
+			SecondsOfWeek: timeToCount(tai, gpsTAIEpoch+week*secondsPerGPSWeek, int64(time.Second)),
+			OffsetSeconds: offset - 19,
+		}
+	}
+	switch {
+	case t.Unix() >= excelEpoch+61*24*3600:
+		result.Excel = count(ScaleExcel)
+	case t.Unix() >= excelBugEpoch:
+		result.Excel = timeToCount(t, excelBugEpoch, nanosPerDay)
+	}
+	if t.Unix() >= filetimeEpoch {
+		result.Filetime = count(ScaleFiletime)
+	}
+
+	return result
+}
+
+// leapSecond is an entry of the leap second table: from start (UTC), TAI is
+// ahead of UTC by offset seconds
+type leapSecond struct {
+	start  time.Time
+	offset int
+}
+
+// leapSecondTable is the parsed leap second table
+type leapSecondTable struct {
+	entries []leapSecond
+	expires time.Time
+}
+
+// leapTable holds the leap seconds embedded in the binary
+var leapTable = mustLoadLeapSeconds()
+
+// mustLoadLeapSeconds parses the embedded leap-seconds.list file
+func mustLoadLeapSeconds() leapSecondTable {
+	var table leapSecondTable
+	ntpTime := func(field string) time.Time {
+		seconds, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded leap second time %q: %v", field, err))
+		}
+		return time.Unix(seconds+ntpEpoch, 0).UTC()
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(leapSecondsData))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if expires, ok := strings.CutPrefix(line, "#@"); ok {
+			table.expires = ntpTime(strings.TrimSpace(expires))
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		offset, err := strconv.Atoi(fields[1])
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded leap second offset %q: %v", fields[1], err))
+		}
+		table.entries = append(table.entries, leapSecond{start: ntpTime(fields[0]), offset: offset})
+	}
+
+	if len(table.entries) == 0 {
+		panic("embedded leap second table is empty")
+	}
+	return table
+}
+
+// offsetAt returns TAI - UTC in seconds at a UTC instant
+func (table leapSecondTable) offsetAt(t time.Time) int {
+	for i := len(table.entries) - 1; i >= 0; i-- {
+		if !t.Before(table.entries[i].start) {
+			return table.entries[i].offset
+		}
+	}
+	return table.entries[0].offset
+}
+
+// insertedAt reports whether a leap second was inserted just before t
+func (table leapSecondTable) insertedAt(t time.Time) bool {
+	for i := 1; i < len(table.entries); i++ {
+		if table.entries[i].start.Equal(t) {
+			return table.entries[i].offset > table.entries[i-1].offset
+		}
+	}
+	return false
+}
+
+// utcInstant places a UTC instant on the TAI time line
+func utcInstant(t time.Time) timestampInstant {
+	return timestampInstant{utc: t, tai: t.Add(time.Duration(leapTable.offsetAt(t)) * time.Second)}
+}
+
+// taiInstant finds the UTC time of a TAI instant. An instant within an
+// inserted leap second is reported as 23:59:59 UTC with leap set.
+func taiInstant(tai time.Time) timestampInstant {
+	entries := leapTable.entries
+	for i := len(entries) - 1; i >= 0; i-- {
+		start := entries[i].start
+		if !tai.Before(start.Add(time.Duration(entries[i].offset) * time.Second)) {
+			return timestampInstant{utc: tai.Add(-time.Duration(entries[i].offset) * time.Second), tai: tai}
+		}
+		if i > 0 && !tai.Before(start.Add(time.Duration(entries[i-1].offset)*time.Second)) {
+			into := tai.Sub(start.Add(time.Duration(entries[i-1].offset) * time.Second))
+			return timestampInstant{utc: start.Add(-time.Second + into), tai: tai, leap: true}
+		}
+	}
+	return timestampInstant{utc: tai.Add(-time.Duration(entries[0].offset) * time.Second), tai: tai}
+}
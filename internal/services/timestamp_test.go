@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTimeService_ConvertTimestamp(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name         string
+		value, scale string
+		detected     string
+		utc          string
+		leap         bool
+		wantErr      bool
+		errCode      int
+	}{
+		{name: "unix seconds", value: "1704067200", detected: ScaleUnix, utc: "2024-01-01T00:00:00Z"},
+		{name: "unix millis by magnitude", value: "1704067200123", detected: ScaleUnixMillis, utc: "2024-01-01T00:00:00.123Z"},
+		{name: "unix nanos by magnitude", value: "1704067200123456789", detected: ScaleUnixNanos, utc: "2024-01-01T00:00:00.123456789Z"},
+		{name: "fractional seconds", value: "1704067200.5", scale: "s", detected: ScaleUnix, utc: "2024-01-01T00:00:00.5Z"},
+		{name: "date string", value: "2024-01-01T01:00:00+01:00", detected: ScaleUTC, utc: "2024-01-01T00:00:00Z"},
+		{name: "ntp seconds", value: "3913056000", scale: "ntp", detected: ScaleNTP, utc: "2024-01-01T00:00:00Z"},
+		{name: "ntp 64-bit", value: "0xE93C7F0080000000", scale: "ntp", detected: ScaleNTP, utc: "2024-01-01T00:00:00.5Z"},
+		{name: "ntp era 1", value: "0x0000F68000000000", scale: "ntp", detected: ScaleNTP, utc: "2036-02-08T00:00:00Z"},
+		{name: "gps seconds", value: "1388102418", scale: "gps", detected: ScaleGPS, utc: "2024-01-01T00:00:00Z"},
+		{name: "gps week and seconds", value: "2295:86418", scale: "gps", detected: ScaleGPS, utc: "2024-01-01T00:00:00Z"},
+		{name: "tai seconds", value: "1704067237", scale: "ptp", detected: ScaleTAI, utc: "2024-01-01T00:00:00Z"},
+		{name: "tai date", value: "2024-01-01T00:00:37 TAI", scale: "tai", detected: ScaleTAI, utc: "2024-01-01T00:00:00Z"},
+		{name: "tai during a leap second", value: "1483228836.5", scale: "tai", detected: ScaleTAI, utc: "2016-12-31T23:59:60.5Z", leap: true},
+		{name: "utc during a leap second", value: "2016-12-31T23:59:60.5Z", detected: ScaleUTC, utc: "2016-12-31T23:59:60.5Z", leap: true},
+		{name: "utc leap second", value: "2016-12-31T23:59:60Z", scale: "utc", detected: ScaleUTC, utc: "2016-12-31T23:59:60Z", leap: true},
+		{name: "utc leap second with an offset", value: "2017-01-01T00:59:60+01:00", scale: "utc", detected: ScaleUTC, utc: "2016-12-31T23:59:60Z", leap: true},
+		{name: "utc without a leap second", value: "2017-06-30T23:59:60Z", scale: "utc", wantErr: true, errCode: ErrCodeInvalidTimestamp},
+		{name: "tai before 1972", value: "10", scale: "tai", detected: ScaleTAI, utc: "1970-01-01T00:00:00Z"},
+		{name: "tai64n label", value: "@40000000659200a500000000", detected: ScaleTAI64, utc: "2024-01-01T00:00:00Z"},
+		{name: "excel serial", value: "45292.75", scale: "excel", detected: ScaleExcel, utc: "2024-01-01T18:00:00Z"},
+		{name: "excel before the phantom leap day", value: "59", scale: "excel", detected: ScaleExcel, utc: "1900-02-28T00:00:00Z"},
+		{name: "filetime", value: "133485408000000000", scale: "filetime", detected: ScaleFiletime, utc: "2024-01-01T00:00:00Z"},
+		{name: ".NET ticks", value: "638396640000000000", scale: "ticks", detected: ScaleDotNetTicks, utc: "2024-01-01T00:00:00Z"},
+		{name: "mac absolute time", value: "725760000", scale: "cocoa", detected: ScaleMacAbsolute, utc: "2024-01-01T00:00:00Z"},
+		{name: "julian day", value: "2460310.5", scale: "jd", detected: ScaleJulianDay, utc: "2024-01-01T00:00:00Z"},
+		{name: "modified julian day", value: "60310", scale: "MJD", detected: ScaleModifiedJulianDay, utc: "2024-01-01T00:00:00Z"},
+		{name: "unknown scale", value: "1", scale: "stardate", wantErr: true, errCode: ErrCodeInvalidTimestamp},
+		{name: "not a number", value: "12abc", scale: "ms", wantErr: true, errCode: ErrCodeInvalidTimestamp},
+		{name: "out of range", value: "1e20", scale: "unix", wantErr: true, errCode: ErrCodeInvalidTimestamp},
+		{name: "huge exponent", value: "1e999999999", scale: "unix", wantErr: true, errCode: ErrCodeInvalidTimestamp},
+		{name: "excel phantom leap day", value: "60.5", scale: "excel", wantErr: true, errCode: ErrCodeInvalidTimestamp},
+		{name: "negative excel serial", value: "-1", scale: "excel", wantErr: true, errCode: ErrCodeInvalidTimestamp},
+		{name: "gps seconds of week too large", value: "2295:604800", scale: "gps", wantErr: true, errCode: ErrCodeInvalidTimestamp},
+		{name: "invalid tai64 label", value: "@12", wantErr: true, errCode: ErrCodeInvalidTimestamp},
+		{name: "empty value", value: "", wantErr: true, errCode: ErrCodeInvalidTimestamp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.ConvertTimestamp(tt.value, tt.scale)
+
+			if tt.wantErr {
+				var tsErr *TimeServiceError
+				if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+					t.Errorf("Expected error code %d, got %+v, %v", tt.errCode, result, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Scale != tt.detected || result.UTC != tt.utc || result.LeapSecond != tt.leap {
+				t.Errorf("Expected %s read as %s (leap %v), got %s (leap %v) as %s", tt.value, tt.utc, tt.leap, result.UTC, result.LeapSecond, result.Scale)
+			}
+		})
+	}
+}
+
+func TestTimeService_ConvertTimestampScales(t *testing.T) {
+	ts := NewTimeService()
+
+	result, err := ts.ConvertTimestamp("2024-01-01T00:00:00.123456789Z", "utc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string][2]string{
+		"unix":              {result.Unix, "1704067200.123456789"},
+		"unixMillis":        {result.UnixMillis, "1704067200123.456789"},
+		"unixMicros":        {result.UnixMicros, "1704067200123456.789"},
+		"unixNanos":         {result.UnixNanos, "1704067200123456789"},
+		"ntp":               {result.NTP.Seconds, "3913056000.123456789"},
+		"ntp hex":           {result.NTP.Hex, "0xE93C7F001F9ADD37"},
+		"tai":               {result.TAI.Time, "2024-01-01T00:00:37.123456789 TAI"},
+		"tai seconds":       {result.TAI.Seconds, "1704067237.123456789"},
+		"tai64n":            {result.TAI.TAI64N, "@40000000659200a5075bcd15"},
+		"gps":               {result.GPS.Seconds, "1388102418.123456789"},
+		"gps seconds":       {result.GPS.SecondsOfWeek, "86418.123456789"},
+		"excel":             {result.Excel, "45292.0000014289"},
+		"filetime":          {result.Filetime, "133485408001234567.89"},
+		"dotnetTicks":       {result.DotNetTicks, "638396640001234567.89"},
+		"macAbsolute":       {result.MacAbsolute, "725760000.123456789"},
+		"julianDay":         {result.JulianDay, "2460310.5000014289"},
+		"modifiedJulianDay": {result.ModifiedJulianDay, "60310.0000014289"},
+	}
+	for name, values := range expected {
+		if values[0] != values[1] {
+			t.Errorf("%s: expected %s, got %s", name, values[1], values[0])
+		}
+	}
+
+	if result.GPS.Week != 2295 || result.GPS.OffsetSeconds != 18 || result.TAI.OffsetSeconds != 37 || result.NTP.Era != 0 {
+		t.Errorf("Unexpected GPS week %d, offsets %d and %d, NTP era %d", result.GPS.Week, result.GPS.OffsetSeconds, result.TAI.OffsetSeconds, result.NTP.Era)
+	}
+
+	// Scales that start after the instant are left out
+	early, err := ts.ConvertTimestamp("1600-01-01", "utc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if early.GPS != nil || early.Excel != "" || early.Filetime != "" {
+		t.Errorf("Expected no GPS, Excel or FILETIME values in 1600, got %+v, %q, %q", early.GPS, early.Excel, early.Filetime)
+	}
+}
+
+func TestLeapSecondTable(t *testing.T) {
+	entries := leapTable.entries
+	if len(entries) < 28 || entries[0].offset != 10 || entries[0].start.Format("2006-01-02") != "1972-01-01" {
+		t.Fatalf("Unexpected leap second table starting %+v", entries[0])
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].offset != entries[i-1].offset+1 || !entries[i].start.After(entries[i-1].start) {
+			t.Errorf("Entry %d does not add one leap second: %+v after %+v", i, entries[i], entries[i-1])
+		}
+	}
+	if !leapTable.expires.After(entries[len(entries)-1].start) {
+		t.Errorf("Expiry %s is before the last leap second", leapTable.expires)
+	}
+}
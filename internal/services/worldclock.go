@@ -0,0 +1,97 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxWorldClockZones caps the zones shown by one world clock
+const maxWorldClockZones = 50
+
+// WorldClock shows one instant in several timezones
+type WorldClock struct {
+	Instant string      `json:"instant"`
+	Unix    int64       `json:"unix"`
+	Clocks  []ZoneClock `json:"clocks"`
+}
+
+// ZoneClock is the local time in one zone of a world clock. DayDifference and
+// OffsetDifference are relative to the first zone.
+type ZoneClock struct {
+	ZonedTime
+	Formatted        string `json:"formatted,omitempty"`
+	Date             string `json:"date"`
+	Weekday          string `json:"weekday"`
+	DayDifference    int    `json:"dayDifference"`
+	OffsetDifference string `json:"offsetDifference"`
+	DST              bool   `json:"dst"`
+}
+
+// WorldClock returns the current time in each zone, all from a single reading
+// of the system clock. Format, dialect and locale are applied as in
+// FormatTime to fill the formatted field; it is omitted when format is empty.
+func (ts *timeService) WorldClock(zones []string, format, dialect, locale string) (*WorldClock, error) {
+	return ts.worldClockAt(time.Now(), zones, format, dialect, locale)
+}
+
+// worldClockAt builds a world clock for a given instant
+func (ts *timeService) worldClockAt(now time.Time, zones []string, format, dialect, locale string) (*WorldClock, error) {
+	switch {
+	case len(zones) == 0:
+		return nil, NewTimeServiceError(ErrCodeTimeOperation, "at least one timezone is required", "timezones", nil)
+	case len(zones) > maxWorldClockZones:
+		return nil, NewTimeServiceError(ErrCodeTimeOperation, fmt.Sprintf("too many timezones: at most %d are supported", maxWorldClockZones), "timezones", nil)
+	}
+
+	clock := &WorldClock{
+		Instant: now.UTC().Format(time.RFC3339Nano),
+		Unix:    now.Unix(),
+		Clocks:  make([]ZoneClock, 0, len(zones)),
+	}
+
+	var firstDate time.Time
+	var firstOffset int
+	for i, zone := range zones {
+		loc, err := ts.loadLocation(zone)
+		if err != nil {
+			return nil, err
+		}
+		local := now.In(loc)
+
+		formatted := ""
+		if format != "" {
+			if formatted, err = ts.FormatTime(local, format, dialect, locale); err != nil {
+				return nil, err
+			}
+		}
+
+		year, month, day := local.Date()
+		date := civilDate(year, month, day)
+		_, offset := local.Zone()
+		if i == 0 {
+			firstDate, firstOffset = date, offset
+		}
+
+		clock.Clocks = append(clock.Clocks, ZoneClock{
+			ZonedTime:        newZonedTime(local, zone),
+			Formatted:        formatted,
+			Date:             date.Format("2006-01-02"),
+			Weekday:          local.Weekday().String(),
+			DayDifference:    int(date.Sub(firstDate).Hours() / 24),
+			OffsetDifference: formatOffsetDifference(offset - firstOffset),
+			DST:              local.IsDST(),
+		})
+	}
+
+	return clock, nil
+}
+
+// formatOffsetDifference formats a difference in seconds between two UTC
+// offsets as a signed "+05:30"
+func formatOffsetDifference(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign, seconds = '-', -seconds
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeService_WorldClock(t *testing.T) {
+	ts := NewTimeService().(*timeService)
+	// Half an hour before the clocks go forward in London
+	now := time.Date(2024, time.March, 31, 0, 30, 0, 0, time.UTC)
+
+	clock, err := ts.worldClockAt(now, []string{"America/Los_Angeles", "Europe/London", "Asia/Kolkata", "Pacific/Kiritimati"}, "%A %H:%M", DialectStrftime, "fr")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if clock.Instant != "2024-03-31T00:30:00Z" || clock.Unix != now.Unix() {
+		t.Errorf("Unexpected instant %s (%d)", clock.Instant, clock.Unix)
+	}
+
+	expected := []struct {
+		time, formatted, weekday string
+		days                     int
+		difference               string
+		dst                      bool
+	}{
+		{"2024-03-30T17:30:00-07:00", "samedi 17:30", "Saturday", 0, "+00:00", true},
+		{"2024-03-31T00:30:00Z", "dimanche 00:30", "Sunday", 1, "+07:00", false},
+		{"2024-03-31T06:00:00+05:30", "dimanche 06:00", "Sunday", 1, "+12:30", false},
+		{"2024-03-31T14:30:00+14:00", "dimanche 14:30", "Sunday", 1, "+21:00", false},
+	}
+	if len(clock.Clocks) != len(expected) {
+		t.Fatalf("Expected %d clocks, got %+v", len(expected), clock.Clocks)
+	}
+	for i, e := range expected {
+		c := clock.Clocks[i]
+		if c.Time != e.time || c.Formatted != e.formatted || c.Weekday != e.weekday {
+			t.Errorf("Clock %d: expected %s %q %s, got %s %q %s", i, e.time, e.formatted, e.weekday, c.Time, c.Formatted, c.Weekday)
+		}
+		if c.DayDifference != e.days || c.OffsetDifference != e.difference || c.DST != e.dst {
+			t.Errorf("Clock %d: expected day %+d, offset %s, DST %v, got %+d, %s, %v", i, e.days, e.difference, e.dst, c.DayDifference, c.OffsetDifference, c.DST)
+		}
+	}
+
+	// Behind the first zone
+	clock, err = ts.worldClockAt(now, []string{"Asia/Tokyo", "America/New_York"}, "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c := clock.Clocks[1]; c.DayDifference != -1 || c.OffsetDifference != "-13:00" || c.Formatted != "" {
+		t.Errorf("Expected the previous day 13 hours behind, got %+v", c)
+	}
+}
+
+func TestTimeService_WorldClockErrors(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name    string
+		zones   []string
+		format  string
+		errCode int
+	}{
+		{name: "no zones", errCode: ErrCodeTimeOperation},
+		{name: "invalid zone", zones: []string{"UTC", "Mars/Base"}, errCode: ErrCodeInvalidTimezone},
+		{name: "invalid format", zones: []string{"UTC"}, format: "%Q", errCode: ErrCodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ts.WorldClock(tt.zones, tt.format, DialectStrftime, "")
+			var tsErr *TimeServiceError
+			if !errors.As(err, &tsErr) || tsErr.Code != tt.errCode {
+				t.Errorf("Expected error code %d, got %v", tt.errCode, err)
+			}
+		})
+	}
+}
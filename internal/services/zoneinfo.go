@@ -0,0 +1,146 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxZoneInfoWindow limits the span searched for offset transitions
+const maxZoneInfoWindow = 100 * 366 * 24 * time.Hour
+
+// ZoneTransition is a change of UTC offset, abbreviation or DST state
+type ZoneTransition struct {
+	At               string `json:"at"`
+	LocalBefore      string `json:"localBefore"`
+	LocalAfter       string `json:"localAfter"`
+	OldOffset        string `json:"oldOffset"`
+	NewOffset        string `json:"newOffset"`
+	OldOffsetSeconds int    `json:"oldOffsetSeconds"`
+	NewOffsetSeconds int    `json:"newOffsetSeconds"`
+	OldAbbreviation  string `json:"oldAbbreviation"`
+	NewAbbreviation  string `json:"newAbbreviation"`
+	IsDST            bool   `json:"isDST"`
+	ShiftMinutes     int    `json:"shiftMinutes"`
+	Effect           string `json:"effect,omitempty"`
+}
+
+// ZoneDetails describes a timezone's current state and its transitions in a window
+type ZoneDetails struct {
+	Timezone      string           `json:"timezone"`
+	CurrentTime   string           `json:"currentTime"`
+	Offset        string           `json:"offset"`
+	OffsetSeconds int              `json:"offsetSeconds"`
+	Abbreviation  string           `json:"abbreviation"`
+	IsDST         bool             `json:"isDST"`
+	From          string           `json:"from"`
+	To            string           `json:"to"`
+	Transitions   []ZoneTransition `json:"transitions"`
+}
+
+// ZoneInfo returns the current offset of a timezone and the transitions
+// between from and to (defaulting to now and one year later). Go does not
+// expose a location's transition table, so transitions are found by walking
+// the zone periods reported by time.Time.ZoneBounds.
+func (ts *timeService) ZoneInfo(timezone, from, to string) (*ZoneDetails, error) {
+	loc, err := ts.loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	if from == "" {
+		from = "now"
+	}
+	start, err := ts.parseInstant(from, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	end := start.AddDate(1, 0, 0)
+	if to != "" {
+		if end, err = ts.parseInstant(to, loc); err != nil {
+			return nil, err
+		}
+	}
+
+	if end.Before(start) {
+		return nil, NewTimeServiceError(
+			ErrCodeTimeOperation,
+			"end of window must not be before its start",
+			"to",
+			nil,
+		)
+	}
+	if end.Sub(start) >= maxZoneInfoWindow {
+		return nil, NewTimeServiceError(
+			ErrCodeTimeOperation,
+			fmt.Sprintf("window cannot exceed %d years", int(maxZoneInfoWindow.Hours()/24/366)),
+			"to",
+			nil,
+		)
+	}
+
+	now := time.Now().In(loc)
+	abbreviation, offset := now.Zone()
+
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	return &ZoneDetails{
+		Timezone:      timezone,
+		CurrentTime:   now.Format(time.RFC3339),
+		Offset:        now.Format("-07:00"),
+		OffsetSeconds: offset,
+		Abbreviation:  abbreviation,
+		IsDST:         now.IsDST(),
+		From:          start.In(loc).Format(time.RFC3339),
+		To:            end.In(loc).Format(time.RFC3339),
+		Transitions:   zoneTransitions(start.In(loc), end.In(loc)),
+	}, nil
+}
+
+// zoneTransitions lists the visible transitions in (start, end]. Zone periods
+// that differ only internally (same offset, abbreviation and DST flag) are skipped.
+func zoneTransitions(start, end time.Time) []ZoneTransition {
+	transitions := []ZoneTransition{}
+
+	t := start
+	for {
+		_, next := t.ZoneBounds()
+		if next.IsZero() || next.After(end) || !next.After(t) {
+			return transitions
+		}
+
+		before := next.Add(-time.Nanosecond)
+		oldAbbr, oldOffset := before.Zone()
+		newAbbr, newOffset := next.Zone()
+		t = next
+
+		if oldAbbr == newAbbr && oldOffset == newOffset && before.IsDST() == next.IsDST() {
+			continue
+		}
+
+		transition := ZoneTransition{
+			At:               next.UTC().Format(time.RFC3339),
+			LocalBefore:      next.In(time.FixedZone(oldAbbr, oldOffset)).Format("2006-01-02T15:04:05"),
+			LocalAfter:       next.Format("2006-01-02T15:04:05"),
+			OldOffset:        before.Format("-07:00"),
+			NewOffset:        next.Format("-07:00"),
+			OldOffsetSeconds: oldOffset,
+			NewOffsetSeconds: newOffset,
+			OldAbbreviation:  oldAbbr,
+			NewAbbreviation:  newAbbr,
+			IsDST:            next.IsDST(),
+			ShiftMinutes:     (newOffset - oldOffset) / 60,
+		}
+
+		switch {
+		case newOffset > oldOffset:
+			transition.Effect = fmt.Sprintf("local times from %s to %s are skipped", transition.LocalBefore, transition.LocalAfter)
+		case newOffset < oldOffset:
+			transition.Effect = fmt.Sprintf("local times from %s to %s occur twice", transition.LocalAfter, transition.LocalBefore)
+		}
+
+		transitions = append(transitions, transition)
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import "testing"
+
+func TestTimeService_ZoneInfo(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name     string
+		timezone string
+		from     string
+		to       string
+		expected []ZoneTransition
+		wantErr  bool
+	}{
+		{
+			name:     "London DST changes in 2025",
+			timezone: "Europe/London",
+			from:     "2025-01-01T00:00:00Z",
+			to:       "2026-01-01T00:00:00Z",
+			expected: []ZoneTransition{
+				{At: "2025-03-30T01:00:00Z", LocalBefore: "2025-03-30T01:00:00", LocalAfter: "2025-03-30T02:00:00", NewAbbreviation: "BST", ShiftMinutes: 60, IsDST: true},
+				{At: "2025-10-26T01:00:00Z", LocalBefore: "2025-10-26T02:00:00", LocalAfter: "2025-10-26T01:00:00", NewAbbreviation: "GMT", ShiftMinutes: -60},
+			},
+		},
+		{
+			name:     "Zone without DST",
+			timezone: "Asia/Tokyo",
+			from:     "2025-01-01T00:00:00Z",
+			to:       "2026-01-01T00:00:00Z",
+			expected: []ZoneTransition{},
+		},
+		{
+			name:     "Historical offset change",
+			timezone: "Asia/Kolkata",
+			from:     "1945-01-01T00:00:00Z",
+			to:       "1946-01-01T00:00:00Z",
+			expected: []ZoneTransition{
+				{At: "1945-10-14T17:30:00Z", LocalBefore: "1945-10-15T00:00:00", LocalAfter: "1945-10-14T23:00:00", NewAbbreviation: "IST", ShiftMinutes: -60},
+			},
+		},
+		{
+			name:     "Reversed window",
+			timezone: "Europe/London",
+			from:     "2026-01-01T00:00:00Z",
+			to:       "2025-01-01T00:00:00Z",
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid timezone",
+			timezone: "Invalid/Timezone",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.ZoneInfo(tt.timezone, tt.from, tt.to)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(result.Transitions) != len(tt.expected) {
+				t.Fatalf("Expected %d transitions, got %+v", len(tt.expected), result.Transitions)
+			}
+
+			for i, want := range tt.expected {
+				got := result.Transitions[i]
+				if got.At != want.At || got.LocalBefore != want.LocalBefore || got.LocalAfter != want.LocalAfter ||
+					got.NewAbbreviation != want.NewAbbreviation || got.ShiftMinutes != want.ShiftMinutes || got.IsDST != want.IsDST {
+					t.Errorf("Transition %d: expected %+v, got %+v", i, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// zoneCatalogData lists IANA zone and link names with their countries,
+// generated from the tzdata.zi, zone.tab, zone1970.tab and iso3166.tab files
+// of the IANA time zone database
+//
+//go:embed zoneinfo/catalog.json
+var zoneCatalogData []byte
+
+// defaultSearchLimit is the number of search results returned when no limit is given
+const defaultSearchLimit = 20
+
+// TimezoneInfo describes an IANA timezone and its current state
+type TimezoneInfo struct {
+	Name          string   `json:"name"`
+	Canonical     bool     `json:"canonical"`
+	LinkTarget    string   `json:"linkTarget,omitempty"`
+	Countries     []string `json:"countries,omitempty"`
+	Offset        string   `json:"offset"`
+	OffsetSeconds int      `json:"offsetSeconds"`
+	Abbreviation  string   `json:"abbreviation"`
+	IsDST         bool     `json:"isDST"`
+}
+
+// TimezoneList is a list of timezones matching a filter or search
+type TimezoneList struct {
+	Version   string         `json:"tzdataVersion"`
+	Count     int            `json:"count"`
+	Timezones []TimezoneInfo `json:"timezones"`
+}
+
+// zoneCatalogFile is the format of the embedded zone catalog
+type zoneCatalogFile struct {
+	Version       string              `json:"version"`
+	Zones         []string            `json:"zones"`
+	Links         map[string]string   `json:"links"`
+	ZoneCountries map[string][]string `json:"zoneCountries"`
+	CountryNames  map[string]string   `json:"countryNames"`
+}
+
+// zoneEntry is a zone or link known to the process
+type zoneEntry struct {
+	name         string
+	linkTarget   string
+	countries    []string
+	countryNames []string
+	searchText   string
+	loc          *time.Location
+}
+
+// zoneCatalog holds every zone from the embedded catalog that the process can load
+type zoneCatalog struct {
+	version      string
+	entries      []zoneEntry
+	countryNames map[string]string
+}
+
+var (
+	zoneCatalogOnce   sync.Once
+	loadedZoneCatalog *zoneCatalog
+)
+
+// getZoneCatalog returns the zone catalog, loading it on first use
+func getZoneCatalog() *zoneCatalog {
+	zoneCatalogOnce.Do(func() {
+		var file zoneCatalogFile
+		if err := json.Unmarshal(zoneCatalogData, &file); err != nil {
+			panic(fmt.Sprintf("failed to parse embedded zone catalog: %v", err))
+		}
+
+		catalog := &zoneCatalog{
+			version:      file.Version,
+			countryNames: file.CountryNames,
+		}
+
+		add := func(name, target string) {
+			// Only list zones the process can actually load from system or embedded tzdata
+			loc, err := time.LoadLocation(name)
+			if err != nil {
+				return
+			}
+
+			countries := file.ZoneCountries[name]
+			if len(countries) == 0 && target != "" {
+				countries = file.ZoneCountries[target]
+			}
+
+			var countryNames []string
+			for _, code := range countries {
+				countryNames = append(countryNames, normalizeZoneText(file.CountryNames[code]))
+			}
+
+			text := append([]string{normalizeZoneText(name), normalizeZoneText(target)}, countryNames...)
+
+			catalog.entries = append(catalog.entries, zoneEntry{
+				name:         name,
+				linkTarget:   target,
+				countries:    countries,
+				countryNames: countryNames,
+				searchText:   strings.Join(text, " | "),
+				loc:          loc,
+			})
+		}
+
+		for _, name := range file.Zones {
+			add(name, "")
+		}
+		for name, target := range file.Links {
+			add(name, target)
+		}
+
+		sort.Slice(catalog.entries, func(i, j int) bool {
+			return catalog.entries[i].name < catalog.entries[j].name
+		})
+
+		loadedZoneCatalog = catalog
+	})
+
+	return loadedZoneCatalog
+}
+
+// ListTimezones lists available IANA timezones, optionally filtered by a
+// region prefix (e.g., "America" or "Europe/") and an ISO 3166 country code
+func (ts *timeService) ListTimezones(region, country string, includeLinks bool) (*TimezoneList, error) {
+	catalog := getZoneCatalog()
+	region = strings.ToLower(strings.TrimSpace(region))
+	country = strings.ToUpper(strings.TrimSpace(country))
+
+	if country != "" {
+		if _, ok := catalog.countryNames[country]; !ok {
+			return nil, NewTimeServiceError(
+				ErrCodeInvalidTimezone,
+				fmt.Sprintf("unknown country code '%s': expected an ISO 3166 alpha-2 code such as 'US'", country),
+				"country",
+				nil,
+			)
+		}
+	}
+
+	now := time.Now()
+	result := &TimezoneList{Version: catalog.version, Timezones: []TimezoneInfo{}}
+	for _, entry := range catalog.entries {
+		if entry.linkTarget != "" && !includeLinks {
+			continue
+		}
+		if region != "" && !strings.HasPrefix(strings.ToLower(entry.name), region) {
+			continue
+		}
+		if country != "" && !slices.Contains(entry.countries, country) {
+			continue
+		}
+		result.Timezones = append(result.Timezones, entry.info(now))
+	}
+	result.Count = len(result.Timezones)
+
+	return result, nil
+}
+
+// SearchTimezones finds timezones matching a free-text query such as
+// "new york", "kolkata" or "india", ranked by how closely they match
+func (ts *timeService) SearchTimezones(query string, limit int) (*TimezoneList, error) {
+	q := normalizeZoneText(query)
+	if q == "" {
+		return nil, NewTimeServiceError(
+			ErrCodeInvalidTimezone,
+			"search query cannot be empty",
+			"query",
+			nil,
+		)
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	catalog := getZoneCatalog()
+	matches := catalog.search(q, 1)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	now := time.Now()
+	result := &TimezoneList{Version: catalog.version, Timezones: make([]TimezoneInfo, len(matches))}
+	for i, entry := range matches {
+		result.Timezones[i] = entry.info(now)
+	}
+	result.Count = len(result.Timezones)
+
+	return result, nil
+}
+
+// search returns the entries scoring at least minScore for a normalized query, best matches first
+func (c *zoneCatalog) search(q string, minScore int) []zoneEntry {
+	type scored struct {
+		entry zoneEntry
+		score int
+	}
+
+	var results []scored
+	for _, entry := range c.entries {
+		if score := entry.matchScore(q); score > 0 && score >= minScore {
+			results = append(results, scored{entry, score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		// Prefer canonical zones over links with the same score
+		if (results[i].entry.linkTarget == "") != (results[j].entry.linkTarget == "") {
+			return results[i].entry.linkTarget == ""
+		}
+		return results[i].entry.name < results[j].entry.name
+	})
+
+	entries := make([]zoneEntry, len(results))
+	for i, r := range results {
+		entries[i] = r.entry
+	}
+	return entries
+}
+
+// matchScore scores how well the entry matches a normalized query, 0 meaning no match
+func (e zoneEntry) matchScore(q string) int {
+	name := normalizeZoneText(e.name)
+	city := normalizeZoneText(e.name[strings.LastIndex(e.name, "/")+1:])
+
+	switch {
+	case name == q || city == q:
+		return 100
+	case slices.Contains(e.countryNames, q) || slices.Contains(e.countries, strings.ToUpper(q)):
+		return 90
+	case strings.HasPrefix(city, q):
+		return 80
+	case strings.Contains(name, q):
+		return 60
+	case strings.Contains(e.searchText, q):
+		return 50
+	case len(q) >= 4 && levenshtein(city, q) <= len(q)/4+1:
+		return 30
+	case isSubsequence(q, name):
+		return 10
+	}
+
+	return 0
+}
+
+// info returns the current offset and DST state of the zone
+func (e zoneEntry) info(now time.Time) TimezoneInfo {
+	t := now.In(e.loc)
+	abbreviation, offset := t.Zone()
+
+	return TimezoneInfo{
+		Name:          e.name,
+		Canonical:     e.linkTarget == "",
+		LinkTarget:    e.linkTarget,
+		Countries:     e.countries,
+		Offset:        t.Format("-07:00"),
+		OffsetSeconds: offset,
+		Abbreviation:  abbreviation,
+		IsDST:         t.IsDST(),
+	}
+}
+
+// suggestTimezones returns up to three zone names similar to an unknown timezone
+func suggestTimezones(timezone string) []string {
+	q := normalizeZoneText(timezone)
+	if q == "" {
+		return nil
+	}
+
+	var suggestions []string
+	// Skip weak subsequence matches, which are rarely what was meant
+	for _, entry := range getZoneCatalog().search(q, 30) {
+		if entry.linkTarget != "" {
+			continue
+		}
+		suggestions = append(suggestions, entry.name)
+		if len(suggestions) == 3 {
+			break
+		}
+	}
+	return suggestions
+}
+
+// normalizeZoneText lowercases text and turns zone name separators into spaces
+func normalizeZoneText(s string) string {
+	s = strings.ToLower(s)
+	s = strings.NewReplacer("_", " ", "/", " ", "-", " ").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// isSubsequence reports whether every character of q appears in s in order
+func isSubsequence(q, s string) bool {
+	i := 0
+	for j := 0; j < len(s) && i < len(q); j++ {
+		if s[j] == q[i] {
+			i++
+		}
+	}
+	return i == len(q)
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTimeService_ListTimezones(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		name         string
+		region       string
+		country      string
+		includeLinks bool
+		contains     string
+		excludes     string
+		wantErr      bool
+	}{
+		{
+			name:     "Region prefix",
+			region:   "Europe",
+			contains: "Europe/Berlin",
+			excludes: "America/New_York",
+		},
+		{
+			name:     "Country code",
+			country:  "in",
+			contains: "Asia/Kolkata",
+			excludes: "Asia/Tokyo",
+		},
+		{
+			name:     "Links excluded by default",
+			region:   "US/",
+			excludes: "US/Eastern",
+		},
+		{
+			name:         "Links included",
+			region:       "US/",
+			includeLinks: true,
+			contains:     "US/Eastern",
+		},
+		{
+			name:    "Unknown country",
+			country: "XX",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ts.ListTimezones(tt.region, tt.country, tt.includeLinks)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			names := make(map[string]TimezoneInfo)
+			for _, tz := range result.Timezones {
+				names[tz.Name] = tz
+			}
+
+			if tt.contains != "" {
+				if _, ok := names[tt.contains]; !ok {
+					t.Errorf("Expected %s in results", tt.contains)
+				}
+			}
+
+			if tt.excludes != "" {
+				if _, ok := names[tt.excludes]; ok {
+					t.Errorf("Did not expect %s in results", tt.excludes)
+				}
+			}
+
+			if result.Count != len(result.Timezones) {
+				t.Errorf("Count %d does not match %d results", result.Count, len(result.Timezones))
+			}
+		})
+	}
+}
+
+func TestTimeService_SearchTimezones(t *testing.T) {
+	ts := NewTimeService()
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"new york", "America/New_York"},
+		{"kolkata", "Asia/Kolkata"},
+		{"Los Angeles", "America/Los_Angeles"},
+		{"india", "Asia/Kolkata"},
+		{"sao paulo", "America/Sao_Paulo"},
+		{"tokoy", "Asia/Tokyo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			result, err := ts.SearchTimezones(tt.query, 5)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(result.Timezones) == 0 || result.Timezones[0].Name != tt.expected {
+				t.Errorf("Expected %s as best match, got %+v", tt.expected, result.Timezones)
+			}
+		})
+	}
+
+	result, err := ts.SearchTimezones("calcutta", 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Timezones) == 0 || result.Timezones[0].Canonical || result.Timezones[0].LinkTarget != "Asia/Kolkata" {
+		t.Errorf("Expected Asia/Calcutta link to Asia/Kolkata, got %+v", result.Timezones)
+	}
+
+	if _, err := ts.SearchTimezones("  ", 5); err == nil {
+		t.Errorf("Expected error for empty query, but got none")
+	}
+}
+
+func TestTimeService_ValidateTimezoneSuggestions(t *testing.T) {
+	ts := NewTimeService()
+
+	err := ts.ValidateTimezone("America/New York")
+	if err == nil {
+		t.Fatalf("Expected error for timezone with a space, but got none")
+	}
+
+	if !strings.Contains(err.Error(), "America/New_York") {
+		t.Errorf("Expected suggestion for America/New_York, got %v", err)
+	}
+}
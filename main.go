@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // Embedded timezone database, used when the system has none
 
 	"github.com/zodimo/go-time-mcp/internal/config"
 	"github.com/zodimo/go-time-mcp/internal/server"
@@ -19,8 +20,35 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Load custom business calendar if configured
+	var opts []services.Option
+	if cfg.HolidaysFile != "" {
+		calendar, err := services.LoadHolidayCalendarFile(cfg.HolidaysFile)
+		if err != nil {
+			log.Fatalf("Failed to load holidays file: %v", err)
+		}
+		log.Printf("Loaded business calendar '%s' from %s", calendar.ID(), cfg.HolidaysFile)
+		opts = append(opts, services.WithBusinessCalendar(calendar))
+	}
+
+	// Load custom timezone abbreviations if configured
+	if cfg.AbbreviationsFile != "" {
+		abbreviations, err := services.LoadTimezoneAbbreviationsFile(cfg.AbbreviationsFile)
+		if err != nil {
+			log.Fatalf("Failed to load abbreviations file: %v", err)
+		}
+		resolver, err := services.NewZoneResolver(abbreviations)
+		if err != nil {
+			log.Fatalf("Invalid abbreviations file: %v", err)
+		}
+		log.Printf("Loaded %d timezone abbreviations from %s", len(abbreviations), cfg.AbbreviationsFile)
+		opts = append(opts, services.WithZoneResolver(resolver))
+	}
+
+	opts = append(opts, services.WithMaxOccurrences(cfg.MaxOccurrences))
+
 	// Create time service
-	timeService := services.NewTimeService()
+	timeService := services.NewTimeService(opts...)
 
 	// Create MCP server
 	mcpServer, err := server.NewServer(cfg, timeService)